@@ -3,16 +3,13 @@ module golang.org/x/tools
 go 1.18 // tagx:compat 1.16
 
 require (
+	github.com/goplus/gogen v1.16.0
 	github.com/goplus/gop v1.3.0-pre.2
 	github.com/goplus/mod v0.13.12
+	github.com/qiniu/x v1.13.10
 	github.com/yuin/goldmark v1.7.8
 	golang.org/x/mod v0.19.0
 	golang.org/x/net v0.29.0
 	golang.org/x/sync v0.8.0
 	golang.org/x/sys v0.25.0
 )
-
-require (
-	github.com/goplus/gogen v1.16.0 // indirect
-	github.com/qiniu/x v1.13.10 // indirect
-)
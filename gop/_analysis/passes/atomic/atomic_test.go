@@ -1,22 +0,0 @@
-// Copyright 2018 The Go Authors. All rights reserved.
-// Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.
-
-package atomic_test
-
-import (
-	"testing"
-
-	"golang.org/x/tools/gop/analysis/analysistest"
-	"golang.org/x/tools/gop/analysis/passes/atomic"
-	"golang.org/x/tools/internal/typeparams"
-)
-
-func Test(t *testing.T) {
-	testdata := analysistest.TestData()
-	tests := []string{"a"}
-	if typeparams.Enabled {
-		tests = append(tests, "typeparams")
-	}
-	analysistest.Run(t, testdata, atomic.Analyzer, tests...)
-}
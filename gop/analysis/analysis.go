@@ -5,6 +5,7 @@
 package analysis
 
 import (
+	"encoding/gob"
 	"flag"
 	"fmt"
 	"reflect"
@@ -75,6 +76,25 @@ func FactTypes(a IAnalyzer) []Fact {
 	return a.(*GoAnalyzer).FactTypes
 }
 
+// RegisterFacts registers the fact types of analyzer a with encoding/gob.
+// The fact types of a Go+ analyzer are registered under their package path,
+// as their names, such as *printf.isWrapper, are usually the same as those of
+// the Go analyzer they are ported from.
+func RegisterFacts(a IAnalyzer) {
+	_, gop := a.(*Analyzer)
+	for _, f := range FactTypes(a) {
+		if gop {
+			t := reflect.TypeOf(f)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			gob.RegisterName("*"+t.PkgPath()+"."+t.Name(), f)
+		} else {
+			gob.Register(f)
+		}
+	}
+}
+
 // Requires returns analyzer Requires.
 func Requires(a IAnalyzer) []IAnalyzer {
 	if i, ok := a.(*Analyzer); ok {
@@ -24,6 +24,7 @@ import (
 	"github.com/goplus/gop/token"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/internal/checker"
+	"golang.org/x/tools/gop/goputil"
 	"golang.org/x/tools/gop/packages"
 	"golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/internal/testenv"
@@ -169,6 +170,11 @@ func RunWithSuggestedFixes(t Testing, dir string, a analysis.IAnalyzer, patterns
 				continue
 			}
 
+			// Classfiles, including the ones registered by the Go+
+			// module of the file, are formatted as such.
+			mod, _, _ := goputil.LoadMod(filepath.Dir(file.Name()))
+			class := goputil.FileKindOf(mod, file.Name()) == goputil.FileGopClass
+
 			if len(ar.Files) > 0 {
 				// one virtual file per kind of suggested fix
 
@@ -195,7 +201,7 @@ func RunWithSuggestedFixes(t Testing, dir string, a analysis.IAnalyzer, patterns
 							// between files in the archive. normalize
 							// this to a single newline.
 							want := string(bytes.TrimRight(vf.Data, "\n")) + "\n"
-							formatted, err := format.Source(out, true)
+							formatted, err := format.Source(out, class, file.Name())
 							if err != nil {
 								t.Errorf("%s: error formatting edited source: %v\n%s", file.Name(), err, out)
 								continue
@@ -226,7 +232,7 @@ func RunWithSuggestedFixes(t Testing, dir string, a analysis.IAnalyzer, patterns
 				}
 				want := string(ar.Comment)
 
-				formatted, err := format.Source(out, true)
+				formatted, err := format.Source(out, class, file.Name())
 				if err != nil {
 					t.Errorf("%s: error formatting resulting source: %v\n%s", file.Name(), err, out)
 					continue
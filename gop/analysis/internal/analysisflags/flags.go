@@ -8,7 +8,6 @@ package analysisflags
 
 import (
 	"crypto/sha256"
-	"encoding/gob"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -41,6 +40,22 @@ var (
 // only reachable from dropped analyzers.
 // This is not a particularly elegant API, but this is an internal package.
 func Parse(analyzers []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
+	return parse(analyzers, nil, multi)
+}
+
+// ParseWithDisabled is like Parse in multi mode, but the disabled analyzers
+// are off by default: they are kept only if enabled by their -NAME flag.
+func ParseWithDisabled(analyzers, disabled []analysis.IAnalyzer) []analysis.IAnalyzer {
+	return parse(analyzers, disabled, true)
+}
+
+func parse(analyzers, disabled []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
+	off := make(map[analysis.IAnalyzer]bool)
+	for _, a := range disabled {
+		off[a] = true
+	}
+	analyzers = append(analyzers[:len(analyzers):len(analyzers)], disabled...)
+
 	// Connect each analysis flag to the command line as -analysis.flag.
 	enabled := make(map[analysis.IAnalyzer]*triState)
 	for _, a := range analyzers {
@@ -53,6 +68,9 @@ func Parse(analyzers []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
 
 			enable := new(triState)
 			enableUsage := "enable " + aName + " analysis"
+			if off[a] {
+				enableUsage += " (disabled by default)"
+			}
 			flag.Var(enable, aName, enableUsage)
 			enabled[a] = enable
 		}
@@ -100,7 +118,8 @@ func Parse(analyzers []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
 	everything := expand(analyzers)
 
 	// If any -NAME flag is true,  run only those analyzers. Otherwise,
-	// if any -NAME flag is false, run all but those analyzers.
+	// run all but those whose -NAME flag is false, or which are disabled
+	// by default.
 	if multi {
 		var hasTrue, hasFalse bool
 		for _, ts := range enabled {
@@ -120,9 +139,9 @@ func Parse(analyzers []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
 				}
 			}
 			analyzers = keep
-		} else if hasFalse {
+		} else if hasFalse || len(off) > 0 {
 			for _, a := range analyzers {
-				if *enabled[a] != setFalse {
+				if *enabled[a] != setFalse && !off[a] {
 					keep = append(keep, a)
 				}
 			}
@@ -135,9 +154,7 @@ func Parse(analyzers []analysis.IAnalyzer, multi bool) []analysis.IAnalyzer {
 	kept := expand(analyzers)
 	for a := range everything {
 		if !kept[a] {
-			for _, f := range analysis.FactTypes(a) {
-				gob.Register(f)
-			}
+			analysis.RegisterFacts(a)
 		}
 	}
 
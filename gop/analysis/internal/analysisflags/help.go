@@ -26,18 +26,18 @@ genuine problems, but it can find errors not caught by the compilers.
 // Help implements the help subcommand for a multichecker or unitchecker
 // style command. The optional args specify the analyzers to describe.
 // Help calls log.Fatal if no such analyzer exists.
-func Help(progname string, analyzers []*analysis.Analyzer, args []string) {
+func Help(progname string, analyzers []analysis.IAnalyzer, args []string) {
 	// No args: show summary of all analyzers.
 	if len(args) == 0 {
 		fmt.Println(strings.Replace(help, "PROGNAME", progname, -1))
 		fmt.Println("Registered analyzers:")
 		fmt.Println()
 		sort.Slice(analyzers, func(i, j int) bool {
-			return analyzers[i].Name < analyzers[j].Name
+			return analysis.Name(analyzers[i]) < analysis.Name(analyzers[j])
 		})
 		for _, a := range analyzers {
-			title := strings.Split(a.Doc, "\n\n")[0]
-			fmt.Printf("    %-12s %s\n", a.Name, title)
+			title := strings.Split(analysis.Doc(a), "\n\n")[0]
+			fmt.Printf("    %-12s %s\n", analysis.Name(a), title)
 		}
 		fmt.Println("\nBy default all analyzers are run.")
 		fmt.Println("To select specific analyzers, use the -NAME flag for each one,")
@@ -64,22 +64,23 @@ func Help(progname string, analyzers []*analysis.Analyzer, args []string) {
 outer:
 	for _, arg := range args {
 		for _, a := range analyzers {
-			if a.Name == arg {
-				paras := strings.Split(a.Doc, "\n\n")
+			name := analysis.Name(a)
+			if name == arg {
+				paras := strings.Split(analysis.Doc(a), "\n\n")
 				title := paras[0]
-				fmt.Printf("%s: %s\n", a.Name, title)
+				fmt.Printf("%s: %s\n", name, title)
 
 				// Show only the flags relating to this analysis,
 				// properly prefixed.
 				first := true
-				fs := flag.NewFlagSet(a.Name, flag.ExitOnError)
-				a.Flags.VisitAll(func(f *flag.Flag) {
+				fs := flag.NewFlagSet(name, flag.ExitOnError)
+				analysis.Flags(a).VisitAll(func(f *flag.Flag) {
 					if first {
 						first = false
 						fmt.Println("\nAnalyzer flags:")
 						fmt.Println()
 					}
-					fs.Var(f.Value, a.Name+"."+f.Name, f.Usage)
+					fs.Var(f.Value, name+"."+f.Name, f.Usage)
 				})
 				fs.SetOutput(os.Stdout)
 				fs.PrintDefaults()
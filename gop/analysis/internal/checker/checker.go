@@ -761,18 +761,14 @@ func (act *action) execOnce() {
 			// Same analysis, different package (vertical edge):
 			// serialized facts produced by prerequisite analysis
 			// become available to this analysis pass.
+			act.initFacts()
 			inheritFacts(act, dep)
 		}
 	}
 	if act.diagnosticsRet == nil {
 		act.diagnosticsRet = new(diagnosticsRet)
 	}
-	if act.objectFacts == nil {
-		act.objectFacts = make(map[objectFactKey]analysis.Fact)
-	}
-	if act.packageFacts == nil {
-		act.packageFacts = make(map[packageFactKey]analysis.Fact)
-	}
+	act.initFacts()
 
 	// Run the analysis.
 	pass := &analysis.Pass{
@@ -850,6 +846,17 @@ func (act *action) execOnce() {
 	pass.ExportPackageFact = nil
 }
 
+// initFacts allocates the facts of act, unless they are shared with
+// (or already inherited from) its dependencies.
+func (act *action) initFacts() {
+	if act.objectFacts == nil {
+		act.objectFacts = make(map[objectFactKey]analysis.Fact)
+	}
+	if act.packageFacts == nil {
+		act.packageFacts = make(map[packageFactKey]analysis.Fact)
+	}
+}
+
 // inheritFacts populates act.facts with
 // those it obtains from its dependency, dep.
 func inheritFacts(act, dep *action) {
@@ -5,6 +5,11 @@
 // Package multichecker defines the main function for an analysis driver
 // with several analyzers. This package makes it easy for anyone to build
 // an analysis tool containing just the analyzers they need.
+//
+// The analyzers may be Go+ analyzers (*analysis.Analyzer) as well as Go
+// analyzers (*analysis.GoAnalyzer). Packages are loaded with
+// golang.org/x/tools/gop/packages, so Go+ analyzers see the Go+ files of
+// a package and report diagnostics at their positions.
 package multichecker
 
 import (
@@ -21,18 +26,30 @@ import (
 	"golang.org/x/tools/gop/analysis/unitchecker"
 )
 
-func Main(analyzers ...*analysis.Analyzer) {
+// Main is the main function of an analysis tool running the specified
+// analyzers. It loads the packages named by its command-line arguments,
+// or analyzes the unit described by a *.cfg file when run by "go vet",
+// and calls os.Exit with an appropriate error code.
+func Main(analyzers ...analysis.IAnalyzer) {
+	MainWithDisabled(nil, analyzers...)
+}
+
+// MainWithDisabled is like Main, but the disabled analyzers are off by
+// default: they run only when enabled by their -NAME flag, as in
+//
+//	$ gopvet -gopShadow ./...
+func MainWithDisabled(disabled []analysis.IAnalyzer, analyzers ...analysis.IAnalyzer) {
 	progname := filepath.Base(os.Args[0])
 	log.SetFlags(0)
 	log.SetPrefix(progname + ": ") // e.g. "vet: "
 
-	if err := analysis.Validate(analyzers); err != nil {
+	if err := analysis.Validate(append(analyzers[:len(analyzers):len(analyzers)], disabled...)); err != nil {
 		log.Fatal(err)
 	}
 
 	checker.RegisterFlags()
 
-	analyzers = analysisflags.Parse(analyzers, true)
+	analyzers = analysisflags.ParseWithDisabled(analyzers, disabled)
 
 	args := flag.Args()
 	if len(args) == 0 {
@@ -0,0 +1,199 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.12
+// +build go1.12
+
+package multichecker_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/multichecker"
+	"golang.org/x/tools/gop/analysis/passes/assign"
+	"golang.org/x/tools/gop/analysis/passes/findcall"
+	"golang.org/x/tools/gop/packages/packagestest"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func main() {
+	fail := &analysis.Analyzer{
+		Name: "fail",
+		Doc:  "always fail on a package 'golang.org/fake/b'",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			if pass.Pkg.Path() == "golang.org/fake/b" {
+				return nil, fmt.Errorf("failed")
+			}
+			return nil, nil
+		},
+	}
+	off := &analysis.Analyzer{
+		Name: "off",
+		Doc:  "always report a diagnostic, but is disabled by default",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			pass.Reportf(pass.GopFiles[0].Package, "off")
+			return nil, nil
+		},
+	}
+	multichecker.MainWithDisabled([]analysis.IAnalyzer{off}, findcall.Analyzer, assign.Analyzer, fail)
+}
+
+// The Go+ packages analyzed by the tests below. gop_autogen.go is their
+// Go form, as generated by gop.
+var files = map[string]interface{}{
+	"a/a.gop": `package a
+
+func _() {
+	MyFunc123()
+}
+
+func MyFunc123() {}
+`,
+	"a/gop_autogen.go": `package a
+
+func _() {
+	MyFunc123()
+}
+func MyFunc123() {
+}
+`,
+	"b/b.gop": `package b
+
+func _() {
+	panic("b")
+}
+`,
+	"b/gop_autogen.go": `package b
+
+func _() {
+	panic("b")
+}
+`,
+	"c/c.gop": `package c
+
+func _() {
+	i := 5
+	i = i
+	_ = i
+}
+`,
+	"c/gop_autogen.go": `package c
+
+func _() {
+	i := 5
+	i = i
+	_ = i
+}
+`,
+}
+
+func export(t *testing.T) *packagestest.Exported {
+	return packagestest.Export(t, packagestest.Modules, []packagestest.Module{{
+		Name:  "golang.org/fake",
+		Files: files,
+	}})
+}
+
+// runMain fork/execs the main function above with the specified args,
+// in the directory of exported, and returns its exit code.
+func runMain(t *testing.T, exported *packagestest.Exported, args ...string) int {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=TestExitCode", "--"}, args...)...)
+	cmd.Env = append(exported.Config.Env, "MULTICHECKER_CHILD=1")
+	cmd.Dir = exported.Config.Dir
+	out, err := cmd.CombinedOutput()
+	if len(out) > 0 {
+		t.Logf("%s: out=<<%s>>", args, out)
+	}
+	var exitcode int
+	if err, ok := err.(*exec.ExitError); ok {
+		exitcode = err.ExitCode() // requires go1.12
+	}
+	return exitcode
+}
+
+// TestExitCode ensures that analysis failures are reported correctly.
+// This test fork/execs the main function above.
+func TestExitCode(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping fork/exec test on this platform")
+	}
+
+	if os.Getenv("MULTICHECKER_CHILD") == "1" {
+		// child process
+
+		// replace [progname -test.run=TestExitCode -- ...]
+		//      by [progname ...]
+		os.Args = os.Args[2:]
+		os.Args[0] = "vet"
+		main()
+		panic("unreachable")
+	}
+
+	testenv.NeedsTool(t, "go")
+
+	exported := export(t)
+	defer exported.Cleanup()
+
+	for _, test := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{"./nosuchdir/..."}, 1},                                                 // matched no packages
+		{[]string{"golang.org/fake/nosuchpkg"}, 1},                                       // matched no packages
+		{[]string{"-unknownflag"}, 2},                                                    // flag error
+		{[]string{"-gopFindcall.name=MyFunc123", "./a"}, 3},                              // finds diagnostics
+		{[]string{"-gopAssign", "./c"}, 3},                                               // finds diagnostics of an enabled analyzer
+		{[]string{"-gopFindcall", "-gopFindcall.name=MyFunc123", "./c"}, 0},              // no diagnostics of the enabled analyzer
+		{[]string{"-gopFindcall=0", "-gopAssign=0", "-fail=0", "./a", "./c"}, 0},         // no checkers, 'off' is disabled
+		{[]string{"-off", "./a"}, 3},                                                     // finds diagnostics of a disabled analyzer
+		{[]string{"-gopFindcall.name=nosuchfunc", "./a"}, 0},                             // no diagnostics
+		{[]string{"-gopFindcall.name=MyFunc123", "./b", "./a"}, 1},                       // 'fail' failed on 'b'
+		{[]string{"-gopFindcall.name=MyFunc123", "-gopAssign=0", "-fail=0", "./..."}, 3}, // finds diagnostics in ./...
+
+		// -json: exits zero even in face of diagnostics or package errors.
+		{[]string{"-gopFindcall.name=MyFunc123", "-json", "./a"}, 0},
+		{[]string{"-gopFindcall.name=MyFunc123", "-json", "./b", "./a"}, 0},
+	} {
+		if got := runMain(t, exported, test.args...); got != test.want {
+			t.Errorf("%s: exited %d, want %d", test.args, got, test.want)
+		}
+	}
+}
+
+// TestFix ensures that -fix applies the suggested fixes to the Go+ files.
+// This test fork/execs the main function above.
+func TestFix(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping fork/exec test on this platform")
+	}
+
+	testenv.NeedsTool(t, "go")
+
+	exported := export(t)
+	defer exported.Cleanup()
+
+	if got := runMain(t, exported, "-gopAssign", "-fix", "./c"); got != 3 {
+		t.Errorf("-fix: exited %d, want 3", got)
+	}
+	const want = `package c
+
+func _() {
+	i := 5
+
+	_ = i
+}
+`
+	got, err := os.ReadFile(exported.File("golang.org/fake", "c/c.gop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("-fix: got <<%s>>, want <<%s>>", got, want)
+	}
+}
@@ -8,7 +8,6 @@ package appends
 
 import (
 	_ "embed"
-	"go/types"
 
 	"github.com/goplus/gop/ast"
 	"golang.org/x/tools/go/analysis/passes/appends"
@@ -38,7 +37,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
 		if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "append" {
-			if _, ok := pass.GopTypesInfo.Uses[ident].(*types.Builtin); ok {
+			if analysisutil.IsBuiltin(pass.GopTypesInfo.Uses[ident], "append") {
 				if len(call.Args) == 1 {
 					pass.ReportRangef(call, "append with no values")
 				}
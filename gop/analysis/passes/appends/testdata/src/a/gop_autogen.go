@@ -0,0 +1,48 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+const _ = true
+//line a.gop:9:1
+func badAppendSlice1() {
+//line a.gop:10:1
+	sli := []string{"a", "b", "c"}
+//line a.gop:11:1
+	sli = append(sli)
+}
+//line a.gop:14:1
+func badAppendSlice2() {
+//line a.gop:15:1
+	_ = append([]string{"a"})
+}
+//line a.gop:18:1
+func goodAppendSlice1() {
+//line a.gop:19:1
+	sli := []string{"a", "b", "c"}
+//line a.gop:20:1
+	sli = append(sli, "d")
+}
+//line a.gop:23:1
+func goodAppendSlice2() {
+//line a.gop:24:1
+	sli1 := []string{"a", "b", "c"}
+//line a.gop:25:1
+	sli2 := []string{"d", "e", "f"}
+//line a.gop:26:1
+	sli1 = append(sli1, sli2...)
+}
+//line a.gop:29:1
+func goodAppendSlice3() {
+//line a.gop:30:1
+	sli := []string{"a", "b", "c"}
+//line a.gop:31:1
+	sli = append(sli, "d", "e", "f")
+}
+//line a.gop:34:1
+func badAppendSliceInLambda(apply func(fn func())) {
+//line a.gop:35:1
+	apply(func() {
+//line a.gop:36:1
+		_ = append([]string{"a"})
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testdata
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type ST[T interface{ ~int }] struct {
+	x T
+	l []T
+}
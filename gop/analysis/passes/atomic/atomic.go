@@ -6,10 +6,11 @@ package atomic
 
 import (
 	_ "embed"
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/atomic"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -20,16 +21,16 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:             "atomic",
+	Name:             "gopAtomic",
 	Doc:              analysisutil.MustExtractDoc(doc, "atomic"),
 	URL:              "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/atomic",
-	Requires:         []*analysis.Analyzer{inspect.Analyzer},
+	Requires:         []analysis.IAnalyzer{atomic.Analyzer, inspect.Analyzer},
 	RunDespiteErrors: true,
 	Run:              run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	if !analysisutil.Imports(pass.Pkg, "sync/atomic") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "sync/atomic") {
 		return nil, nil // doesn't directly import sync/atomic
 	}
 
@@ -57,7 +58,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				continue
 			}
 			pkgIdent, _ := sel.X.(*ast.Ident)
-			pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+			pkgName, ok := pass.GopTypesInfo.Uses[pkgIdent].(*types.PkgName)
 			if !ok || pkgName.Imported().Path() != "sync/atomic" {
 				continue
 			}
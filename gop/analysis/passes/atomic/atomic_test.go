@@ -2,16 +2,16 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package atomicalign_test
+package atomic_test
 
 import (
 	"testing"
 
 	"golang.org/x/tools/gop/analysis/analysistest"
-	"golang.org/x/tools/gop/analysis/passes/atomicalign"
+	"golang.org/x/tools/gop/analysis/passes/atomic"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, atomicalign.Analyzer, "a", "b")
+	analysistest.Run(t, testdata, atomic.Analyzer, "a", "typeparams")
 }
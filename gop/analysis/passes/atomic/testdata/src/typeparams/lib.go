@@ -0,0 +1,19 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type S[T any] struct {
+	x *T
+}
+
+func (v S[T]) AddInt64(_ *int64, delta int64) int64 {
+	return delta
+}
+
+func Ptr[T any](v T) *T {
+	return &v
+}
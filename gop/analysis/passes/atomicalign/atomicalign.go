@@ -10,10 +10,11 @@
 package atomicalign
 
 import (
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/atomicalign"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -23,10 +24,10 @@ import (
 const Doc = "check for non-64-bits-aligned arguments to sync/atomic functions"
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "atomicalign",
+	Name:     "gopAtomicalign",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/atomicalign",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{atomicalign.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -34,7 +35,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	if 8*pass.TypesSizes.Sizeof(types.Typ[types.Uintptr]) == 64 {
 		return nil, nil // 64-bit platform
 	}
-	if !analysisutil.Imports(pass.Pkg, "sync/atomic") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "sync/atomic") {
 		return nil, nil // doesn't directly import sync/atomic
 	}
 
@@ -53,7 +54,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		if !ok {
 			return
 		}
-		pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+		pkgName, ok := pass.GopTypesInfo.Uses[pkgIdent].(*types.PkgName)
 		if !ok || pkgName.Imported().Path() != "sync/atomic" {
 			return
 		}
@@ -88,12 +89,13 @@ func check64BitAlignment(pass *analysis.Pass, funcName string, arg ast.Expr) {
 	if !ok {
 		return
 	}
-	tvar, ok := pass.TypesInfo.Selections[sel].Obj().(*types.Var)
+	// Go+ doesn't record Selections: use the object of the field identifier.
+	tvar, ok := pass.GopTypesInfo.Uses[sel.Sel].(*types.Var)
 	if !ok || !tvar.IsField() {
 		return
 	}
 
-	stype, ok := pass.TypesInfo.Types[sel.X].Type.Underlying().(*types.Struct)
+	stype, ok := pass.GopTypesInfo.Types[sel.X].Type.Underlying().(*types.Struct)
 	if !ok {
 		return
 	}
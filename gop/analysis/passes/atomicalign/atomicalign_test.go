@@ -0,0 +1,26 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atomicalign_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gop/analysis/analysistest"
+	"golang.org/x/tools/gop/analysis/passes/atomicalign"
+)
+
+func Test(t *testing.T) {
+	// Go+ files have no build constraints: a is checked on a 32-bit
+	// platform and b on a 64-bit one.
+	testdata := analysistest.TestData()
+	t.Run("386", func(t *testing.T) {
+		t.Setenv("GOARCH", "386")
+		analysistest.Run(t, testdata, atomicalign.Analyzer, "a")
+	})
+	t.Run("amd64", func(t *testing.T) {
+		t.Setenv("GOARCH", "amd64")
+		analysistest.Run(t, testdata, atomicalign.Analyzer, "b")
+	})
+}
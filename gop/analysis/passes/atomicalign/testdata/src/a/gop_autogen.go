@@ -0,0 +1,281 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package testdata
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+const _ = true
+
+type ts struct {
+	e  int64
+	e2 []int
+	f  uint64
+}
+//line a.gop:16:1
+func intsAlignment() {
+//line a.gop:17:1
+	var s struct {
+		a bool
+		b uint8
+		c int8
+		d byte
+		f int16
+		g int16
+		h int64
+		i byte
+		j uint64
+	}
+//line a.gop:28:1
+	atomic.AddInt64(&s.h, 9)
+//line a.gop:29:1
+	atomic.AddUint64(&s.j, 0)
+}
+//line a.gop:32:1
+func floatAlignment() {
+//line a.gop:33:1
+	var s struct {
+		a float32
+		b int64
+		c float32
+		d float64
+		e uint64
+	}
+//line a.gop:40:1
+	atomic.LoadInt64(&s.b)
+//line a.gop:41:1
+	atomic.LoadUint64(&s.e)
+}
+//line a.gop:44:1
+func uintptrAlignment() {
+//line a.gop:45:1
+	var s struct {
+		a uintptr
+		b int64
+		c int
+		d uint
+		e int32
+		f uint64
+	}
+//line a.gop:53:1
+	atomic.StoreInt64(&s.b, 0)
+//line a.gop:54:1
+	atomic.StoreUint64(&s.f, 0)
+}
+//line a.gop:57:1
+func runeAlignment() {
+//line a.gop:58:1
+	var s struct {
+		a rune
+		b int64
+		_ rune
+		c uint64
+	}
+//line a.gop:64:1
+	atomic.SwapInt64(&s.b, 0)
+//line a.gop:65:1
+	atomic.SwapUint64(&s.c, 0)
+}
+//line a.gop:68:1
+func complexAlignment() {
+//line a.gop:69:1
+	var s struct {
+		a complex64
+		b int64
+		c complex128
+		d uint64
+	}
+//line a.gop:75:1
+	atomic.CompareAndSwapInt64(&s.b, 0, 1)
+//line a.gop:76:1
+	atomic.CompareAndSwapUint64(&s.d, 0, 1)
+}
+//line a.gop:81:1
+func channelAlignment() {
+//line a.gop:82:1
+	var a struct {
+		a chan struct {
+		}
+		b int64
+		c <-chan struct {
+		}
+		d uint64
+	}
+//line a.gop:89:1
+	atomic.AddInt64(&a.b, 0)
+//line a.gop:90:1
+	atomic.AddUint64(&a.d, 0)
+}
+//line a.gop:93:1
+func arrayAlignment() {
+//line a.gop:94:1
+	var a struct {
+		a [1]uint16
+		b int64
+		_ [2]uint16
+		c int64
+		d [1]uint16
+		e uint64
+	}
+//line a.gop:103:1
+	atomic.LoadInt64(&a.b)
+//line a.gop:104:1
+	atomic.LoadInt64(&a.c)
+//line a.gop:105:1
+	atomic.LoadUint64(&a.e)
+}
+//line a.gop:108:1
+func anonymousFieldAlignment() {
+//line a.gop:109:1
+	var f struct {
+		a int32
+		b int32
+		c int64
+		d int64
+		_ bool
+		e uint64
+		f uint64
+	}
+//line a.gop:116:1
+	atomic.StoreInt64(&f.c, 12)
+//line a.gop:117:1
+	atomic.StoreInt64(&f.d, 27)
+//line a.gop:118:1
+	atomic.StoreUint64(&f.e, 6)
+//line a.gop:119:1
+	atomic.StoreUint64(&f.f, 79)
+}
+//line a.gop:128:1
+func typedStructAlignment() {
+//line a.gop:129:1
+	var b ts
+//line a.gop:130:1
+	atomic.SwapInt64(&b.e, 9)
+//line a.gop:131:1
+	atomic.SwapUint64(&b.f, 9)
+}
+//line a.gop:134:1
+func aliasAlignment() {
+//line a.gop:135:1
+	type mybytea uint8
+//line a.gop:135:1
+	type mybyteb byte
+//line a.gop:135:1
+	type mybytec = uint8
+//line a.gop:135:1
+	type mybyted = byte
+//line a.gop:142:1
+	var e struct {
+		a byte
+		b mybytea
+		c mybyteb
+		e uint8
+		f int64
+		g uint16
+		h uint16
+		i uint64
+	}
+//line a.gop:152:1
+	atomic.CompareAndSwapInt64(&e.f, 0, 1)
+//line a.gop:153:1
+	atomic.CompareAndSwapUint64(&e.i, 1, 2)
+}
+//line a.gop:156:1
+func stringAlignment() {
+//line a.gop:157:1
+	var a struct {
+		a uint32
+		b string
+		c int64
+	}
+//line a.gop:162:1
+	atomic.AddInt64(&a.c, 10)
+}
+//line a.gop:165:1
+func sliceAlignment() {
+//line a.gop:166:1
+	var s struct {
+		a []int32
+		b int64
+		c uint32
+		d uint64
+	}
+//line a.gop:173:1
+	atomic.LoadInt64(&s.b)
+//line a.gop:174:1
+	atomic.LoadUint64(&s.d)
+}
+//line a.gop:177:1
+func interfaceAlignment() {
+//line a.gop:178:1
+	var s struct {
+		a interface{}
+		b int64
+		c io.Writer
+		e int64
+		_ int32
+		f uint64
+	}
+//line a.gop:187:1
+	atomic.StoreInt64(&s.b, 9)
+//line a.gop:188:1
+	atomic.StoreInt64(&s.e, 9)
+//line a.gop:189:1
+	atomic.StoreUint64(&s.f, 9)
+}
+//line a.gop:192:1
+func pointerAlignment() {
+//line a.gop:193:1
+	var s struct {
+		a *int
+		b *int
+		c int64
+		d *interface{}
+		e uint64
+	}
+//line a.gop:200:1
+	atomic.SwapInt64(&s.c, 9)
+//line a.gop:201:1
+	atomic.SwapUint64(&s.e, 9)
+}
+//line a.gop:204:1
+// non-struct fields are already 64-bits correctly aligned per Go spec
+func nonStructFields() {
+//line a.gop:206:1
+	var a *int64
+//line a.gop:206:1
+	var b [2]uint64
+//line a.gop:206:1
+	var c int64
+//line a.gop:212:1
+	atomic.CompareAndSwapInt64(a, 10, 11)
+//line a.gop:213:1
+	atomic.CompareAndSwapUint64(&b[0], 5, 23)
+//line a.gop:214:1
+	atomic.CompareAndSwapInt64(&c, -1, -15)
+}
+//line a.gop:217:1
+func embeddedStructFields() {
+//line a.gop:218:1
+	var s1 struct {
+		_ struct {
+			_ int32
+		}
+		a int64
+		_ struct {
+		}
+		b uint64
+		_ struct {
+			_ [2]uint16
+		}
+		c int64
+	}
+//line a.gop:227:1
+	atomic.AddInt64(&s1.a, 9)
+//line a.gop:228:1
+	atomic.AddUint64(&s1.b, 9)
+//line a.gop:229:1
+	atomic.AddInt64(&s1.c, 9)
+}
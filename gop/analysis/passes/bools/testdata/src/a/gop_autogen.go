@@ -0,0 +1,180 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "io"
+
+const _ = true
+
+type T int
+type FT func() int
+//line a.gop:13:1
+func (t T) Foo() int {
+//line a.gop:13:1
+	return int(t)
+}
+
+var S []int
+//line a.gop:19:1
+func RatherStupidConditions() {
+//line a.gop:20:1
+	var f, g func() int
+//line a.gop:21:1
+	if f() == 0 || f() == 0 {
+	}
+//line a.gop:23:1
+	var t T
+//line a.gop:24:1
+	_ = t.Foo() == 2 || t.Foo() == 2
+//line a.gop:25:1
+	if
+//line a.gop:25:1
+	v, w := f(), g(); v == w || v == w {
+	}
+//line a.gop:27:1
+	_ = f == nil || f == nil
+//line a.gop:29:1
+	var B byte
+//line a.gop:30:1
+	_ = B == byte(1) || B == byte(1)
+//line a.gop:31:1
+	_ = t == T(2) || t == T(2)
+//line a.gop:32:1
+	_ = FT(f) == nil || FT(f) == nil
+//line a.gop:34:1
+	_ = (func() int)(f) == nil || (func() int)(f) == nil
+//line a.gop:35:1
+	_ = append(S, 3) == nil || append(S, 3) == nil
+//line a.gop:37:1
+	var namedFuncVar FT
+//line a.gop:38:1
+	_ = namedFuncVar() == namedFuncVar()
+//line a.gop:40:1
+	var c chan int
+//line a.gop:41:1
+	_ = 0 == <-c || 0 == <-c
+//line a.gop:42:1
+	for
+//line a.gop:42:1
+	i, j := <-c, <-c; i == j || i == j;
+//line a.gop:42:1
+	i, j = <-c, <-c {
+	}
+//line a.gop:45:1
+	var i, j, k int
+//line a.gop:46:1
+	_ = i+1 == 1 || i+1 == 1
+//line a.gop:47:1
+	_ = i == 1 || j+1 == i || i == 1
+//line a.gop:49:1
+	_ = i == 1 || i == 1 || f() == 1
+//line a.gop:50:1
+	_ = i == 1 || f() == 1 || i == 1
+//line a.gop:51:1
+	_ = f() == 1 || i == 1 || i == 1
+//line a.gop:54:1
+	_ = f() == 1 || i == 1 || i == 1 || j == 1
+//line a.gop:55:1
+	_ = f() == 1 || j == 1 || i == 1 || i == 1
+//line a.gop:56:1
+	_ = i == 1 || f() == 1 || i == 1 || i == 1
+//line a.gop:57:1
+	_ = i == 1 || i == 1 || f() == 1 || i == 1
+//line a.gop:58:1
+	_ = i == 1 || i == 1 || j == 1 || f() == 1
+//line a.gop:59:1
+	_ = j == 1 || i == 1 || i == 1 || f() == 1
+//line a.gop:60:1
+	_ = i == 1 || f() == 1 || f() == 1 || i == 1
+//line a.gop:62:1
+	_ = i == 1 || (i == 1 || i == 2)
+//line a.gop:63:1
+	_ = i == 1 || (f() == 1 || i == 1)
+//line a.gop:64:1
+	_ = i == 1 || (i == 1 || f() == 1)
+//line a.gop:65:1
+	_ = i == 1 || (i == 2 || (i == 1 || i == 3))
+//line a.gop:67:1
+	var a, b bool
+//line a.gop:68:1
+	_ = i == 1 || (a || (i == 1 || b))
+//line a.gop:71:1
+	_ = j == 0 || i == 1 || f() == 1 || j == 0 || i == 1 || i == 1 || i == 1 || j == 0 || k == 0
+//line a.gop:81:1
+	_ = i == 1*2*3 || i == 1*2*3
+//line a.gop:84:1
+	_ = i != 0 || i != 0
+//line a.gop:85:1
+	_ = i == 0 && i == 0
+//line a.gop:89:1
+	_ = 0 != <-c && 0 != <-c
+//line a.gop:90:1
+	_ = f() != 0 && f() != 0
+//line a.gop:91:1
+	_ = f != nil && f != nil
+//line a.gop:92:1
+	_ = i != 1 && i != 1 && f() != 1
+//line a.gop:93:1
+	_ = i != 1 && f() != 1 && i != 1
+//line a.gop:94:1
+	_ = f() != 1 && i != 1 && i != 1
+}
+//line a.gop:97:1
+func RoyallySuspectConditions() {
+//line a.gop:98:1
+	var i, j int
+//line a.gop:100:1
+	_ = i == 0 || i == 1
+//line a.gop:101:1
+	_ = i != 0 || i != 1
+//line a.gop:102:1
+	_ = i != 0 || 1 != i
+//line a.gop:103:1
+	_ = 0 != i || 1 != i
+//line a.gop:104:1
+	_ = 0 != i || i != 1
+//line a.gop:106:1
+	_ = 0 != i || i != 1
+//line a.gop:108:1
+	_ = i+3 != 7 || j+5 == 0 || i+3 != 9
+//line a.gop:110:1
+	_ = i != 0 || j == 0 || i != 1
+//line a.gop:112:1
+	_ = i != 0 || i != 1<<4
+//line a.gop:114:1
+	_ = i != 0 || j != 0
+//line a.gop:115:1
+	_ = 0 != i || 0 != j
+//line a.gop:117:1
+	var s string
+//line a.gop:118:1
+	_ = s != "one" || s != "the other"
+//line a.gop:120:1
+	_ = true
+//line a.gop:121:1
+	_ = true
+//line a.gop:123:1
+	var err error
+//line a.gop:124:1
+	_ = err != nil || err != io.EOF
+//line a.gop:127:1
+	_ = i != 0 && i != 1
+//line a.gop:128:1
+	_ = i == 0 && i == 1
+//line a.gop:129:1
+	_ = i == 0 && 1 == i
+//line a.gop:130:1
+	_ = 0 == i && 1 == i
+//line a.gop:131:1
+	_ = 0 == i && i == 1
+}
+//line a.gop:134:1
+func RedundantInLambda(apply func(fn func() bool)) {
+//line a.gop:135:1
+	var i int
+//line a.gop:136:1
+	apply(func() bool {
+//line a.gop:136:1
+		return i == 1 || i == 1
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type T[P interface{ ~int }] struct {
+	a P
+}
+
+func (t T[P]) Foo() int { return int(t.a) }
+
+type FT[P any] func() P
+
+func Sink[Elem any]() chan Elem {
+	return make(chan Elem)
+}
+
+func Zero[P any]() P {
+	var p P
+	return p
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package buildssa defines an Analyzer that constructs the SSA
+// representation of an error-free package and returns the set of all
+// functions within it, in its Go and Go+ files. It does not report any
+// diagnostics itself but may be used as an input to other analyzers.
+//
+// There is no SSA builder for Go+ syntax trees, so the SSA representation
+// of the Go+ files is built from their Go form, as the Go+ compiler writes
+// it to gop_autogen.go. Its positions are mapped back to the Go+ files by
+// the //line directives of the Go form: see SSA.Pos.
+package buildssa
+
+import (
+	goast "go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/internal/typeparams"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:       "gopBuildssa",
+	Doc:        "build SSA-form IR for later passes",
+	URL:        "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/buildssa",
+	Run:        run,
+	ResultType: reflect.TypeOf(new(SSA)),
+}
+
+// SSA provides SSA-form intermediate representation for all the
+// non-blank source functions in the current package.
+// Pkg is nil if the package has Go+ files whose Go form can't be
+// generated or type-checked.
+type SSA struct {
+	Pkg      *ssa.Package
+	SrcFuncs []*ssa.Function
+
+	fset     *token.FileSet
+	genFiles map[*token.File]bool   // the Go form of the Go+ files
+	gopFiles map[string]*token.File // by name
+}
+
+// Pos returns the position of pos, such as the position of an SSA
+// instruction, in the source files of the package. A position in the Go
+// form of the Go+ files is mapped to the Go+ files, or to token.NoPos if
+// it isn't covered by a //line directive of the Go form.
+//
+// The Go+ compiler writes a //line directive before the code of each
+// declaration and statement, so the line of pos is exact but its column
+// is the one in the Go form.
+func (s *SSA) Pos(pos token.Pos) token.Pos {
+	if !s.genFiles[s.fset.File(pos)] {
+		return pos
+	}
+	posn := s.fset.PositionFor(pos, true)
+	tf := s.gopFiles[posn.Filename]
+	if tf == nil || posn.Line < 1 || posn.Line > tf.LineCount() {
+		return token.NoPos
+	}
+	start := tf.LineStart(posn.Line)
+	end := token.Pos(tf.Base() + tf.Size())
+	if posn.Line < tf.LineCount() {
+		end = tf.LineStart(posn.Line + 1)
+	}
+	if col := token.Pos(posn.Column - 1); col > 0 && start+col < end {
+		return start + col
+	}
+	return start
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ret := &SSA{
+		fset:     pass.Fset,
+		genFiles: make(map[*token.File]bool),
+		gopFiles: make(map[string]*token.File),
+	}
+	pkg, files, info := pass.Pkg, pass.Files, pass.TypesInfo
+	if len(pass.GopFiles) > 0 {
+		// goxls: the types of a Go+ package are those of its Go+ files, so
+		// the Go form of the Go+ files is type-checked with the Go files
+		// of the package, as the Go files of a package with a
+		// gop_autogen.go are.
+		for _, f := range pass.GopFiles {
+			ret.gopFiles[pass.Fset.File(f.Pos()).Name()] = pass.Fset.File(f.Pos())
+		}
+		imp := newImporter(pass)
+		genFiles := goForm(pass, imp)
+		if genFiles == nil {
+			return ret, nil
+		}
+		for _, f := range genFiles {
+			ret.genFiles[pass.Fset.File(f.Pos())] = true
+		}
+		files = append(append([]*goast.File(nil), pass.Files...), genFiles...)
+		info = &types.Info{
+			Types:      make(map[goast.Expr]types.TypeAndValue),
+			Defs:       make(map[*goast.Ident]types.Object),
+			Uses:       make(map[*goast.Ident]types.Object),
+			Implicits:  make(map[goast.Node]types.Object),
+			Scopes:     make(map[goast.Node]*types.Scope),
+			Selections: make(map[*goast.SelectorExpr]*types.Selection),
+		}
+		typeparams.InitInstanceInfo(info)
+		tc := &types.Config{
+			Importer: imp,
+			Sizes:    pass.TypesSizes,
+			Error:    func(error) {}, // report all errors: the SSA builder needs error-free code
+		}
+		var err error
+		if pkg, err = tc.Check(pass.Pkg.Path(), pass.Fset, files, info); err != nil {
+			return ret, nil
+		}
+	}
+
+	// Plundered from ssautil.BuildPackage.
+
+	// We must create a new Program for each Package because the
+	// analysis API provides no place to hang a Program shared by
+	// all Packages. Consequently, SSA Packages and Functions do not
+	// have a canonical representation across an analysis session of
+	// multiple packages. This is unlikely to be a problem in
+	// practice because the analysis API essentially forces all
+	// packages to be analysed independently, so any given call to
+	// Analysis.Run on a package will see only SSA objects belonging
+	// to a single Program.
+
+	// Some Analyzers may need GlobalDebug, in which case we'll have
+	// to set it globally, but let's wait till we need it.
+	mode := ssa.BuilderMode(0)
+
+	prog := ssa.NewProgram(pass.Fset, mode)
+
+	// Create SSA packages for all imports.
+	// Order is not significant.
+	created := make(map[*types.Package]bool)
+	var createAll func(pkgs []*types.Package)
+	createAll = func(pkgs []*types.Package) {
+		for _, p := range pkgs {
+			if !created[p] {
+				created[p] = true
+				prog.CreatePackage(p, nil, nil, true)
+				createAll(p.Imports())
+			}
+		}
+	}
+	createAll(pkg.Imports())
+
+	// Create and build the primary package.
+	ssapkg := prog.CreatePackage(pkg, files, info, false)
+	ssapkg.Build()
+
+	// Compute list of source functions, including literals,
+	// in source order.
+	var funcs []*ssa.Function
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fdecl, ok := decl.(*goast.FuncDecl); ok {
+
+				// SSA will not build a Function
+				// for a FuncDecl named blank.
+				// That's arguably too strict but
+				// relaxing it would break uniqueness of
+				// names of package members.
+				if fdecl.Name.Name == "_" {
+					continue
+				}
+
+				// (init functions have distinct Func
+				// objects named "init" and distinct
+				// ssa.Functions named "init#1", ...)
+
+				fn := info.Defs[fdecl.Name].(*types.Func)
+				if fn == nil {
+					panic(fn)
+				}
+
+				f := ssapkg.Prog.FuncValue(fn)
+				if f == nil {
+					panic(fn)
+				}
+
+				var addAnons func(f *ssa.Function)
+				addAnons = func(f *ssa.Function) {
+					funcs = append(funcs, f)
+					for _, anon := range f.AnonFuncs {
+						addAnons(anon)
+					}
+				}
+				addAnons(f)
+			}
+		}
+	}
+
+	ret.Pkg, ret.SrcFuncs = ssapkg, funcs
+	return ret, nil
+}
@@ -7,6 +7,7 @@ package buildssa_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"golang.org/x/tools/gop/analysis/analysistest"
@@ -38,7 +39,7 @@ func TestGenericDecls(t *testing.T) {
 
 	ssainfo := result.(*buildssa.SSA)
 	got := fmt.Sprint(ssainfo.SrcFuncs)
-	want := `[(*b.Pointer[T]).Load b.Load b.LoadPointer]`
+	want := `[(*b.Pointer[T]).Load b.Load b.LoadPointer b.StorePointer (*b.Pointer[T]).Store]`
 	if got != want {
 		t.Errorf("SSA.SrcFuncs = %s, want %s", got, want)
 		for _, f := range ssainfo.SrcFuncs {
@@ -64,3 +65,19 @@ func TestImporting(t *testing.T) {
 		}
 	}
 }
+
+func TestPos(t *testing.T) {
+	testdata := analysistest.TestData()
+	result := analysistest.Run(t, testdata, buildssa.Analyzer, "b")[0]
+
+	ssainfo := result.Result.(*buildssa.SSA)
+	var got []string
+	for _, f := range ssainfo.SrcFuncs {
+		posn := result.Pass.Fset.Position(ssainfo.Pos(f.Pos()))
+		got = append(got, fmt.Sprintf("%s:%d:%d", filepath.Base(posn.Filename), posn.Line, posn.Column))
+	}
+	want := "[lib.go:12:22 lib.go:16:6 lib.go:20:6 lib.go:24:6 b.gop:5:22]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("positions of SSA.SrcFuncs = %s, want %s", got, want)
+	}
+}
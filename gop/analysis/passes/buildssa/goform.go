@@ -0,0 +1,115 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildssa
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/types"
+	"path/filepath"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/cl"
+	"github.com/goplus/mod/gopmod"
+	"golang.org/x/tools/gop/analysis"
+)
+
+// goForm returns the Go form of the Go+ files of the package, parsed into
+// pass.Fset, or nil if the Go+ compiler can't generate it. Its imports are
+// resolved by imp.
+//
+// The Go form is generated as for gop_autogen.go, and gop_autogen_test.go
+// for the Go+ test files, except that the //line directives name the Go+
+// files by their names in pass.Fset. The classfiles of a module that
+// gop.mod registers can't be compiled, as the module isn't known here.
+func goForm(pass *analysis.Pass, imp types.Importer) []*goast.File {
+	if len(pass.GopFiles) == 0 {
+		return nil
+	}
+	gopFiles := make(map[string]*ast.File)
+	for _, f := range pass.GopFiles {
+		gopFiles[pass.Fset.File(f.Pos()).Name()] = f
+	}
+	goFiles := make(map[string]*goast.File)
+	for _, f := range pass.Files {
+		goFiles[pass.Fset.File(f.Pos()).Name()] = f
+	}
+	pkg, err := cl.NewPackage(pass.Pkg.Path(), &ast.Package{
+		Name:    pass.Pkg.Name(),
+		Files:   gopFiles,
+		GoFiles: goFiles,
+	}, &cl.Config{
+		Fset:           pass.Fset,
+		LookupClass:    gopmod.Default.LookupClass,
+		Importer:       imp,
+		NoAutoGenMain:  true,
+		NoSkipConstant: true,
+	})
+	if err != nil {
+		return nil
+	}
+
+	dir := filepath.Dir(pass.Fset.File(pass.GopFiles[0].Pos()).Name())
+	var files []*goast.File
+	for _, gen := range []struct{ name, file string }{
+		{"", "gop_autogen.go"},
+		{"_test", "gop_autogen_test.go"}, // the Go+ test files
+	} {
+		var buf bytes.Buffer
+		if pkg.WriteTo(&buf, gen.name) != nil { // no such file
+			continue
+		}
+		f, err := parser.ParseFile(pass.Fset, filepath.Join(dir, gen.file), buf.Bytes(), parser.ParseComments)
+		if err != nil {
+			return nil
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// importer resolves the imports of the Go form of the Go+ files from the
+// packages that the package imports, directly or indirectly.
+type importer map[string]*types.Package
+
+func newImporter(pass *analysis.Pass) importer {
+	imp := make(importer)
+	var addAll func(pkgs []*types.Package)
+	addAll = func(pkgs []*types.Package) {
+		for _, pkg := range pkgs {
+			if _, ok := imp[pkg.Path()]; !ok {
+				imp[pkg.Path()] = pkg
+				addAll(pkg.Imports())
+			}
+		}
+	}
+	addAll(pass.Pkg.Imports())
+	// The Go+ files may import packages that the Go files don't, e.g. if
+	// gop_autogen.go is stale.
+	for _, f := range pass.GopFiles {
+		for _, spec := range f.Imports {
+			obj := pass.GopTypesInfo.Implicits[spec]
+			if spec.Name != nil {
+				obj = pass.GopTypesInfo.Defs[spec.Name]
+			}
+			if obj, ok := obj.(*types.PkgName); ok {
+				addAll([]*types.Package{obj.Imported()})
+			}
+		}
+	}
+	return imp
+}
+
+func (imp importer) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	if pkg, ok := imp[path]; ok {
+		return pkg, nil
+	}
+	return nil, fmt.Errorf("can't find import: %q", path)
+}
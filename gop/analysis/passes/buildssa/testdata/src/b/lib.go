@@ -3,6 +3,8 @@ package b
 
 import "unsafe"
 
+// Go+ can't declare generic types and functions: they are declared in Go.
+
 type Pointer[T any] struct {
 	v unsafe.Pointer
 }
@@ -15,6 +17,12 @@ func Load[T any](x *Pointer[T]) *T {
 	return x.Load()
 }
 
-func LoadPointer(addr *unsafe.Pointer) (val unsafe.Pointer)
+func LoadPointer(addr *unsafe.Pointer) (val unsafe.Pointer) {
+	return *addr
+}
+
+func StorePointer(addr *unsafe.Pointer, val unsafe.Pointer) {
+	*addr = val
+}
 
 var G Pointer[int]
@@ -2,72 +2,53 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build go1.16
-// +build go1.16
-
 // Package buildtag defines an Analyzer that checks build tags.
 package buildtag
 
 import (
-	"go/ast"
 	"go/build/constraint"
-	"go/parser"
 	"go/token"
 	"strings"
 	"unicode"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/buildtag"
 	"golang.org/x/tools/gop/analysis"
-	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 )
 
 const Doc = "check //go:build and // +build directives"
 
 var Analyzer = &analysis.Analyzer{
-	Name: "buildtag",
-	Doc:  Doc,
-	URL:  "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/buildtag",
-	Run:  runBuildTag,
+	Name:     "gopBuildtag",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/buildtag",
+	Requires: []analysis.IAnalyzer{buildtag.Analyzer},
+	Run:      runBuildTag,
 }
 
+// goxls: the Go files, the other files and the ignored files of the package
+// are checked by the Go buildtag analyzer.
 func runBuildTag(pass *analysis.Pass) (interface{}, error) {
-	for _, f := range pass.Files {
-		checkGoFile(pass, f)
-	}
-	for _, name := range pass.OtherFiles {
-		if err := checkOtherFile(pass, name); err != nil {
-			return nil, err
-		}
-	}
-	for _, name := range pass.IgnoredFiles {
-		if strings.HasSuffix(name, ".go") {
-			f, err := parser.ParseFile(pass.Fset, name, nil, parser.ParseComments)
-			if err != nil {
-				// Not valid Go source code - not our job to diagnose, so ignore.
-				return nil, nil
-			}
-			checkGoFile(pass, f)
-		} else {
-			if err := checkOtherFile(pass, name); err != nil {
-				return nil, err
-			}
-		}
+	for _, f := range pass.GopFiles {
+		checkGopFile(pass, f)
 	}
 	return nil, nil
 }
 
-func checkGoFile(pass *analysis.Pass, f *ast.File) {
+func checkGopFile(pass *analysis.Pass, f *ast.File) {
 	var check checker
 	check.init(pass)
 	defer check.finish()
 
+	pkg := packagePos(f)
 	for _, group := range f.Comments {
 		// A +build comment is ignored after or adjoining the package declaration.
-		if group.End()+1 >= f.Package {
+		if group.End()+1 >= pkg {
 			check.plusBuildOK = false
 		}
 		// A //go:build comment is ignored after the package declaration
 		// (but adjoining it is OK, in contrast to +build comments).
-		if group.Pos() >= f.Package {
+		if group.Pos() >= pkg {
 			check.goBuildOK = false
 		}
 
@@ -82,20 +63,13 @@ func checkGoFile(pass *analysis.Pass, f *ast.File) {
 	}
 }
 
-func checkOtherFile(pass *analysis.Pass, filename string) error {
-	var check checker
-	check.init(pass)
-	defer check.finish()
-
-	// We cannot use the Go parser, since this may not be a Go source file.
-	// Read the raw bytes instead.
-	content, tf, err := analysisutil.ReadFile(pass.Fset, filename)
-	if err != nil {
-		return err
+// packagePos returns the position of the package declaration of f, or of its
+// first declaration if f has none.
+func packagePos(f *ast.File) token.Pos {
+	if f.Package.IsValid() || len(f.Decls) == 0 {
+		return f.Package
 	}
-
-	check.file(token.Pos(tf.Base()), string(content))
-	return nil
+	return f.Decls[0].Pos()
 }
 
 type checker struct {
@@ -117,89 +91,6 @@ func (check *checker) init(pass *analysis.Pass) {
 	check.crossCheck = true
 }
 
-func (check *checker) file(pos token.Pos, text string) {
-	// Determine cutpoint where +build comments are no longer valid.
-	// They are valid in leading // comments in the file followed by
-	// a blank line.
-	//
-	// This must be done as a separate pass because of the
-	// requirement that the comment be followed by a blank line.
-	var plusBuildCutoff int
-	fullText := text
-	for text != "" {
-		i := strings.Index(text, "\n")
-		if i < 0 {
-			i = len(text)
-		} else {
-			i++
-		}
-		offset := len(fullText) - len(text)
-		line := text[:i]
-		text = text[i:]
-		line = strings.TrimSpace(line)
-		if !strings.HasPrefix(line, "//") && line != "" {
-			break
-		}
-		if line == "" {
-			plusBuildCutoff = offset
-		}
-	}
-
-	// Process each line.
-	// Must stop once we hit goBuildOK == false
-	text = fullText
-	check.inStar = false
-	for text != "" {
-		i := strings.Index(text, "\n")
-		if i < 0 {
-			i = len(text)
-		} else {
-			i++
-		}
-		offset := len(fullText) - len(text)
-		line := text[:i]
-		text = text[i:]
-		check.plusBuildOK = offset < plusBuildCutoff
-
-		if strings.HasPrefix(line, "//") {
-			check.comment(pos+token.Pos(offset), line)
-			continue
-		}
-
-		// Keep looking for the point at which //go:build comments
-		// stop being allowed. Skip over, cut out any /* */ comments.
-		for {
-			line = strings.TrimSpace(line)
-			if check.inStar {
-				i := strings.Index(line, "*/")
-				if i < 0 {
-					line = ""
-					break
-				}
-				line = line[i+len("*/"):]
-				check.inStar = false
-				continue
-			}
-			if strings.HasPrefix(line, "/*") {
-				check.inStar = true
-				line = line[len("/*"):]
-				continue
-			}
-			break
-		}
-		if line != "" {
-			// Found non-comment non-blank line.
-			// Ends space for valid //go:build comments,
-			// but also ends the fraction of the file we can
-			// reliably parse. From this point on we might
-			// incorrectly flag "comments" inside multiline
-			// string constants or anything else (this might
-			// not even be a Go program). So stop.
-			break
-		}
-	}
-}
-
 func (check *checker) comment(pos token.Pos, text string) {
 	if strings.HasPrefix(text, "//") {
 		if strings.Contains(text, "+build") {
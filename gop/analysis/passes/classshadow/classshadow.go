@@ -0,0 +1,103 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package classshadow
+
+import (
+	_ "embed"
+	"go/types"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "gopClassshadow",
+	Doc:  analysisutil.MustExtractDoc(doc, "classshadow"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/classshadow",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	info := pass.GopTypesInfo
+	if info == nil {
+		return nil, nil
+	}
+	for _, f := range pass.GopFiles {
+		if !f.IsClass {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Shadow {
+				continue // the entry generated for the class body
+			}
+			method, ok := info.Defs[fn.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			recv := method.Type().(*types.Signature).Recv()
+			if recv == nil {
+				continue
+			}
+			for _, base := range baseTypes(pass.Pkg, recv.Type()) {
+				if shadowed := lookupMethod(pass.Pkg, base, method.Name()); shadowed != nil {
+					pass.ReportRangef(fn.Name, "method %s shadows method %s of the classfile base type %s",
+						method.Name(), shadowed.Name(), types.TypeString(base, (*types.Package).Name))
+					break
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// baseTypes returns the types embedded in the class type t that are declared
+// by other packages: the classfile base types of the framework.
+func baseTypes(pkg *types.Package, t types.Type) (bases []types.Type) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Embedded() {
+			continue
+		}
+		ft := field.Type()
+		if ptr, ok := ft.(*types.Pointer); ok {
+			ft = ptr.Elem()
+		}
+		if named, ok := ft.(*types.Named); ok && named.Obj().Pkg() != pkg {
+			bases = append(bases, field.Type())
+		}
+	}
+	return bases
+}
+
+// lookupMethod returns the method of base that a class method named name
+// shadows, if any. A lowercase name also shadows the exported method of the
+// same name, which Go+ calls by its lowercase name.
+func lookupMethod(pkg *types.Package, base types.Type, name string) *types.Func {
+	names := []string{name}
+	if r, size := utf8.DecodeRuneInString(name); unicode.IsLower(r) {
+		names = append(names, string(unicode.ToUpper(r))+name[size:])
+	}
+	for _, name := range names {
+		obj, _, _ := types.LookupFieldOrMethod(base, true, pkg, name)
+		if fn, ok := obj.(*types.Func); ok {
+			return fn
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package classshadow defines an Analyzer that checks for methods of
+// Go+ classes that shadow methods of their classfile base type.
+//
+// # Analyzer classshadow
+//
+// classshadow: check for class methods that shadow framework methods
+//
+// A Go+ class file (such as a .spx, .gsh or _test.gox file) declares a
+// class that embeds the base type registered for its classfile, so the
+// methods of the framework can be called directly in the class. A method
+// declared in the class file with the same name hides the one of the
+// framework. For example, in a .gsh file:
+//
+//	func Output() string {
+//		return "ok"
+//	}
+//
+// hides gsh.App.Output. As Go+ lets a lowercase name call the exported
+// method, declaring a method named output hides it as well.
+package classshadow
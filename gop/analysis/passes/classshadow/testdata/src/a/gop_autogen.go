@@ -0,0 +1,45 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"github.com/qiniu/x/gsh"
+)
+
+const _ = true
+
+type App struct {
+	gsh.App
+}
+//line main.gsh:1:1
+func (this *App) Output() string {
+//line main.gsh:2:1
+	return "out"
+}
+//line main.gsh:5:1
+func (this *App) exitCode() int {
+//line main.gsh:6:1
+	return 0
+}
+//line main.gsh:9:1
+func (this *App) cleanup() {
+}
+//line main.gsh:12:1
+func (this *App) run() {
+//line main.gsh:13:1
+	this.Exec__1("ls")
+}
+//line main.gsh:16
+func (this *App) MainEntry() {
+//line main.gsh:16:1
+	this.run()
+//line main.gsh:17:1
+	fmt.Println(this.Output())
+}
+func (this *App) Main() {
+	gsh.Gopt_App_Main(this)
+}
+func main() {
+	new(App).Main()
+}
@@ -0,0 +1,52 @@
+// Package gsh synthesizes Go+'s package "github.com/qiniu/x/gsh", the base
+// of the .gsh classfiles, for unit-testing.
+package gsh
+
+type App struct {
+	err  error
+	out  string
+	code int
+}
+
+func (p *App) initApp() {}
+
+func (p *App) Gop_Env(key string) string {
+	return ""
+}
+
+func (p *App) Gop_Exec(name string, args ...string) error {
+	return p.err
+}
+
+func (p *App) Exec__0(env map[string]string, name string, args ...string) error {
+	return p.err
+}
+
+func (p *App) Exec__1(cmdline string) error {
+	return p.err
+}
+
+func (p *App) Exec__2(name string, args ...string) error {
+	return p.err
+}
+
+func (p *App) LastErr() error {
+	return p.err
+}
+
+func (p *App) ExitCode() int {
+	return p.code
+}
+
+func (p *App) Capout(doSth func()) (string, error) {
+	doSth()
+	return p.out, p.err
+}
+
+func (p *App) Output() string {
+	return p.out
+}
+
+func Gopt_App_Main(a interface{ initApp() }) {
+	a.initApp()
+}
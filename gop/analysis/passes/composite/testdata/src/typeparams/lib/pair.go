@@ -0,0 +1,14 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lib
+
+// Go+ can't declare generic types: they are declared in Go.
+
+type Pair[K, V any] struct {
+	Key K
+	Val V
+}
+
+type Box[T any] struct{ V T }
@@ -0,0 +1,12 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types: they are declared in Go.
+
+type localPair[K, V any] struct {
+	Key K
+	Val V
+}
@@ -9,14 +9,9 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/copylock"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	pkgs := []string{"a"}
-	if typeparams.Enabled {
-		pkgs = append(pkgs, "typeparams")
-	}
-	analysistest.Run(t, testdata, copylock.Analyzer, pkgs...)
+	analysistest.Run(t, testdata, copylock.Analyzer, "a", "typeparams")
 }
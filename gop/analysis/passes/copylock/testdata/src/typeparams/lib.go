@@ -0,0 +1,28 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+import "sync"
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type Guarded[T any] struct {
+	mu sync.Mutex
+	v  T
+}
+
+type Ptr[T any] struct {
+	p *T
+}
+
+type Pair[K, V any] struct {
+	k K
+	v V
+}
+
+func Zero[T any]() T {
+	var x T
+	return x
+}
@@ -9,26 +9,30 @@
 package ctrlflow
 
 import (
-	"go/ast"
+	goast "go/ast"
 	"go/types"
 	"log"
 	"reflect"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	gocfg "golang.org/x/tools/go/cfg"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/gop/ast/inspector"
 	"golang.org/x/tools/gop/cfg"
 	"golang.org/x/tools/gop/types/typeutil"
 )
 
 var Analyzer = &analysis.Analyzer{
-	Name:       "ctrlflow",
+	Name:       "gopCtrlflow",
 	Doc:        "build a control-flow graph",
 	URL:        "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/ctrlflow",
 	Run:        run,
 	ResultType: reflect.TypeOf(new(CFGs)),
 	FactTypes:  []analysis.Fact{new(noReturn)},
-	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Requires:   []analysis.IAnalyzer{ctrlflow.Analyzer, inspect.Analyzer},
 }
 
 // noReturn is a fact indicating that a function does not return.
@@ -104,7 +108,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		switch n := n.(type) {
 		case *ast.FuncDecl:
 			// Type information may be incomplete.
-			if fn, ok := pass.TypesInfo.Defs[n.Name].(*types.Func); ok {
+			if fn, ok := pass.GopTypesInfo.Defs[n.Name].(*types.Func); ok {
 				funcDecls[fn] = &declInfo{decl: n}
 				decls = append(decls, fn)
 			}
@@ -114,13 +118,37 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 	})
 
+	var defs map[*ast.Ident]types.Object
+	if pass.GopTypesInfo != nil { // goxls: nil for a Go package
+		defs = pass.GopTypesInfo.Defs
+	}
 	c := &CFGs{
-		defs:      pass.TypesInfo.Defs,
+		defs:      defs,
 		funcDecls: funcDecls,
 		funcLits:  funcLits,
 		pass:      pass,
 	}
 
+	// goxls: export the noReturn facts of the Go functions of this package,
+	// such as log.Fatal, from the CFGs built by the Go ctrlflow analysis.
+	goCFGs := pass.GoPass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*goast.FuncDecl); ok {
+				fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+				if !ok {
+					continue
+				}
+				if _, ok := funcDecls[fn]; ok {
+					continue
+				}
+				if isIntrinsicNoReturn(fn) || goNoReturn(goCFGs.FuncDecl(decl)) {
+					pass.ExportObjectFact(fn, new(noReturn))
+				}
+			}
+		}
+	}
+
 	// Pass 2. Build CFGs.
 
 	// Build CFGs for named functions.
@@ -184,7 +212,8 @@ func (c *CFGs) buildDecl(fn *types.Func, di *declInfo) {
 // callMayReturn reports whether the called function may return.
 // It is passed to the CFG builder.
 func (c *CFGs) callMayReturn(call *ast.CallExpr) (r bool) {
-	if id, ok := call.Fun.(*ast.Ident); ok && c.pass.TypesInfo.Uses[id] == panicBuiltin {
+	// goxls: Go+ records panic as a template function, not a builtin.
+	if id, ok := call.Fun.(*ast.Ident); ok && analysisutil.IsBuiltin(c.pass.GopTypesInfo.Uses[id], "panic") {
 		return false // panic never returns
 	}
 
@@ -193,7 +222,7 @@ func (c *CFGs) callMayReturn(call *ast.CallExpr) (r bool) {
 	// return depending on the parameter type, but in some
 	// cases the answer is definite. We let ctrlflow figure
 	// that out.
-	fn := typeutil.StaticCallee(c.pass.TypesInfo, call)
+	fn := typeutil.StaticCallee(c.pass.GopTypesInfo, call)
 	if fn == nil {
 		return true // callee not statically known; be conservative
 	}
@@ -209,8 +238,6 @@ func (c *CFGs) callMayReturn(call *ast.CallExpr) (r bool) {
 	return !c.pass.ImportObjectFact(fn, new(noReturn))
 }
 
-var panicBuiltin = types.Universe.Lookup("panic").(*types.Builtin)
-
 func hasReachableReturn(g *cfg.CFG) bool {
 	for _, b := range g.Blocks {
 		if b.Live && b.Return() != nil {
@@ -220,6 +247,20 @@ func hasReachableReturn(g *cfg.CFG) bool {
 	return false
 }
 
+// goNoReturn reports whether the Go function of the control-flow graph g
+// never returns. g is nil for a function without body.
+func goNoReturn(g *gocfg.CFG) bool {
+	if g == nil {
+		return false
+	}
+	for _, b := range g.Blocks {
+		if b.Live && b.Return() != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // isIntrinsicNoReturn reports whether a function intrinsically never
 // returns because it stops execution of the calling thread.
 // It is the base case in the recursion.
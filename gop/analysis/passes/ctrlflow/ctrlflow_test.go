@@ -5,31 +5,25 @@
 package ctrlflow_test
 
 import (
-	"go/ast"
 	"testing"
 
+	"github.com/goplus/gop/ast"
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/ctrlflow"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 
-	// load testdata/src/a/a.go
-	tests := []string{"a"}
-	if typeparams.Enabled {
-		// and testdata/src/typeparams/typeparams.go when possible
-		tests = append(tests, "typeparams")
-	}
-	results := analysistest.Run(t, testdata, ctrlflow.Analyzer, tests...)
+	// load testdata/src/a/a.gop
+	results := analysistest.Run(t, testdata, ctrlflow.Analyzer, "a", "nobody", "typeparams")
 
 	// Perform a minimal smoke test on
 	// the result (CFG) computed by ctrlflow.
 	for _, result := range results {
 		cfgs := result.Result.(*ctrlflow.CFGs)
 
-		for _, decl := range result.Pass.Files[0].Decls {
+		for _, decl := range result.Pass.GopFiles[0].Decls {
 			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
 				if cfgs.FuncDecl(decl) == nil {
 					t.Errorf("%s: no CFG for func %s",
@@ -0,0 +1,160 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"lib"
+	"log"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+const _ = true
+
+type T int
+
+var cond bool
+//line a.gop:21:1
+func a() {
+//line a.gop:22:1
+	if cond {
+//line a.gop:31:1
+		b()
+	} else {
+//line a.gop:25:1
+		for {
+		}
+	}
+}
+//line a.gop:30:1
+func b() {
+//line a.gop:31:1
+	select {}
+}
+//line a.gop:34:1
+func f(x int) {
+//line a.gop:35:1
+	switch x {
+//line a.gop:36:1
+	case 0:
+//line a.gop:37:1
+		os.Exit(0)
+//line a.gop:38:1
+	case 1:
+//line a.gop:39:1
+		panic(0)
+	}
+}
+//line a.gop:46:1
+func (T) method1() {
+//line a.gop:47:1
+	a()
+}
+//line a.gop:50:1
+func (T) method2() {
+//line a.gop:51:1
+	if cond {
+//line a.gop:52:1
+		a()
+	}
+}
+//line a.gop:56:1
+// Checking for the noreturn fact associated with F ensures that
+// ctrlflow proved each of the listed functions was "noReturn".
+//
+func standardFunctions(x int) {
+//line a.gop:60:1
+	t := new(testing.T)
+//line a.gop:61:1
+	switch x {
+//line a.gop:62:1
+	case 0:
+//line a.gop:63:1
+		t.FailNow()
+//line a.gop:64:1
+	case 1:
+//line a.gop:65:1
+		t.Fatal()
+//line a.gop:66:1
+	case 2:
+//line a.gop:67:1
+		t.Fatalf("")
+//line a.gop:68:1
+	case 3:
+//line a.gop:69:1
+		t.Skip()
+//line a.gop:70:1
+	case 4:
+//line a.gop:71:1
+		t.SkipNow()
+//line a.gop:72:1
+	case 5:
+//line a.gop:73:1
+		t.Skipf("")
+//line a.gop:74:1
+	case 6:
+//line a.gop:75:1
+		log.Fatal()
+//line a.gop:76:1
+	case 7:
+//line a.gop:77:1
+		log.Fatalf("")
+//line a.gop:78:1
+	case 8:
+//line a.gop:79:1
+		log.Fatalln()
+//line a.gop:80:1
+	case 9:
+//line a.gop:81:1
+		os.Exit(0)
+//line a.gop:82:1
+	case 10:
+//line a.gop:83:1
+		syscall.Exit(0)
+//line a.gop:84:1
+	case 11:
+//line a.gop:85:1
+		runtime.Goexit()
+//line a.gop:86:1
+	case 12:
+//line a.gop:87:1
+		log.Panic()
+//line a.gop:88:1
+	case 13:
+//line a.gop:89:1
+		log.Panicln()
+//line a.gop:90:1
+	case 14:
+//line a.gop:91:1
+		log.Panicf("")
+//line a.gop:92:1
+	default:
+//line a.gop:93:1
+		panic(0)
+	}
+}
+//line a.gop:97:1
+// False positives are possible.
+// This function is marked noReturn but in fact returns.
+//
+func spurious() {
+//line a.gop:101:1
+	defer func() {
+//line a.gop:101:1
+		recover()
+	}()
+//line a.gop:102:1
+	panic(nil)
+}
+//line a.gop:105:1
+func g() {
+//line a.gop:106:1
+	lib.CanReturn()
+}
+//line a.gop:109:1
+func h() {
+//line a.gop:110:1
+	lib.NoReturn()
+}
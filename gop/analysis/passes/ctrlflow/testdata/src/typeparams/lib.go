@@ -0,0 +1,21 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func b[B any]() { // want b:"noReturn"
+	select {}
+}
+
+func id[T any](t T) T {
+	return t
+}
+
+type I[T any] interface {
+	Id(T) T
+}
+
+type T[X any] int
@@ -7,9 +7,10 @@
 package deepequalerrors
 
 import (
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/deepequalerrors"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -27,15 +28,15 @@ where err1 and err2 are errors. Using reflect.DeepEqual to compare
 errors is discouraged.`
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "deepequalerrors",
+	Name:     "gopDeepequalerrors",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/deepequalerrors",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{deepequalerrors.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	if !analysisutil.Imports(pass.Pkg, "reflect") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "reflect") {
 		return nil, nil // doesn't directly import reflect
 	}
 
@@ -46,7 +47,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		fn, ok := typeutil.Callee(pass.GopTypesInfo, call).(*types.Func)
 		if !ok {
 			return
 		}
@@ -62,7 +63,7 @@ var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Inter
 // hasError reports whether the type of e contains the type error.
 // See containsError, below, for the meaning of "contains".
 func hasError(pass *analysis.Pass, e ast.Expr) bool {
-	tv, ok := pass.TypesInfo.Types[e]
+	tv, ok := pass.GopTypesInfo.Types[e]
 	if !ok { // no type info, assume good
 		return false
 	}
@@ -9,14 +9,9 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/deepequalerrors"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	tests := []string{"a"}
-	if typeparams.Enabled {
-		tests = append(tests, "typeparams")
-	}
-	analysistest.Run(t, testdata, deepequalerrors.Analyzer, tests...)
+	analysistest.Run(t, testdata, deepequalerrors.Analyzer, "a", "typeparams")
 }
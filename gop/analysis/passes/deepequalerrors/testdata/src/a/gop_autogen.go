@@ -0,0 +1,68 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"io"
+	"io/fs"
+	"reflect"
+)
+
+const _ = true
+
+type myError int
+type s1 struct {
+	s2 *s2
+	i  int
+}
+type myError2 error
+type s2 struct {
+	s1   *s1
+	errs []*myError2
+}
+//line a.gop:17:1
+func (myError) Error() string {
+//line a.gop:17:1
+	return ""
+}
+//line a.gop:19:1
+func bad() error {
+//line a.gop:19:1
+	return nil
+}
+//line a.gop:33:1
+func hasError() {
+//line a.gop:34:1
+	var e error
+//line a.gop:35:1
+	var m myError2
+//line a.gop:36:1
+	reflect.DeepEqual(bad(), e)
+//line a.gop:37:1
+	reflect.DeepEqual(io.EOF, io.EOF)
+//line a.gop:38:1
+	reflect.DeepEqual(e, &e)
+//line a.gop:39:1
+	reflect.DeepEqual(e, m)
+//line a.gop:40:1
+	reflect.DeepEqual(e, s1{})
+//line a.gop:41:1
+	reflect.DeepEqual(e, [1]error{})
+//line a.gop:42:1
+	reflect.DeepEqual(e, map[error]int{})
+//line a.gop:43:1
+	reflect.DeepEqual(e, map[int]error{})
+//line a.gop:46:1
+	reflect.DeepEqual(&fs.PathError{}, io.EOF)
+}
+//line a.gop:50:1
+func notHasError() {
+//line a.gop:51:1
+	reflect.ValueOf(4)
+//line a.gop:52:1
+	reflect.DeepEqual(3, 4)
+//line a.gop:53:1
+	reflect.DeepEqual(5, io.EOF)
+//line a.gop:54:1
+	reflect.DeepEqual(myError(1), io.EOF)
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func bad[T any]() T {
+	var t T
+	return t
+}
+
+type s1 struct {
+	s2 *s2[myError2]
+	i  int
+}
+
+type myError2 error
+
+type s2[T any] struct {
+	s1   *s1
+	errs []*T
+}
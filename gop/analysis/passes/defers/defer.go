@@ -6,9 +6,10 @@ package defers
 
 import (
 	_ "embed"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/defers"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -21,21 +22,21 @@ var doc string
 
 // Analyzer is the defer analyzer.
 var Analyzer = &analysis.Analyzer{
-	Name:     "defer",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Name:     "gopDefer",
+	Requires: []analysis.IAnalyzer{defers.Analyzer, inspect.Analyzer},
 	Doc:      analysisutil.MustExtractDoc(doc, "defer"),
 	Run:      run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	if !analysisutil.Imports(pass.Pkg, "time") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "time") {
 		return nil, nil
 	}
 
 	checkDeferCall := func(node ast.Node) bool {
 		switch v := node.(type) {
 		case *ast.CallExpr:
-			fn, ok := typeutil.Callee(pass.TypesInfo, v).(*types.Func)
+			fn, ok := typeutil.Callee(pass.GopTypesInfo, v).(*types.Func)
 			if ok && fn.Name() == "Since" && fn.Pkg().Path() == "time" {
 				pass.Reportf(v.Pos(), "call to time.Since is not deferred")
 			}
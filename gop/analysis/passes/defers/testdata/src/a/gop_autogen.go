@@ -0,0 +1,95 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"fmt"
+	"time"
+)
+
+const _ = true
+
+type y struct {
+}
+//line a.gop:12:1
+func Since() (t time.Duration) {
+//line a.gop:13:1
+	return
+}
+//line a.gop:16:1
+func x(time.Duration) {
+}
+//line a.gop:17:1
+func x2(float64) {
+}
+//line a.gop:19:1
+func good() {
+//line a.gop:21:1
+	now := time.Now()
+//line a.gop:22:1
+	defer func() {
+//line a.gop:23:1
+		fmt.Println(time.Since(now))
+	}()
+//line a.gop:25:1
+	evalBefore := time.Since(now)
+//line a.gop:26:1
+	defer fmt.Println(evalBefore)
+//line a.gop:27:1
+	do := func(f func()) {
+	}
+//line a.gop:28:1
+	defer do(func() {
+//line a.gop:28:1
+		time.Since(now)
+	})
+//line a.gop:29:1
+	defer fmt.Println(Since())
+}
+//line a.gop:35:1
+func (y) A(float64) {
+}
+//line a.gop:36:1
+func (*y) B(float64) {
+}
+//line a.gop:37:1
+func (y) C(time.Duration) {
+}
+//line a.gop:38:1
+func (*y) D(time.Duration) {
+}
+//line a.gop:40:1
+func bad() {
+//line a.gop:41:1
+	var zero time.Time
+//line a.gop:42:1
+	now := time.Now()
+//line a.gop:43:1
+	defer time.Since(zero)
+//line a.gop:44:1
+	defer time.Since(now)
+//line a.gop:45:1
+	defer fmt.Println(time.Since(now))
+//line a.gop:46:1
+	defer fmt.Println(time.Since(time.Now()))
+//line a.gop:47:1
+	defer x(time.Since(now))
+//line a.gop:48:1
+	defer x2(time.Since(now).Seconds())
+//line a.gop:49:1
+	defer y{}.A(time.Since(now).Seconds())
+//line a.gop:50:1
+	defer (&y{}).B(time.Since(now).Seconds())
+//line a.gop:51:1
+	defer y{}.C(time.Since(now))
+//line a.gop:52:1
+	defer (&y{}).D(time.Since(now))
+}
+//line a.gop:55:1
+func ugly() {
+//line a.gop:58:1
+	defer x(func() time.Duration {
+//line a.gop:58:1
+		return time.Since(time.Now())
+	}())
+}
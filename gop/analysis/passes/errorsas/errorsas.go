@@ -8,9 +8,10 @@ package errorsas
 
 import (
 	"errors"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/errorsas"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -24,10 +25,10 @@ The errorsas analysis reports calls to errors.As where the type
 of the second argument is not a pointer to a type implementing error.`
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "errorsas",
+	Name:     "gopErrorsas",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/errorsas",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{errorsas.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -39,7 +40,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return nil, nil
 	}
 
-	if !analysisutil.Imports(pass.Pkg, "errors") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "errors") {
 		return nil, nil // doesn't directly import errors
 	}
 
@@ -50,7 +51,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		fn := typeutil.StaticCallee(pass.TypesInfo, call)
+		fn := typeutil.StaticCallee(pass.GopTypesInfo, call)
 		if fn == nil {
 			return // not a static call
 		}
@@ -74,7 +75,7 @@ var errorType = types.Universe.Lookup("error").Type()
 
 // checkAsTarget reports an error if the second argument to errors.As is invalid.
 func checkAsTarget(pass *analysis.Pass, e ast.Expr) error {
-	t := pass.TypesInfo.Types[e].Type
+	t := pass.GopTypesInfo.Types[e].Type
 	if it, ok := t.Underlying().(*types.Interface); ok && it.NumMethods() == 0 {
 		// A target of interface{} is always allowed, since it often indicates
 		// a value forwarded from another source.
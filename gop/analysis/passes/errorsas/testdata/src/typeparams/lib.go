@@ -0,0 +1,19 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type myError[T any] struct{ t T }
+
+func (myError[T]) Error() string { return "" }
+
+type twice[T any] struct {
+	t T
+}
+
+func perr[T any]() *T { return nil }
+
+func two[T any]() (error, *T) { return nil, nil }
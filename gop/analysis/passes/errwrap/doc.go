@@ -0,0 +1,27 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errwrap defines an Analyzer that checks for "expr!" in functions
+// that can return an error.
+//
+// # Analyzer errwrap
+//
+// errwrap: check for panicking error wraps in functions that return an error
+//
+// The Go+ expression "expr!" panics when expr fails, while "expr?"
+// returns the error from the enclosing function. In a function whose
+// last result is an error, returning the error is almost always better:
+//
+//	func readConfig(name string) (string, error) {
+//		b := os.ReadFile(name)!
+//		return string(b), nil
+//	}
+//
+// will be changed to:
+//
+//	func readConfig(name string) (string, error) {
+//		b := os.ReadFile(name)?
+//		return string(b), nil
+//	}
+package errwrap
@@ -0,0 +1,129 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errwrap
+
+import (
+	_ "embed"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/gop/ast/inspector"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "gopErrwrap",
+	Doc:      analysisutil.MustExtractDoc(doc, "errwrap"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/errwrap",
+	Requires: []analysis.IAnalyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	info := pass.GopTypesInfo
+	if info == nil {
+		return nil, nil
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.ErrWrapExpr)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		e := n.(*ast.ErrWrapExpr)
+		if e.Tok != token.NOT || e.Default != nil {
+			return true
+		}
+		if sig := enclosingSignature(info, stack); sig != nil && returnsError(sig) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     e.Pos(),
+				End:     e.End(),
+				Message: `"!" panics on error in a function that returns an error: use "?" to return it`,
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: `Replace "!" with "?"`,
+					TextEdits: []analysis.TextEdit{{
+						Pos:     e.TokPos,
+						End:     e.TokPos + token.Pos(len("!")),
+						NewText: []byte("?"),
+					}},
+				}},
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// enclosingSignature returns the signature of the innermost function
+// (declaration, literal or lambda) of stack, or nil if it is unknown.
+func enclosingSignature(info *typesutil.Info, stack []ast.Node) *types.Signature {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var t types.Type
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			if obj := info.Defs[n.Name]; obj != nil {
+				t = obj.Type()
+			}
+		case *ast.FuncLit:
+			t = info.TypeOf(n)
+		case *ast.LambdaExpr, *ast.LambdaExpr2:
+			if t = info.TypeOf(n.(ast.Expr)); t == nil && i > 0 {
+				t = lambdaType(info, stack[i-1], n.(ast.Expr))
+			}
+		default:
+			continue
+		}
+		sig, _ := t.(*types.Signature)
+		return sig
+	}
+	return nil
+}
+
+// returnsError reports whether the last result of sig is an error.
+func returnsError(sig *types.Signature) bool {
+	results := sig.Results()
+	return results.Len() > 0 && types.Identical(results.At(results.Len()-1).Type(), errorType)
+}
+
+// lambdaType returns the type of the lambda argument of call, which the
+// type checker doesn't record: it's the type of the matching parameter.
+func lambdaType(info *typesutil.Info, call ast.Node, lambda ast.Expr) types.Type {
+	c, ok := call.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sig, ok := info.TypeOf(c.Fun).(*types.Signature)
+	if !ok {
+		return nil
+	}
+	params := sig.Params()
+	for i, arg := range c.Args {
+		if arg != lambda {
+			continue
+		}
+		if sig.Variadic() && i >= params.Len()-1 {
+			if s, ok := params.At(params.Len() - 1).Type().(*types.Slice); ok {
+				return s.Elem()
+			}
+			return nil
+		}
+		if i < params.Len() {
+			return params.At(i).Type()
+		}
+	}
+	return nil
+}
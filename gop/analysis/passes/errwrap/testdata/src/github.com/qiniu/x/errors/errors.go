@@ -0,0 +1,24 @@
+// Package errors synthesizes Go+'s package "github.com/qiniu/x/errors",
+// which wraps the errors of ErrWrap expressions, for unit-testing.
+package errors
+
+type Frame struct {
+	Err  error
+	Code string
+	File string
+	Line int
+	Fn   string
+	Args []interface{}
+}
+
+func NewFrame(err error, code, file string, line int, fn string, args ...interface{}) *Frame {
+	return &Frame{Err: err, Code: code, File: file, Line: line, Fn: fn, Args: args}
+}
+
+func (p *Frame) Error() string {
+	return p.Err.Error()
+}
+
+func (p *Frame) Unwrap() error {
+	return p.Err
+}
@@ -9,12 +9,13 @@ package fieldalignment
 import (
 	"bytes"
 	"fmt"
-	"go/ast"
-	"go/format"
-	"go/token"
 	"go/types"
 	"sort"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/token"
+	gofieldalignment "golang.org/x/tools/go/analysis/passes/fieldalignment"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/ast/inspector"
@@ -49,10 +50,10 @@ known as "false sharing" that slows down both goroutines.
 `
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "fieldalignment",
+	Name:     "gopFieldalignment",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/fieldalignment",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{gofieldalignment.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -67,7 +68,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		if s, ok = node.(*ast.StructType); !ok {
 			return
 		}
-		if tv, ok := pass.TypesInfo.Types[s]; ok {
+		if tv, ok := pass.GopTypesInfo.Types[s]; ok {
 			fieldalignment(pass, s, tv.Type.(*types.Struct))
 		}
 	})
@@ -0,0 +1,26 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fieldalignment_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gop/analysis/analysistest"
+	"golang.org/x/tools/gop/analysis/passes/fieldalignment"
+)
+
+func TestTest(t *testing.T) {
+	// Go+ files have no build constraints: a is checked on a 64-bit
+	// platform and b on a 32-bit one.
+	testdata := analysistest.TestData()
+	t.Run("amd64", func(t *testing.T) {
+		t.Setenv("GOARCH", "amd64")
+		analysistest.RunWithSuggestedFixes(t, testdata, fieldalignment.Analyzer, "a")
+	})
+	t.Run("386", func(t *testing.T) {
+		t.Setenv("GOARCH", "386")
+		analysistest.RunWithSuggestedFixes(t, testdata, fieldalignment.Analyzer, "b")
+	})
+}
@@ -0,0 +1,82 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+const _ = true
+
+type Good struct {
+	y int32
+	x byte
+	z byte
+}
+type Bad struct {
+	x byte
+	y int32
+	z byte
+}
+type ZeroGood struct {
+	a [0]byte
+	b uint32
+}
+type ZeroBad struct {
+	a uint32
+	b [0]byte
+}
+type NoNameGood struct {
+	Good
+	y int32
+	x byte
+	z byte
+}
+type NoNameBad struct {
+	Good
+	x byte
+	y int32
+	z byte
+}
+type WithComments struct {
+	a uint32
+	b [0]byte
+}
+type PointerGood struct {
+	P   *int
+	buf [1000]uintptr
+}
+type PointerBad struct {
+	buf [1000]uintptr
+	P   *int
+}
+type PointerSorta struct {
+	a struct {
+		p *int
+		q uintptr
+	}
+	b struct {
+		p *int
+		q [2]uintptr
+	}
+}
+type PointerSortaBad struct {
+	a struct {
+		p *int
+		q [2]uintptr
+	}
+	b struct {
+		p *int
+		q uintptr
+	}
+}
+type MultiField struct {
+	b  bool
+	i1 int
+	i2 int
+	a3 [3]bool
+	_  [0]func()
+}
+type Issue43233 struct {
+	AllowedEvents []*string
+	BlockedEvents []*string
+	APIVersion    string `mapstructure:"api_version"`
+	BaseURL       string `mapstructure:"base_url"`
+	AccessToken   string `mapstructure:"access_token"`
+}
@@ -13,7 +13,6 @@ package findcall
 import (
 	"fmt"
 	"go/types"
-	"log"
 
 	"github.com/goplus/gop/ast"
 	"golang.org/x/tools/go/analysis/passes/findcall"
@@ -46,8 +45,6 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return nil, nil
 	}
 
-	log.Println("==> gopFindcall:", pass.Pkg.Path())
-
 	noFacts := len(pass.AllObjectFacts()) == 0
 
 	for _, f := range pass.GopFiles {
@@ -7,9 +7,11 @@
 package httpresponse
 
 import (
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/httpresponse"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -33,10 +35,10 @@ This checker helps uncover latent nil dereference bugs by reporting a
 diagnostic for such mistakes.`
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "httpresponse",
+	Name:     "gopHttpresponse",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/httpresponse",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{httpresponse.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -45,7 +47,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 	// Fast path: if the package doesn't import net/http,
 	// skip the traversal.
-	if !analysisutil.Imports(pass.Pkg, "net/http") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "net/http") {
 		return nil, nil
 	}
 
@@ -57,7 +59,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			return true
 		}
 		call := n.(*ast.CallExpr)
-		if !isHTTPFuncOrMethodOnClient(pass.TypesInfo, call) {
+		if !isHTTPFuncOrMethodOnClient(pass.GopTypesInfo, call) {
 			return true // the function call is not related to this check.
 		}
 
@@ -105,7 +107,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 // isHTTPFuncOrMethodOnClient checks whether the given call expression is on
 // either a function of the net/http package or a method of http.Client that
 // returns (*http.Response, error).
-func isHTTPFuncOrMethodOnClient(info *types.Info, expr *ast.CallExpr) bool {
+func isHTTPFuncOrMethodOnClient(info *typesutil.Info, expr *ast.CallExpr) bool {
 	fun, _ := expr.Fun.(*ast.SelectorExpr)
 	sig, _ := info.Types[fun].Type.(*types.Signature)
 	if sig == nil {
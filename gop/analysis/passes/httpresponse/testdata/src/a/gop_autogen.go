@@ -0,0 +1,169 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"log"
+	"net/http"
+)
+
+const _ = true
+//line a.gop:8:1
+func goodHTTPGet() {
+//line a.gop:9:1
+	res, err := http.Get("http://foo.com")
+//line a.gop:10:1
+	if err != nil {
+//line a.gop:11:1
+		log.Fatal(err)
+	}
+//line a.gop:13:1
+	defer res.Body.Close()
+}
+//line a.gop:16:1
+func badHTTPGet() {
+//line a.gop:17:1
+	res, err := http.Get("http://foo.com")
+//line a.gop:18:1
+	defer res.Body.Close()
+//line a.gop:19:1
+	if err != nil {
+//line a.gop:20:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:24:1
+func badHTTPHead() {
+//line a.gop:25:1
+	res, err := http.Head("http://foo.com")
+//line a.gop:26:1
+	defer res.Body.Close()
+//line a.gop:27:1
+	if err != nil {
+//line a.gop:28:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:32:1
+func goodClientGet() {
+//line a.gop:33:1
+	client := http.DefaultClient
+//line a.gop:34:1
+	res, err := client.Get("http://foo.com")
+//line a.gop:35:1
+	if err != nil {
+//line a.gop:36:1
+		log.Fatal(err)
+	}
+//line a.gop:38:1
+	defer res.Body.Close()
+}
+//line a.gop:41:1
+func badClientPtrGet() {
+//line a.gop:42:1
+	client := http.DefaultClient
+//line a.gop:43:1
+	resp, err := client.Get("http://foo.com")
+//line a.gop:44:1
+	defer resp.Body.Close()
+//line a.gop:45:1
+	if err != nil {
+//line a.gop:46:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:50:1
+func badClientGet() {
+//line a.gop:51:1
+	client := http.Client{}
+//line a.gop:52:1
+	resp, err := client.Get("http://foo.com")
+//line a.gop:53:1
+	defer resp.Body.Close()
+//line a.gop:54:1
+	if err != nil {
+//line a.gop:55:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:59:1
+func badClientPtrDo() {
+//line a.gop:60:1
+	client := http.DefaultClient
+//line a.gop:61:1
+	req, err := http.NewRequest("GET", "http://foo.com", nil)
+//line a.gop:62:1
+	if err != nil {
+//line a.gop:63:1
+		log.Fatal(err)
+	}
+//line a.gop:66:1
+	resp, err := client.Do(req)
+//line a.gop:67:1
+	defer resp.Body.Close()
+//line a.gop:68:1
+	if err != nil {
+//line a.gop:69:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:73:1
+func badClientDo() {
+//line a.gop:74:1
+	var client http.Client
+//line a.gop:75:1
+	req, err := http.NewRequest("GET", "http://foo.com", nil)
+//line a.gop:76:1
+	if err != nil {
+//line a.gop:77:1
+		log.Fatal(err)
+	}
+//line a.gop:80:1
+	resp, err := client.Do(req)
+//line a.gop:81:1
+	defer resp.Body.Close()
+//line a.gop:82:1
+	if err != nil {
+//line a.gop:83:1
+		log.Fatal(err)
+	}
+}
+//line a.gop:87:1
+func goodUnwrapResp() {
+//line a.gop:88:1
+	unwrapResp := func(resp *http.Response, err error) *http.Response {
+//line a.gop:89:1
+		if err != nil {
+//line a.gop:90:1
+			panic(err)
+		}
+//line a.gop:92:1
+		return resp
+	}
+//line a.gop:94:1
+	resp := unwrapResp(http.Get("https://golang.org"))
+//line a.gop:97:1
+	defer resp.Body.Close()
+}
+//line a.gop:100:1
+func badUnwrapResp() {
+//line a.gop:101:1
+	unwrapResp := func(resp *http.Response, err error) string {
+//line a.gop:102:1
+		if err != nil {
+//line a.gop:103:1
+			panic(err)
+		}
+//line a.gop:105:1
+		return "https://golang.org/" + resp.Status
+	}
+//line a.gop:107:1
+	resp, err := http.Get(unwrapResp(http.Get("https://golang.org")))
+//line a.gop:108:1
+	defer resp.Body.Close()
+//line a.gop:109:1
+	if err != nil {
+//line a.gop:110:1
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+import "net/http"
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func mkClient[T any]() *T {
+	return nil
+}
+
+// User-defined type embedded "http.Client"
+type S[P any] struct {
+	http.Client
+}
+
+// User-defined Client type
+type C[P any] interface {
+	Get(url string) (resp *P, err error)
+}
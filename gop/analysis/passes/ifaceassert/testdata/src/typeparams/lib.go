@@ -0,0 +1,23 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type SourceReader[Source any] interface {
+	Read(p Source) (n int, err error)
+}
+
+type Float interface {
+	float32 | float64
+}
+
+type Doer[F Float] interface {
+	Do() F
+}
+
+type X[T any] struct{}
+
+func (x X[T]) m(T) {}
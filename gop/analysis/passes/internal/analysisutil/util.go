@@ -10,11 +10,13 @@ import (
 	"bytes"
 	"go/types"
 	"os"
+	"strconv"
 
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/printer"
 	"github.com/goplus/gop/token"
 	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gop/ast/astutil"
 )
 
 // Format returns a string representation of the expression.
@@ -30,7 +32,9 @@ func HasSideEffects(info *typesutil.Info, e ast.Expr) bool {
 	ast.Inspect(e, func(node ast.Node) bool {
 		switch n := node.(type) {
 		case *ast.CallExpr:
-			typVal := info.Types[n.Fun]
+			// Go+ doesn't record the type of a parenthesized
+			// conversion type, such as (func() int).
+			typVal := info.Types[astutil.Unparen(n.Fun)]
 			switch {
 			case typVal.IsType():
 				// Type conversion, which is safe.
@@ -120,3 +124,36 @@ func Imports(pkg *types.Package, path string) bool {
 	}
 	return false
 }
+
+// GopImports reports whether path is imported by pkg or by one of its Go+
+// files. The Go files of a Go+ package, such as gop_autogen.go, may be stale
+// or missing, so the import declarations of files are checked too.
+func GopImports(pkg *types.Package, files []*ast.File, path string) bool {
+	if Imports(pkg, path) {
+		return true
+	}
+	for _, f := range files {
+		for _, imp := range f.Imports {
+			if p, err := strconv.Unquote(imp.Path.Value); err == nil && p == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsBuiltin reports whether obj is the builtin function name.
+//
+// The builtin functions of Go+, such as append and len, are generally not
+// *types.Builtin objects but template functions of the builtin package,
+// which has an empty path.
+func IsBuiltin(obj types.Object, name string) bool {
+	if obj == nil || obj.Name() != name {
+		return false
+	}
+	if _, ok := obj.(*types.Builtin); ok {
+		return true
+	}
+	pkg := obj.Pkg()
+	return pkg != nil && pkg.Path() == ""
+}
@@ -6,6 +6,7 @@ package analysisutil_test
 
 import (
 	"go/types"
+	"reflect"
 	"testing"
 
 	"github.com/goplus/gop/ast"
@@ -50,3 +51,44 @@ func _() {
 		return true
 	})
 }
+
+func TestIsBuiltin(t *testing.T) {
+	src := `package p
+
+func _() {
+	var s []string
+	s = append(s, "a")
+	_ = len(s)
+}
+
+func _() {
+	append := func(s []int) []int { return s }
+	_ = append(nil)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.gop", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conf typesutil.CheckConfig
+	info := &typesutil.Info{
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	if _, err = conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+	var got []bool
+	ast.Inspect(file, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok {
+			fun := call.Fun.(*ast.Ident)
+			got = append(got, analysisutil.IsBuiltin(info.Uses[fun], fun.Name))
+		}
+		return true
+	})
+	// The last append is a local variable.
+	want := []bool{true, true, false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IsBuiltin = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lambdaparams defines an Analyzer that checks for unused
+// parameters of Go+ lambda expressions.
+//
+// # Analyzer lambdaparams
+//
+// lambdaparams: check for unused parameters of lambda expressions
+//
+// The lambdaparams checker reports parameters of lambda expressions
+// that are never used, such as x in:
+//
+//	onMsg "tick", (msg, x) => {
+//		echo msg
+//	}
+//
+// Their names should be replaced with "_", which documents that the
+// parameter is ignored on purpose.
+package lambdaparams
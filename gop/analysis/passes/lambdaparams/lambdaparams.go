@@ -0,0 +1,74 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lambdaparams
+
+import (
+	_ "embed"
+	"fmt"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "gopLambdaparams",
+	Doc:  analysisutil.MustExtractDoc(doc, "lambdaparams"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/lambdaparams",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	info := pass.GopTypesInfo
+	if info == nil {
+		return nil, nil
+	}
+	used := make(map[types.Object]bool)
+	for _, obj := range info.Uses {
+		used[obj] = true
+	}
+	// The Go+ inspector doesn't know lambda expressions: walk the files.
+	for _, f := range pass.GopFiles {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var params []*ast.Ident
+			switch n := n.(type) {
+			case *ast.LambdaExpr:
+				params = n.Lhs
+			case *ast.LambdaExpr2:
+				params = n.Lhs
+			default:
+				return true
+			}
+			for _, param := range params {
+				if param.Name == "_" {
+					continue
+				}
+				obj := info.Defs[param]
+				if obj == nil || used[obj] {
+					continue // unknown or used parameter
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:     param.Pos(),
+					End:     param.End(),
+					Message: fmt.Sprintf("unused lambda parameter: '%s'", param.Name),
+					SuggestedFixes: []analysis.SuggestedFix{{
+						Message: `Replace with "_"`,
+						TextEdits: []analysis.TextEdit{{
+							Pos:     param.Pos(),
+							End:     param.End(),
+							NewText: []byte("_"),
+						}},
+					}},
+				})
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
@@ -6,9 +6,11 @@ package loopclosure
 
 import (
 	_ "embed"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/loopclosure"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -20,10 +22,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "loopclosure",
+	Name:     "gopLoopclosure",
 	Doc:      analysisutil.MustExtractDoc(doc, "loopclosure"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/loopclosure",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{loopclosure.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -33,13 +35,14 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	nodeFilter := []ast.Node{
 		(*ast.RangeStmt)(nil),
 		(*ast.ForStmt)(nil),
+		(*ast.ForPhraseStmt)(nil),
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		// Find the variables updated by the loop statement.
 		var vars []types.Object
 		addVar := func(expr ast.Expr) {
 			if id, _ := expr.(*ast.Ident); id != nil {
-				if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+				if obj := pass.GopTypesInfo.ObjectOf(id); obj != nil {
 					vars = append(vars, obj)
 				}
 			}
@@ -62,6 +65,11 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				// e.g. for i := 0; i < n; i++
 				addVar(post.X)
 			}
+		case *ast.ForPhraseStmt:
+			// e.g. for k, v <- m
+			body = n.Body
+			addVar(n.Key)
+			addVar(n.Value)
 		}
 		if vars == nil {
 			return
@@ -88,7 +96,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				stmts = litStmts(s.Call.Fun)
 			case *ast.ExprStmt: // check for errgroup.Group.Go
 				if call, ok := s.X.(*ast.CallExpr); ok {
-					stmts = litStmts(goInvoke(pass.TypesInfo, call))
+					stmts = litStmts(goInvoke(pass.GopTypesInfo, call))
 				}
 			}
 			for _, stmt := range stmts {
@@ -106,7 +114,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			switch s := s.(type) {
 			case *ast.ExprStmt:
 				if call, ok := s.X.(*ast.CallExpr); ok {
-					for _, stmt := range parallelSubtest(pass.TypesInfo, call) {
+					for _, stmt := range parallelSubtest(pass.GopTypesInfo, call) {
 						reportCaptured(pass, vars, stmt)
 					}
 
@@ -127,7 +135,7 @@ func reportCaptured(pass *analysis.Pass, vars []types.Object, checkStmt ast.Stmt
 		if !ok {
 			return true
 		}
-		obj := pass.TypesInfo.Uses[id]
+		obj := pass.GopTypesInfo.Uses[id]
 		if obj == nil {
 			return true
 		}
@@ -169,6 +177,8 @@ func forEachLastStmt(stmts []ast.Stmt, onLast func(last ast.Stmt)) {
 		forEachLastStmt(s.Body.List, onLast)
 	case *ast.RangeStmt:
 		forEachLastStmt(s.Body.List, onLast)
+	case *ast.ForPhraseStmt:
+		forEachLastStmt(s.Body.List, onLast)
 	case *ast.SwitchStmt:
 		for _, c := range s.Body.List {
 			cc := c.(*ast.CaseClause)
@@ -190,15 +200,24 @@ func forEachLastStmt(stmts []ast.Stmt, onLast func(last ast.Stmt)) {
 }
 
 // litStmts returns all statements from the function body of a function
-// literal.
+// literal or a Go+ lambda expression. The results of a lambda expression
+// such as `x => x+i` are returned as expression statements.
 //
-// If fun is not a function literal, it returns nil.
+// If fun is not a function literal or a lambda expression, it returns nil.
 func litStmts(fun ast.Expr) []ast.Stmt {
-	lit, _ := fun.(*ast.FuncLit)
-	if lit == nil {
-		return nil
+	switch lit := fun.(type) {
+	case *ast.FuncLit:
+		return lit.Body.List
+	case *ast.LambdaExpr2:
+		return lit.Body.List
+	case *ast.LambdaExpr:
+		stmts := make([]ast.Stmt, len(lit.Rhs))
+		for i, x := range lit.Rhs {
+			stmts[i] = &ast.ExprStmt{X: x}
+		}
+		return stmts
 	}
-	return lit.Body.List
+	return nil
 }
 
 // goInvoke returns a function expression that would be called asynchronously
@@ -210,7 +229,7 @@ func litStmts(fun ast.Expr) []ast.Stmt {
 //	g.Go(func() error { ... })
 //
 // Currently only "golang.org/x/sync/errgroup.Group()" is considered.
-func goInvoke(info *types.Info, call *ast.CallExpr) ast.Expr {
+func goInvoke(info *typesutil.Info, call *ast.CallExpr) ast.Expr {
 	if !isMethodCall(info, call, "golang.org/x/sync/errgroup", "Group", "Go") {
 		return nil
 	}
@@ -243,7 +262,7 @@ func goInvoke(info *types.Info, call *ast.CallExpr) ast.Expr {
 //			})
 //		}
 //	}
-func parallelSubtest(info *types.Info, call *ast.CallExpr) []ast.Stmt {
+func parallelSubtest(info *typesutil.Info, call *ast.CallExpr) []ast.Stmt {
 	if !isMethodCall(info, call, "testing", "T", "Run") {
 		return nil
 	}
@@ -253,18 +272,26 @@ func parallelSubtest(info *types.Info, call *ast.CallExpr) []ast.Stmt {
 		return nil
 	}
 
-	lit, _ := call.Args[1].(*ast.FuncLit)
-	if lit == nil {
-		return nil
-	}
-
 	// Capture the *testing.T object for the first argument to the function
-	// literal.
-	if len(lit.Type.Params.List[0].Names) == 0 {
+	// literal or the lambda expression.
+	var tParam *ast.Ident
+	var body *ast.BlockStmt
+	switch lit := call.Args[1].(type) {
+	case *ast.FuncLit:
+		if len(lit.Type.Params.List[0].Names) == 0 {
+			return nil
+		}
+		tParam, body = lit.Type.Params.List[0].Names[0], lit.Body
+	case *ast.LambdaExpr2:
+		if len(lit.Lhs) == 0 {
+			return nil
+		}
+		tParam, body = lit.Lhs[0], lit.Body
+	default:
 		return nil
 	}
 
-	tObj := info.Defs[lit.Type.Params.List[0].Names[0]]
+	tObj := info.Defs[tParam]
 	if tObj == nil {
 		return nil
 	}
@@ -272,12 +299,12 @@ func parallelSubtest(info *types.Info, call *ast.CallExpr) []ast.Stmt {
 	// Match statements that occur after a call to t.Parallel following the final
 	// labeled statement in the function body.
 	//
-	// We iterate over lit.Body.List to have a simple, fast and "frequent enough"
-	// dominance relationship for t.Parallel(): lit.Body.List[i] dominates
-	// lit.Body.List[j] for i < j unless there is a jump.
+	// We iterate over body.List to have a simple, fast and "frequent enough"
+	// dominance relationship for t.Parallel(): body.List[i] dominates
+	// body.List[j] for i < j unless there is a jump.
 	var stmts []ast.Stmt
 	afterParallel := false
-	for _, stmt := range lit.Body.List {
+	for _, stmt := range body.List {
 		stmt, labeled := unlabel(stmt)
 		if labeled {
 			// Reset: naively we don't know if a jump could have caused the
@@ -337,7 +364,7 @@ func unlabel(stmt ast.Stmt) (ast.Stmt, bool) {
 
 // isMethodCall reports whether expr is a method call of
 // <pkgPath>.<typeName>.<method>.
-func isMethodCall(info *types.Info, expr ast.Expr, pkgPath, typeName, method string) bool {
+func isMethodCall(info *typesutil.Info, expr ast.Expr, pkgPath, typeName, method string) bool {
 	call, ok := expr.(*ast.CallExpr)
 	if !ok {
 		return false
@@ -0,0 +1,29 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func f[T any](data T) {
+	print(data)
+}
+
+func loop[P interface{ Go(func() error) }](grp P) {
+	var s []int
+	for i, v := range s {
+		// The checker only matches on methods "(*...errgroup.Group).Go".
+		grp.Go(func() error {
+			print(i)
+			print(v)
+			return nil
+		})
+	}
+}
+
+type T[P any] struct {
+	a P
+}
+
+func (t T[P]) Go(func() error) {}
@@ -7,9 +7,11 @@ package lostcancel
 import (
 	_ "embed"
 	"fmt"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/lostcancel"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/ctrlflow"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
@@ -22,11 +24,11 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name: "lostcancel",
+	Name: "gopLostcancel",
 	Doc:  analysisutil.MustExtractDoc(doc, "lostcancel"),
 	URL:  "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/lostcancel",
 	Run:  run,
-	Requires: []*analysis.Analyzer{
+	Requires: []analysis.IAnalyzer{lostcancel.Analyzer,
 		inspect.Analyzer,
 		ctrlflow.Analyzer,
 	},
@@ -48,7 +50,7 @@ var contextPackage = "context"
 // checkLostCancel analyzes a single named or literal function.
 func run(pass *analysis.Pass) (interface{}, error) {
 	// Fast path: bypass check if file doesn't use context.WithCancel.
-	if !analysisutil.Imports(pass.Pkg, contextPackage) {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, contextPackage) {
 		return nil, nil
 	}
 
@@ -69,9 +71,9 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	var funcScope *types.Scope
 	switch v := node.(type) {
 	case *ast.FuncLit:
-		funcScope = pass.TypesInfo.Scopes[v.Type]
+		funcScope = pass.GopTypesInfo.Scopes[v.Type]
 	case *ast.FuncDecl:
-		funcScope = pass.TypesInfo.Scopes[v.Type]
+		funcScope = pass.GopTypesInfo.Scopes[v.Type]
 	}
 
 	// Maps each cancel variable to its defining ValueSpec/AssignStmt.
@@ -101,7 +103,7 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 		//   ctx, cancel     = context.WithCancel(...)
 		//   var ctx, cancel = context.WithCancel(...)
 		//
-		if !isContextWithCancel(pass.TypesInfo, n) || !isCall(stack[len(stack)-2]) {
+		if !isContextWithCancel(pass.GopTypesInfo, n) || !isCall(stack[len(stack)-2]) {
 			return true
 		}
 		var id *ast.Ident // id of cancel var
@@ -121,13 +123,13 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 				pass.ReportRangef(id,
 					"the cancel function returned by context.%s should be called, not discarded, to avoid a context leak",
 					n.(*ast.SelectorExpr).Sel.Name)
-			} else if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+			} else if v, ok := pass.GopTypesInfo.Uses[id].(*types.Var); ok {
 				// If the cancel variable is defined outside function scope,
 				// do not analyze it.
 				if funcScope.Contains(v.Pos()) {
 					cancelvars[v] = stmt
 				}
-			} else if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+			} else if v, ok := pass.GopTypesInfo.Defs[id].(*types.Var); ok {
 				cancelvars[v] = stmt
 			}
 		}
@@ -144,7 +146,7 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	var sig *types.Signature
 	switch node := node.(type) {
 	case *ast.FuncDecl:
-		sig, _ = pass.TypesInfo.Defs[node.Name].Type().(*types.Signature)
+		sig, _ = pass.GopTypesInfo.Defs[node.Name].Type().(*types.Signature)
 		if node.Name.Name == "main" && sig.Recv() == nil && pass.Pkg.Name() == "main" {
 			// Returning from main.main terminates the process,
 			// so there's no need to cancel contexts.
@@ -153,7 +155,7 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 		g = cfgs.FuncDecl(node)
 
 	case *ast.FuncLit:
-		sig, _ = pass.TypesInfo.Types[node.Type].Type.(*types.Signature)
+		sig, _ = pass.GopTypesInfo.Types[node.Type].Type.(*types.Signature)
 		g = cfgs.FuncLit(node)
 	}
 	if sig == nil {
@@ -181,7 +183,7 @@ func isCall(n ast.Node) bool { _, ok := n.(*ast.CallExpr); return ok }
 
 // isContextWithCancel reports whether n is one of the qualified identifiers
 // context.With{Cancel,Timeout,Deadline}.
-func isContextWithCancel(info *types.Info, n ast.Node) bool {
+func isContextWithCancel(info *typesutil.Info, n ast.Node) bool {
 	sel, ok := n.(*ast.SelectorExpr)
 	if !ok {
 		return false
@@ -216,7 +218,7 @@ func lostCancelPath(pass *analysis.Pass, g *cfg.CFG, v *types.Var, stmt ast.Node
 			ast.Inspect(stmt, func(n ast.Node) bool {
 				switch n := n.(type) {
 				case *ast.Ident:
-					if pass.TypesInfo.Uses[n] == v {
+					if pass.GopTypesInfo.Uses[n] == v {
 						found = true
 					}
 				case *ast.ReturnStmt:
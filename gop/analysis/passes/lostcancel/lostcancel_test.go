@@ -9,14 +9,10 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/lostcancel"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	tests := []string{"a", "b"}
-	if typeparams.Enabled {
-		tests = append(tests, "typeparams")
-	}
+	tests := []string{"a", "b", "typeparams"}
 	analysistest.Run(t, testdata, lostcancel.Analyzer, tests...)
 }
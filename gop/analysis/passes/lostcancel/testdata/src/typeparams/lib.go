@@ -0,0 +1,24 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+import "time"
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+// User-defined Context that matches type "context.Context"
+type C1[P1 any, P2 any] interface {
+	Deadline() (deadline time.Time, ok P1)
+	Done() <-chan struct{}
+	Err() error
+	Value(key P2) P2
+}
+
+// User-defined Context that doesn't match type "context.Context"
+type C2[P any] interface {
+	WithCancel(parent C1[P, bool]) (ctx C1[P, bool], cancel func())
+}
+
+type C[P any] struct{ f func() P }
@@ -0,0 +1,19 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func f[P any]() {}
+
+func g[P1 any, P2 any](x P1) {}
+
+type T1[P any] struct {
+	f func() P
+}
+
+type T2[P1 any, P2 any] struct {
+	g func(P1) P2
+}
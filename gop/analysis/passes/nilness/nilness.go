@@ -10,10 +10,10 @@ import (
 	"go/token"
 	"go/types"
 
+	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/buildssa"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
-	"golang.org/x/tools/gop/ssa"
 	"golang.org/x/tools/internal/typeparams"
 )
 
@@ -21,23 +21,27 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "nilness",
+	Name:     "gopNilness",
 	Doc:      analysisutil.MustExtractDoc(doc, "nilness"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/nilness",
 	Run:      run,
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Requires: []analysis.IAnalyzer{buildssa.Analyzer},
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	ssainput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
 	for _, fn := range ssainput.SrcFuncs {
-		runFunc(pass, fn)
+		runFunc(pass, ssainput, fn)
 	}
 	return nil, nil
 }
 
-func runFunc(pass *analysis.Pass, fn *ssa.Function) {
+func runFunc(pass *analysis.Pass, ssainput *buildssa.SSA, fn *ssa.Function) {
 	reportf := func(category string, pos token.Pos, format string, args ...interface{}) {
+		// goxls: report at the position in the Go+ files.
+		if pos = ssainput.Pos(pos); !pos.IsValid() {
+			return
+		}
 		pass.Report(analysis.Diagnostic{
 			Pos:      pos,
 			Category: category,
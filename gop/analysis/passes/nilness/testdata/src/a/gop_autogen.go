@@ -0,0 +1,321 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "fmt"
+
+const _ = true
+
+type X struct {
+	f int
+	g int
+}
+type Y struct {
+	innerY
+}
+type innerY struct {
+	value int
+}
+//line a.gop:5:1
+func f(x *X, y *X) {
+//line a.gop:6:1
+	if x == nil {
+//line a.gop:7:1
+		fmt.Print(x.f)
+	} else {
+//line a.gop:9:1
+		fmt.Print(x.f)
+	}
+//line a.gop:12:1
+	if x == nil {
+//line a.gop:13:1
+		if nil != y {
+//line a.gop:14:1
+			fmt.Print(1)
+//line a.gop:15:1
+			panic(0)
+		}
+//line a.gop:17:1
+		x.f = 1
+//line a.gop:18:1
+		y.f = 1
+	}
+//line a.gop:21:1
+	var f func()
+//line a.gop:22:1
+	if f == nil {
+//line a.gop:23:1
+		go f()
+	} else {
+//line a.gop:28:1
+		defer f()
+	}
+}
+//line a.gop:32:1
+func f2(ptr *[3]int, i interface{}) {
+//line a.gop:33:1
+	if ptr != nil {
+//line a.gop:34:1
+		fmt.Print(ptr[:])
+//line a.gop:35:1
+		*ptr = [3]int{}
+//line a.gop:36:1
+		fmt.Print(*ptr)
+	} else {
+//line a.gop:38:1
+		fmt.Print(ptr[:])
+//line a.gop:39:1
+		*ptr = [3]int{}
+//line a.gop:40:1
+		fmt.Print(*ptr)
+//line a.gop:42:1
+		if ptr != nil {
+//line a.gop:46:1
+			fmt.Print(*ptr)
+		}
+	}
+//line a.gop:50:1
+	if i != nil {
+//line a.gop:51:1
+		fmt.Print(i.(interface {
+			f()
+		}))
+	} else {
+//line a.gop:53:1
+		fmt.Print(i.(interface {
+			f()
+		}))
+	}
+}
+//line a.gop:57:1
+func f3() error {
+//line a.gop:58:1
+	err := g()
+//line a.gop:59:1
+	if err != nil {
+//line a.gop:60:1
+		return err
+	}
+//line a.gop:62:1
+	if err != nil && err.Error() == "foo" {
+//line a.gop:63:1
+		fmt.Print(0)
+	}
+//line a.gop:65:1
+	ch := make(chan int)
+//line a.gop:66:1
+	if ch == nil {
+//line a.gop:67:1
+		fmt.Print(0)
+	}
+//line a.gop:69:1
+	if ch != nil {
+//line a.gop:70:1
+		fmt.Print(0)
+	}
+//line a.gop:72:1
+	return nil
+}
+//line a.gop:75:1
+func h(err error, b bool) {
+//line a.gop:76:1
+	if err != nil && b {
+//line a.gop:77:1
+		return
+	} else
+//line a.gop:78:1
+	if err != nil {
+//line a.gop:79:1
+		panic(err)
+	}
+}
+//line a.gop:83:1
+func i(*int) error {
+//line a.gop:84:1
+	for {
+//line a.gop:85:1
+		if
+//line a.gop:85:1
+		err := g(); err != nil {
+//line a.gop:86:1
+			return err
+		}
+	}
+}
+//line a.gop:91:1
+func f4(x *X) {
+//line a.gop:92:1
+	if x == nil {
+//line a.gop:93:1
+		panic(x)
+	}
+}
+//line a.gop:97:1
+func f5(x *X) {
+//line a.gop:98:1
+	panic(nil)
+}
+//line a.gop:101:1
+func f6(x *X) {
+//line a.gop:102:1
+	var err error
+//line a.gop:103:1
+	panic(err)
+}
+//line a.gop:106:1
+func f7() {
+//line a.gop:107:1
+	x, err := bad()
+//line a.gop:108:1
+	if err != nil {
+//line a.gop:109:1
+		panic(0)
+	}
+//line a.gop:111:1
+	if x == nil {
+//line a.gop:112:1
+		panic(err)
+	}
+}
+//line a.gop:116:1
+func bad() (*X, error) {
+//line a.gop:117:1
+	return nil, nil
+}
+//line a.gop:120:1
+func f8() {
+//line a.gop:121:1
+	var e error
+//line a.gop:122:1
+	v, _ := e.(interface{})
+//line a.gop:123:1
+	fmt.Print(v)
+}
+//line a.gop:126:1
+func f9(x interface {
+	a()
+	b()
+	c()
+}) {
+//line a.gop:131:1
+	x.b()
+//line a.gop:132:1
+	xx := interface {
+		a()
+		b()
+	}(x)
+//line a.gop:136:1
+	if xx != nil {
+//line a.gop:137:1
+		return
+	}
+//line a.gop:139:1
+	x.c()
+//line a.gop:140:1
+	xx.b()
+//line a.gop:141:1
+	xxx := interface {
+		a()
+	}(xx)
+//line a.gop:142:1
+	xxx.a()
+//line a.gop:144:1
+	if unknown() {
+//line a.gop:145:1
+		panic(x)
+	}
+//line a.gop:147:1
+	if unknown() {
+//line a.gop:148:1
+		panic(xx)
+	}
+//line a.gop:150:1
+	if unknown() {
+//line a.gop:151:1
+		panic(xxx)
+	}
+}
+//line a.gop:171:1
+func unknown() bool {
+//line a.gop:172:1
+	return false
+}
+//line a.gop:155:1
+func f10() {
+//line a.gop:156:1
+	s0 := make([]string, 0)
+//line a.gop:157:1
+	if s0 == nil {
+//line a.gop:158:1
+		fmt.Print(0)
+	}
+//line a.gop:161:1
+	var s1 []string
+//line a.gop:162:1
+	if s1 == nil {
+//line a.gop:163:1
+		fmt.Print(0)
+	}
+//line a.gop:165:1
+	s2 := s1[:][:]
+//line a.gop:166:1
+	if s2 == nil {
+//line a.gop:167:1
+		fmt.Print(0)
+	}
+}
+//line a.gop:175:1
+func f11(a interface{}) {
+//line a.gop:176:1
+	switch a.(type) {
+//line a.gop:177:1
+	case nil:
+//line a.gop:178:1
+		return
+	}
+//line a.gop:180:1
+	switch a.(type) {
+//line a.gop:181:1
+	case nil:
+//line a.gop:182:1
+		return
+	}
+}
+//line a.gop:186:1
+func f12(a interface{}) {
+//line a.gop:187:1
+	switch a {
+//line a.gop:188:1
+	case nil:
+//line a.gop:189:1
+		return
+	}
+//line a.gop:191:1
+	switch a {
+//line a.gop:194:1
+	case 5, nil:
+//line a.gop:196:1
+		return
+	}
+}
+//line a.gop:208:1
+func f13() {
+//line a.gop:209:1
+	var d *Y
+//line a.gop:210:1
+	fmt.Print(d.value)
+}
+//line a.gop:213:1
+func f14() {
+//line a.gop:214:1
+	var x struct {
+		f string
+	}
+//line a.gop:215:1
+	if x == (struct {
+		f string
+	}{}) {
+//line a.gop:216:1
+		fmt.Print(x)
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// gop can't compile a function without a body: it is declared in Go.
+
+func g() error
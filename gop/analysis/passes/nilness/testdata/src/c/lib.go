@@ -0,0 +1,5 @@
+package c
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type T[X any] struct{}
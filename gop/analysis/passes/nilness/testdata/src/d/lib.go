@@ -0,0 +1,28 @@
+package d
+
+// Go+ can't declare generic types and functions, call a method of a type
+// parameter value or call such a value: they are declared in Go.
+
+type message interface{ PR() }
+
+func paramNonnil[T message]() {
+	var messageT T
+	messageT.PR() // cannot conclude messageT is nil.
+}
+
+func param[T interface {
+	message
+	~*int | ~chan int
+}]() {
+	var messageT T // messageT is nil.
+	messageT.PR()  // nil receiver may be okay. See param[nilMsg].
+}
+
+func noninvoke[T ~func()]() {
+	var x T
+	x() // want "nil dereference in dynamic function call"
+}
+
+type allNillable[T ~*int | ~chan int] struct{}
+
+type notAll[T ~*int | ~chan int | ~int] struct{}
@@ -0,0 +1,23 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package overload defines an Analyzer that checks for ambiguous calls
+// of overloaded functions.
+//
+// # Analyzer overload
+//
+// overload: check for ambiguous calls of overloaded functions
+//
+// Go+ resolves a call of an overloaded function to the first overload,
+// in declaration order, that accepts its arguments. The overload checker
+// reports the calls that several overloads accept equally well, such as:
+//
+//	func Mul__0(a int, b float64) float64
+//	func Mul__1(a float64, b int) float64
+//
+//	mul 1, 2
+//
+// The chosen overload then depends on the declaration order. Converting
+// the arguments, for example mul(1, float64(2)), removes the ambiguity.
+package overload
@@ -0,0 +1,168 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package overload
+
+import (
+	_ "embed"
+	"go/constant"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/gop/ast/inspector"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "gopOverload",
+	Doc:      analysisutil.MustExtractDoc(doc, "overload"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/overload",
+	Requires: []analysis.IAnalyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	info := pass.GopTypesInfo
+	if info == nil {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		var id *ast.Ident
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			id = fun
+		case *ast.SelectorExpr:
+			id = fun.Sel
+		default:
+			return
+		}
+		_, objs := info.OverloadOf(id)
+		if len(objs) < 2 || call.Ellipsis.IsValid() {
+			return
+		}
+		args := make([]types.TypeAndValue, len(call.Args))
+		for i, arg := range call.Args {
+			tv, ok := info.Types[arg]
+			if !ok || tv.Type == nil {
+				return // e.g. a lambda: its type depends on the overload
+			}
+			if _, ok := tv.Type.(*types.Tuple); ok {
+				return // f(g()) with a multi-valued g
+			}
+			args[i] = tv
+		}
+		if n := ambiguities(objs, args); n > 1 {
+			pass.ReportRangef(call, "ambiguous call to overloaded function %s: %d overloads accept these arguments",
+				analysisutil.Format(pass.Fset, call.Fun), n)
+		}
+	})
+	return nil, nil
+}
+
+// ambiguities returns the number of overloads that accept args equally well.
+// An overload whose parameters have the types of the arguments (the default
+// ones for untyped constants) is better than the others, and so is a
+// non-variadic overload.
+func ambiguities(overloads []types.Object, args []types.TypeAndValue) int {
+	var candidates, exact, fixed int
+	for _, o := range overloads {
+		sig, ok := o.Type().(*types.Signature)
+		if !ok || !accepts(sig, args) {
+			continue
+		}
+		candidates++
+		if !sig.Variadic() {
+			fixed++
+			if identical(sig, args) {
+				exact++
+			}
+		}
+	}
+	switch {
+	case exact > 0:
+		return exact
+	case fixed > 0:
+		return fixed
+	}
+	return candidates
+}
+
+// accepts reports whether sig can be called with args.
+func accepts(sig *types.Signature, args []types.TypeAndValue) bool {
+	n := sig.Params().Len()
+	if sig.Variadic() {
+		if len(args) < n-1 {
+			return false
+		}
+	} else if len(args) != n {
+		return false
+	}
+	for i, arg := range args {
+		if !assignable(arg, paramType(sig, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// identical reports whether the parameters of the non-variadic sig have
+// the types of args.
+func identical(sig *types.Signature, args []types.TypeAndValue) bool {
+	for i, arg := range args {
+		if !types.Identical(types.Default(arg.Type), paramType(sig, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// paramType returns the type of the i'th parameter of sig, taking a
+// variadic parameter into account.
+func paramType(sig *types.Signature, i int) types.Type {
+	params := sig.Params()
+	if n := params.Len(); sig.Variadic() && i >= n-1 {
+		return params.At(n - 1).Type().(*types.Slice).Elem()
+	}
+	return params.At(i).Type()
+}
+
+// assignable reports whether arg is assignable to a variable of type t.
+func assignable(arg types.TypeAndValue, t types.Type) bool {
+	if b, ok := arg.Type.(*types.Basic); ok && arg.Value != nil && b.Info()&types.IsUntyped != 0 {
+		if tb, ok := t.Underlying().(*types.Basic); ok {
+			return representable(arg.Value, tb)
+		}
+	}
+	return types.AssignableTo(arg.Type, t)
+}
+
+// representable reports whether the untyped constant x is representable by
+// a value of the basic type t, ignoring overflows.
+func representable(x constant.Value, t *types.Basic) bool {
+	info := t.Info()
+	switch {
+	case info&types.IsInteger != 0:
+		return constant.ToInt(x).Kind() == constant.Int
+	case info&types.IsFloat != 0:
+		return constant.ToFloat(x).Kind() == constant.Float
+	case info&types.IsComplex != 0:
+		return constant.ToComplex(x).Kind() == constant.Complex
+	case info&types.IsString != 0:
+		return x.Kind() == constant.String
+	case info&types.IsBoolean != 0:
+		return x.Kind() == constant.Bool
+	}
+	return false
+}
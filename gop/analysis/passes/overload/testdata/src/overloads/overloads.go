@@ -0,0 +1,18 @@
+// Package overloads defines overloaded functions, as Go+ sees them.
+package overloads
+
+const GopPackage = true
+
+func Add__0(a, b int) int { return a + b }
+
+func Add__1(a, b float64) float64 { return a + b }
+
+func Add__2(a, b string) string { return a + b }
+
+func Mul__0(a int, b float64) float64 { return float64(a) * b }
+
+func Mul__1(a float64, b int) float64 { return a * float64(b) }
+
+func Join__0(sep string, elems ...string) string { return sep }
+
+func Join__1(elem string) string { return elem }
@@ -9,16 +9,11 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/printf"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	printf.Analyzer.Flags.Set("funcs", "Warn,Warnf")
 
-	tests := []string{"a", "b", "nofmt"}
-	if typeparams.Enabled {
-		tests = append(tests, "typeparams")
-	}
-	analysistest.Run(t, testdata, printf.Analyzer, tests...)
+	analysistest.Run(t, testdata, printf.Analyzer, "a", "typeparams", "b", "nofmt")
 }
@@ -0,0 +1,1228 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"fmt"
+	"b"
+	"log"
+	"math"
+	"os"
+	"testing"
+	"unsafe"
+)
+
+const _ = true
+// Error methods that do not satisfy the Error interface and should be checked.
+type errorTest1 int
+type errorTest2 int
+type errorTest3 int
+type errorTest4 int
+type errorTest5 int
+type errorTestOK int
+type someStruct struct {
+}
+type errorfStruct struct {
+}
+type errorfStringStruct struct {
+}
+type errorfIntStruct struct {
+}
+type stringer int
+type ptrStringer float64
+type embeddedStringer struct {
+	foo string
+	ptrStringer
+	bar int
+}
+type notstringer struct {
+	f float64
+}
+type stringerarray [4]float64
+type notstringerarray [4]float64
+// A data type we can print with "%d".
+type percentDStruct struct {
+	a int
+	b []byte
+	c *float64
+}
+// A data type we cannot print correctly with "%d".
+type notPercentDStruct struct {
+	a int
+	b []byte
+	c bool
+}
+// A data type we can print with "%s".
+type percentSStruct struct {
+	a string
+	b []byte
+	C stringerarray
+}
+type recursiveStringer int
+type recursivePtrStringer int
+type recursiveError int
+type recursivePtrError int
+type recursiveStringerAndError int
+type recursivePtrStringerAndError int
+// implements a String() method but with non-matching return types
+type nonStringerWrongReturn int
+// implements a String() method but with non-matching arguments
+type nonStringerWrongArgs int
+type cons struct {
+	car int
+	cdr *cons
+}
+type BoolFormatter bool
+// Formatter with value receiver
+type FormatterVal bool
+type RecursiveSlice []RecursiveSlice
+type RecursiveMap map[int]RecursiveMap
+type RecursiveStruct struct {
+	next *RecursiveStruct
+}
+type RecursiveStruct1 struct {
+	next *RecursiveStruct2
+}
+type RecursiveStruct2 struct {
+	next *RecursiveStruct1
+}
+type unexportedInterface struct {
+	f interface{}
+}
+// Issue 17798: unexported ptrStringer cannot be formatted.
+type unexportedStringer struct {
+	t ptrStringer
+}
+type unexportedStringerOtherFields struct {
+	s string
+	t ptrStringer
+	S string
+}
+// Issue 17798: unexported error cannot be formatted.
+type unexportedError struct {
+	e error
+}
+type unexportedErrorOtherFields struct {
+	s string
+	e error
+	S string
+}
+type errorer struct {
+}
+type unexportedCustomError struct {
+	e errorer
+}
+type errorInterface interface {
+	error
+	ExtraMethod()
+}
+type unexportedErrorInterface struct {
+	e errorInterface
+}
+//line a.gop:22:1
+func UnsafePointerPrintfTest() {
+//line a.gop:23:1
+	var up unsafe.Pointer
+//line a.gop:24:1
+	fmt.Printf("%p, %x %X", up, up, up)
+}
+//line a.gop:30:1
+func (errorTest1) Error(...interface{}) string {
+//line a.gop:31:1
+	return "hi"
+}
+//line a.gop:35:1
+func (errorTest2) Error(...interface{}) {
+}
+//line a.gop:40:1
+func (errorTest3) Error() {
+}
+//line a.gop:45:1
+func (errorTest4) Error() int {
+//line a.gop:46:1
+	return 3
+}
+//line a.gop:51:1
+func (errorTest5) error() {
+}
+//line a.gop:56:1
+func (errorTestOK) Error() string {
+//line a.gop:56:1
+	return ""
+}
+//line a.gop:58:1
+// This function never executes, but it serves as a simple test for the program.
+// Test with make test.
+func PrintfTests() {
+//line a.gop:61:1
+	var b bool
+//line a.gop:62:1
+	var i int
+//line a.gop:63:1
+	var r rune
+//line a.gop:64:1
+	var s string
+//line a.gop:65:1
+	var x float64
+//line a.gop:66:1
+	var p *int
+//line a.gop:67:1
+	var imap map[int]int
+//line a.gop:68:1
+	var fslice []float64
+//line a.gop:69:1
+	var c complex64
+//line a.gop:70:1
+	var err error
+//line a.gop:72:1
+	fmt.Printf("")
+//line a.gop:73:1
+	fmt.Printf("%b %b %b", 3, i, x)
+//line a.gop:74:1
+	fmt.Printf("%c %c %c %c", 3, i, 'x', r)
+//line a.gop:75:1
+	fmt.Printf("%d %d %d", 3, i, imap)
+//line a.gop:76:1
+	fmt.Printf("%e %e %e %e", 3e9, x, fslice, c)
+//line a.gop:77:1
+	fmt.Printf("%E %E %E %E", 3e9, x, fslice, c)
+//line a.gop:78:1
+	fmt.Printf("%f %f %f %f", 3e9, x, fslice, c)
+//line a.gop:79:1
+	fmt.Printf("%F %F %F %F", 3e9, x, fslice, c)
+//line a.gop:80:1
+	fmt.Printf("%g %g %g %g", 3e9, x, fslice, c)
+//line a.gop:81:1
+	fmt.Printf("%G %G %G %G", 3e9, x, fslice, c)
+//line a.gop:82:1
+	fmt.Printf("%b %b %b %b", 3e9, x, fslice, c)
+//line a.gop:83:1
+	fmt.Printf("%o %o", 3, i)
+//line a.gop:84:1
+	fmt.Printf("%O %O", 3, i)
+//line a.gop:85:1
+	fmt.Printf("%p", p)
+//line a.gop:86:1
+	fmt.Printf("%q %q %q %q", 3, i, 'x', r)
+//line a.gop:87:1
+	fmt.Printf("%s %s %s", "hi", s, []byte{65})
+//line a.gop:88:1
+	fmt.Printf("%t %t", true, b)
+//line a.gop:89:1
+	fmt.Printf("%T %T", 3, i)
+//line a.gop:90:1
+	fmt.Printf("%U %U", 3, i)
+//line a.gop:91:1
+	fmt.Printf("%v %v", 3, i)
+//line a.gop:92:1
+	fmt.Printf("%x %x %x %x %x %x %x", 3, i, "hi", s, x, c, fslice)
+//line a.gop:93:1
+	fmt.Printf("%X %X %X %X %X %X %X", 3, i, "hi", s, x, c, fslice)
+//line a.gop:94:1
+	fmt.Printf("%.*s %d %g", 3, "hi", 23, 2.3)
+//line a.gop:95:1
+	fmt.Printf("%s", &stringerv)
+//line a.gop:96:1
+	fmt.Printf("%v", &stringerv)
+//line a.gop:97:1
+	fmt.Printf("%T", &stringerv)
+//line a.gop:98:1
+	fmt.Printf("%s", &embeddedStringerv)
+//line a.gop:99:1
+	fmt.Printf("%v", &embeddedStringerv)
+//line a.gop:100:1
+	fmt.Printf("%T", &embeddedStringerv)
+//line a.gop:101:1
+	fmt.Printf("%v", notstringerv)
+//line a.gop:102:1
+	fmt.Printf("%T", notstringerv)
+//line a.gop:103:1
+	fmt.Printf("%q", stringerarrayv)
+//line a.gop:104:1
+	fmt.Printf("%v", stringerarrayv)
+//line a.gop:105:1
+	fmt.Printf("%s", stringerarrayv)
+//line a.gop:106:1
+	fmt.Printf("%v", notstringerarrayv)
+//line a.gop:107:1
+	fmt.Printf("%T", notstringerarrayv)
+//line a.gop:108:1
+	fmt.Printf("%d", new(fmt.Formatter))
+//line a.gop:109:1
+	fmt.Printf("%*%", 2)
+//line a.gop:110:1
+	fmt.Printf("%s", interface{}(nil))
+//line a.gop:111:1
+	fmt.Printf("%a", interface{}(new(BoolFormatter)))
+//line a.gop:113:1
+	fmt.Printf("%g", 1+2i)
+//line a.gop:114:1
+	fmt.Printf("%#e %#E %#f %#F %#g %#G", 1.2, 1.2, 1.2, 1.2, 1.2, 1.2)
+//line a.gop:116:1
+	fmt.Printf("%b", "hi")
+//line a.gop:117:1
+	fmt.Printf("%t", c)
+//line a.gop:118:1
+	fmt.Printf("%t", 1+2i)
+//line a.gop:119:1
+	fmt.Printf("%c", 2.3)
+//line a.gop:120:1
+	fmt.Printf("%d", 2.3)
+//line a.gop:121:1
+	fmt.Printf("%e", "hi")
+//line a.gop:122:1
+	fmt.Printf("%E", true)
+//line a.gop:123:1
+	fmt.Printf("%f", "hi")
+//line a.gop:124:1
+	fmt.Printf("%F", 'x')
+//line a.gop:125:1
+	fmt.Printf("%g", "hi")
+//line a.gop:126:1
+	fmt.Printf("%g", imap)
+//line a.gop:127:1
+	fmt.Printf("%G", i)
+//line a.gop:128:1
+	fmt.Printf("%o", x)
+//line a.gop:129:1
+	fmt.Printf("%O", x)
+//line a.gop:130:1
+	fmt.Printf("%p", nil)
+//line a.gop:131:1
+	fmt.Printf("%p", 23)
+//line a.gop:132:1
+	fmt.Printf("%q", x)
+//line a.gop:133:1
+	fmt.Printf("%s", b)
+//line a.gop:134:1
+	fmt.Printf("%s", byte(65))
+//line a.gop:135:1
+	fmt.Printf("%t", 23)
+//line a.gop:136:1
+	fmt.Printf("%U", x)
+//line a.gop:137:1
+	fmt.Printf("%x", nil)
+//line a.gop:138:1
+	fmt.Printf("%s", stringerv)
+//line a.gop:139:1
+	fmt.Printf("%t", stringerv)
+//line a.gop:140:1
+	fmt.Printf("%s", embeddedStringerv)
+//line a.gop:141:1
+	fmt.Printf("%t", embeddedStringerv)
+//line a.gop:142:1
+	fmt.Printf("%q", notstringerv)
+//line a.gop:143:1
+	fmt.Printf("%t", notstringerv)
+//line a.gop:144:1
+	fmt.Printf("%t", stringerarrayv)
+//line a.gop:145:1
+	fmt.Printf("%t", notstringerarrayv)
+//line a.gop:146:1
+	fmt.Printf("%q", notstringerarrayv)
+//line a.gop:147:1
+	fmt.Printf("%d", BoolFormatter(true))
+//line a.gop:148:1
+	fmt.Printf("%z", FormatterVal(true))
+//line a.gop:149:1
+	fmt.Printf("%d", FormatterVal(true))
+//line a.gop:150:1
+	fmt.Printf("%s", nonemptyinterface)
+//line a.gop:151:1
+	fmt.Printf("%.*s %d %6g", 3, "hi", 23, 'x')
+//line a.gop:152:1
+	fmt.Println()
+//line a.gop:153:1
+	fmt.Println("%s", "hi")
+//line a.gop:154:1
+	fmt.Println("%v", "hi")
+//line a.gop:155:1
+	fmt.Println("%T", "hi")
+//line a.gop:156:1
+	fmt.Println("%s"+" there", "hi")
+//line a.gop:157:1
+	fmt.Println("0.0%")
+//line a.gop:158:1
+	fmt.Printf("%s", "hi", 3)
+//line a.gop:159:1
+	_ = fmt.Sprintf("%"+"s", "hi", 3)
+//line a.gop:160:1
+	fmt.Printf("%s%%%d", "hi", 3)
+//line a.gop:161:1
+	fmt.Printf("%08s", "woo")
+//line a.gop:162:1
+	fmt.Printf("% 8s", "woo")
+//line a.gop:163:1
+	fmt.Printf("%.*d", 3, 3)
+//line a.gop:164:1
+	fmt.Printf("%.*d x", 3, 3, 3, 3)
+//line a.gop:165:1
+	fmt.Printf("%.*d x", "hi", 3)
+//line a.gop:166:1
+	fmt.Printf("%.*d x", i, 3)
+//line a.gop:167:1
+	fmt.Printf("%.*d x", s, 3)
+//line a.gop:168:1
+	fmt.Printf("%*% x", 0.22)
+//line a.gop:445:1
+	fmt.Printf("%q %q", multi()...) 
+//line a.gop:170:1
+	fmt.Printf("%#q", `blah`)
+//line a.gop:171:1
+	fmt.Printf("%#b", 3)
+//line a.gop:395:1
+	Printf("now is the time", "buddy")
+//line a.gop:174:1
+	Printf("hi")
+//line a.gop:175:1
+	const format = "%s %s\n"
+//line a.gop:176:1
+	Printf(format, "hi", "there")
+//line a.gop:177:1
+	Printf(format, "hi")
+//line a.gop:178:1
+	Printf("%s %d %.3v %q", "str", 4)
+//line a.gop:179:1
+	f := new(ptrStringer)
+//line a.gop:180:1
+	f.Warn(0, "%s", "hello", 3)
+//line a.gop:181:1
+	f.Warnf(0, "%s", "hello", 3)
+//line a.gop:182:1
+	f.Warnf(0, "%r", "hello")
+//line a.gop:183:1
+	f.Warnf(0, "%#s", "hello")
+//line a.gop:184:1
+	f.Warn2(0, "%s", "hello", 3)
+//line a.gop:185:1
+	f.Warnf2(0, "%s", "hello", 3)
+//line a.gop:186:1
+	f.Warnf2(0, "%r", "hello")
+//line a.gop:187:1
+	f.Warnf2(0, "%#s", "hello")
+//line a.gop:188:1
+	f.Wrap(0, "%s", "hello", 3)
+//line a.gop:189:1
+	f.Wrapf(0, "%s", "hello", 3)
+//line a.gop:190:1
+	f.Wrapf(0, "%r", "hello")
+//line a.gop:191:1
+	f.Wrapf(0, "%#s", "hello")
+//line a.gop:192:1
+	f.Wrap2(0, "%s", "hello", 3)
+//line a.gop:193:1
+	f.Wrapf2(0, "%s", "hello", 3)
+//line a.gop:194:1
+	f.Wrapf2(0, "%r", "hello")
+//line a.gop:195:1
+	f.Wrapf2(0, "%#s", "hello")
+//line a.gop:196:1
+	fmt.Printf("%#s", FormatterVal(true))
+//line a.gop:197:1
+	Printf("d%", 2)
+//line a.gop:198:1
+	Printf("%d", percentDV)
+//line a.gop:199:1
+	Printf("%d", &percentDV)
+//line a.gop:200:1
+	Printf("%d", notPercentDV)
+//line a.gop:201:1
+	Printf("%d", &notPercentDV)
+//line a.gop:202:1
+	Printf("%p", &notPercentDV)
+//line a.gop:203:1
+	Printf("%q", &percentDV)
+//line a.gop:204:1
+	Printf("%s", percentSV)
+//line a.gop:205:1
+	Printf("%s", &percentSV)
+//line a.gop:207:1
+	Printf("%[1]d", 3)
+//line a.gop:208:1
+	Printf("%[1]*d", 3, 1)
+//line a.gop:209:1
+	Printf("%[2]*[1]d", 1, 3)
+//line a.gop:210:1
+	Printf("%[2]*.[1]*[3]d", 2, 3, 4)
+//line a.gop:211:1
+	fmt.Fprintf(os.Stderr, "%[2]*.[1]*[3]d", 2, 3, 4)
+//line a.gop:213:1
+	Printf("%[xd", 3)
+//line a.gop:214:1
+	Printf("%[x]d x", 3)
+//line a.gop:215:1
+	Printf("%[3]*s x", "hi", 2)
+//line a.gop:216:1
+	_ = fmt.Sprintf("%[3]d x", 2)
+//line a.gop:217:1
+	Printf("%[2]*.[1]*[3]d x", 2, "hi", 4)
+//line a.gop:218:1
+	Printf("%[0]s x", "arg1")
+//line a.gop:219:1
+	Printf("%[0]d x", 1)
+//line a.gop:220:1
+	Printf("%[3]*.[2*[1]f", 1, 2, 3)
+//line a.gop:221:1
+	// Something that satisfies the error interface.
+	var e error
+//line a.gop:223:1
+	fmt.Println(e.Error())
+//line a.gop:224:1
+	// Something that looks like an error interface but isn't, such as the (*T).Error method
+	// in the testing package.
+	var et1 *testing.T
+//line a.gop:227:1
+	et1.Error()
+//line a.gop:228:1
+	et1.Error("hi")
+//line a.gop:229:1
+	et1.Error("%d", 3)
+//line a.gop:230:1
+	et1.Errorf("%s", 1)
+//line a.gop:231:1
+	var et3 errorTest3
+//line a.gop:232:1
+	et3.Error()
+//line a.gop:233:1
+	var et4 errorTest4
+//line a.gop:234:1
+	et4.Error()
+//line a.gop:235:1
+	var et5 errorTest5
+//line a.gop:236:1
+	et5.error()
+//line a.gop:237:1
+	// Interfaces can be used with any verb.
+	var iface interface {
+		ToTheMadness() bool
+	}
+//line a.gop:241:1
+	fmt.Printf("%f", iface)
+//line a.gop:243:1
+	Printf("%d", someFunction)
+//line a.gop:244:1
+	Printf("%v", someFunction)
+//line a.gop:400:1
+	Println(someFunction)
+//line a.gop:246:1
+	Printf("%p", someFunction)
+//line a.gop:247:1
+	Printf("%T", someFunction)
+//line a.gop:249:1
+	Printf("%p %x", recursiveStructV, recursiveStructV.next)
+//line a.gop:250:1
+	Printf("%p %x", recursiveStruct1V, recursiveStruct1V.next)
+//line a.gop:251:1
+	Printf("%p %x", recursiveSliceV, recursiveSliceV)
+//line a.gop:252:1
+	Printf("%p %x", recursiveMapV, recursiveMapV)
+//line a.gop:254:1
+	math.Log(3)
+//line a.gop:255:1
+	var t *testing.T
+//line a.gop:256:1
+	t.Log("%d", 3)
+//line a.gop:257:1
+	t.Logf("%d", 3)
+//line a.gop:258:1
+	t.Logf("%d", "hi")
+//line a.gop:411:1
+	Errorf(1, "%d", 3)
+//line a.gop:261:1
+	Errorf(1, "%d", "hi")
+//line a.gop:417:1
+	errorf("WARNING", "foobar")
+//line a.gop:265:1
+	errorf("INFO", "s=%s, n=%d", "foo", 1)
+//line a.gop:266:1
+	errorf("ERROR", "%d")
+//line a.gop:268:1
+	var tb testing.TB
+//line a.gop:269:1
+	tb.Errorf("%s", 1)
+//line a.gop:283:1
+	ss := &someStruct{}
+//line a.gop:284:1
+	ss.Log(someFunction, "foo")
+//line a.gop:285:1
+	ss.Error(someFunction, someFunction)
+//line a.gop:286:1
+	ss.Println()
+//line a.gop:287:1
+	ss.Println(1.234, "foo")
+//line a.gop:288:1
+	ss.Println(1, someFunction)
+//line a.gop:289:1
+	ss.log(someFunction)
+//line a.gop:290:1
+	ss.log(someFunction, "bar", 1.33)
+//line a.gop:291:1
+	ss.log(someFunction, someFunction)
+//line a.gop:294:1
+	Printf("%d %[3]d %d %[2]d x", 1, 2, 3, 4)
+//line a.gop:295:1
+	Printf("%d %[0]d %d %[2]d x", 1, 2, 3, 4)
+//line a.gop:296:1
+	Printf("%d %[3]d %d %[-2]d x", 1, 2, 3, 4)
+//line a.gop:297:1
+	Printf("%d %[3]d %d %[2234234234234]d x", 1, 2, 3, 4)
+//line a.gop:298:1
+	Printf("%d %[3]d %-10d %[2]d x", 1, 2, 3)
+//line a.gop:299:1
+	Printf("%[1][3]d x", 1, 2)
+//line a.gop:300:1
+	Printf("%[1]d x", 1, 2)
+//line a.gop:301:1
+	Printf("%d %[3]d %d %[2]d x", 1, 2, 3, 4, 5)
+//line a.gop:304:1
+	Printf("%p\n", os.Stdout)
+//line a.gop:305:1
+	Println(os.Stdout, "hello")
+//line a.gop:368:1
+	Printf(someString(), "hello")
+//line a.gop:310:1
+	log.Fatal("%d", 1)
+//line a.gop:311:1
+	log.Fatalf("%d", "x")
+//line a.gop:312:1
+	log.Fatalln("%d", 1)
+//line a.gop:313:1
+	log.Panic("%d", 1)
+//line a.gop:314:1
+	log.Panicf("%d", "x")
+//line a.gop:315:1
+	log.Panicln("%d", 1)
+//line a.gop:316:1
+	log.Print("%d", 1)
+//line a.gop:317:1
+	log.Printf("%d", "x")
+//line a.gop:318:1
+	log.Println("%d", 1)
+//line a.gop:320:1
+	// Methods too.
+	var l *log.Logger
+//line a.gop:322:1
+	l.Fatal("%d", 1)
+//line a.gop:323:1
+	l.Fatalf("%d", "x")
+//line a.gop:324:1
+	l.Fatalln("%d", 1)
+//line a.gop:325:1
+	l.Panic("%d", 1)
+//line a.gop:326:1
+	l.Panicf("%d", "x")
+//line a.gop:327:1
+	l.Panicln("%d", 1)
+//line a.gop:328:1
+	l.Print("%d", 1)
+//line a.gop:329:1
+	l.Printf("%d", "x")
+//line a.gop:330:1
+	l.Println("%d", 1)
+//line a.gop:802:1
+	dbg("", 1)
+//line a.gop:335:1
+	// %w
+	var errSubset interface {
+		A()
+		Error() string
+	}
+//line a.gop:340:1
+	_ = fmt.Errorf("%w", err)
+//line a.gop:341:1
+	_ = fmt.Errorf("%#w", err)
+//line a.gop:342:1
+	_ = fmt.Errorf("%[2]w %[1]s", "x", err)
+//line a.gop:343:1
+	_ = fmt.Errorf("%[2]w %[1]s", e, "x")
+//line a.gop:344:1
+	_ = fmt.Errorf("%w", "x")
+//line a.gop:345:1
+	_ = fmt.Errorf("%w %w", err, err)
+//line a.gop:346:1
+	_ = fmt.Errorf("%w", interface{}(nil))
+//line a.gop:347:1
+	_ = fmt.Errorf("%w", errorTestOK(0))
+//line a.gop:348:1
+	_ = fmt.Errorf("%w", errSubset)
+//line a.gop:349:1
+	fmt.Printf("%w", err)
+//line a.gop:350:1
+	var wt *testing.T
+//line a.gop:351:1
+	wt.Errorf("%w", err)
+//line a.gop:352:1
+	wt.Errorf("%[1][3]d x", 1, 2)
+//line a.gop:353:1
+	wt.Errorf("%[1]d x", 1, 2)
+//line a.gop:355:1
+	Errorf(0, "%w", err)
+//line a.gop:356:1
+	// %w should work on fmt.Errorf-based wrappers.
+	var es errorfStruct
+//line a.gop:358:1
+	var eis errorfIntStruct
+//line a.gop:359:1
+	var ess errorfStringStruct
+//line a.gop:360:1
+	es.Errorf("%w", err)
+//line a.gop:361:1
+	eis.Errorf(0, "%w", err)
+//line a.gop:362:1
+	ess.Errorf("ERROR", "%w", err)
+//line a.gop:363:1
+	fmt.Appendf(nil, "%d", "123")
+//line a.gop:364:1
+	fmt.Append(nil, "%d", 123)
+}
+
+var stringerv ptrStringer
+//line a.gop:456:1
+func (*ptrStringer) String() string {
+//line a.gop:457:1
+	return "string"
+}
+//line a.gop:460:1
+func (p *ptrStringer) Warn2(x int, args ...interface{}) string {
+//line a.gop:461:1
+	return p.Warn(x, args...)
+}
+//line a.gop:464:1
+func (p *ptrStringer) Warnf2(x int, format string, args ...interface{}) string {
+//line a.gop:465:1
+	return p.Warnf(x, format, args...)
+}
+//line a.gop:468:1
+// During testing -printf.funcs flag matches Warn.
+func (*ptrStringer) Warn(x int, args ...interface{}) string {
+//line a.gop:470:1
+	return "warn"
+}
+//line a.gop:473:1
+// During testing -printf.funcs flag matches Warnf.
+func (*ptrStringer) Warnf(x int, format string, args ...interface{}) string {
+//line a.gop:475:1
+	return "warnf"
+}
+//line a.gop:478:1
+func (p *ptrStringer) Wrap2(x int, args ...interface{}) string {
+//line a.gop:479:1
+	return p.Wrap(x, args...)
+}
+//line a.gop:482:1
+func (p *ptrStringer) Wrapf2(x int, format string, args ...interface{}) string {
+//line a.gop:483:1
+	return p.Wrapf(x, format, args...)
+}
+//line a.gop:486:1
+func (*ptrStringer) Wrap(x int, args ...interface{}) string {
+//line a.gop:487:1
+	return fmt.Sprint(args...)
+}
+//line a.gop:490:1
+func (*ptrStringer) Wrapf(x int, format string, args ...interface{}) string {
+//line a.gop:491:1
+	return fmt.Sprintf(format, args...)
+}
+//line a.gop:494:1
+func (*ptrStringer) BadWrap(x int, args ...interface{}) string {
+//line a.gop:495:1
+	return fmt.Sprint(args)
+}
+//line a.gop:498:1
+func (*ptrStringer) BadWrapf(x int, format string, args ...interface{}) string {
+//line a.gop:499:1
+	return fmt.Sprintf(format, args)
+}
+//line a.gop:502:1
+func (*ptrStringer) WrapfFalsePositive(x int, arg1 string, arg2 ...interface{}) string {
+//line a.gop:503:1
+	return fmt.Sprintf("%s %v", arg1, arg2)
+}
+
+var embeddedStringerv embeddedStringer
+var notstringerv notstringer
+var stringerarrayv stringerarray
+//line a.gop:522:1
+func (stringerarray) String() string {
+//line a.gop:523:1
+	return "string"
+}
+
+var notstringerarrayv notstringerarray
+//line a.gop:664:1
+func (*BoolFormatter) Format(fmt.State, rune) {
+}
+//line a.gop:670:1
+func (FormatterVal) Format(fmt.State, rune) {
+}
+
+var nonemptyinterface = interface {
+	f()
+}(nil)
+//line a.gop:443:1
+// multi is used by the test.
+func multi() []interface{} {
+//line a.gop:445:1
+	panic("don't call - testing only")
+}
+//line a.gop:393:1
+// Printf is used by the test so we must declare it.
+func Printf(format string, args ...interface{}) {
+//line a.gop:395:1
+	fmt.Printf(format, args...)
+}
+
+var percentDV percentDStruct
+var notPercentDV notPercentDStruct
+var percentSV percentSStruct
+//line a.gop:390:1
+// A function we use as a function value; it has no other purpose.
+func someFunction() {
+}
+//line a.gop:398:1
+// Println is used by the test so we must declare it.
+func Println(args ...interface{}) {
+//line a.gop:400:1
+	fmt.Println(args...)
+}
+
+var recursiveStructV = &RecursiveStruct{}
+var recursiveStruct1V = &RecursiveStruct1{}
+var recursiveSliceV = &RecursiveSlice{}
+var recursiveMapV = make(RecursiveMap)
+//line a.gop:408:1
+// Errorf is used by the test for a case in which the first parameter
+// is not a format string.
+func Errorf(i int, format string, args ...interface{}) {
+//line a.gop:411:1
+	fmt.Sprintf(format, args...)
+}
+//line a.gop:414:1
+// errorf is used by the test for a case in which the function accepts multiple
+// string parameters before variadic arguments
+func errorf(level string, format string, args ...interface{}) {
+//line a.gop:417:1
+	fmt.Sprintf(format, args...)
+}
+//line a.gop:372:1
+// Log is non-variadic user-define Println-like function.
+// Calls to this func must be skipped when checking
+// for Println-like arguments.
+func (ss *someStruct) Log(f func(), s string) {
+}
+//line a.gop:377:1
+// Error is variadic user-define Println-like function.
+// Calls to this func mustn't be checked for Println-like arguments,
+// since variadic arguments type isn't interface{}.
+func (ss *someStruct) Error(args ...func()) {
+}
+//line a.gop:382:1
+// Println is variadic user-defined Println-like function.
+// Calls to this func must be checked for Println-like arguments.
+func (ss *someStruct) Println(args ...interface{}) {
+}
+//line a.gop:386:1
+// log is variadic user-defined Println-like function.
+// Calls to this func must be checked for Println-like arguments.
+func (ss *someStruct) log(f func(), args ...interface{}) {
+}
+//line a.gop:368:1
+func someString() string {
+//line a.gop:368:1
+	return "X"
+}
+//line a.gop:797:1
+// Issue 26486.
+func dbg(format string, args ...interface{}) {
+//line a.gop:799:1
+	if format == "" {
+//line a.gop:800:1
+		format = "%v"
+	}
+//line a.gop:802:1
+	fmt.Printf(format, args...)
+}
+//line a.gop:422:1
+// Errorf is used to test %w works on errorf wrappers.
+func (errorfStruct) Errorf(format string, args ...interface{}) {
+//line a.gop:424:1
+	_ = fmt.Errorf(format, args...)
+}
+//line a.gop:437:1
+// Errorf is used by the test for a case in which the first parameter
+// is not a format string.
+func (errorfIntStruct) Errorf(i int, format string, args ...interface{}) {
+//line a.gop:440:1
+	_ = fmt.Errorf(format, args...)
+}
+//line a.gop:429:1
+// Errorf is used by the test for a case in which the function accepts multiple
+// string parameters before variadic arguments
+func (errorfStringStruct) Errorf(level string, format string, args ...interface{}) {
+//line a.gop:432:1
+	_ = fmt.Errorf(format, args...)
+}
+//line a.gop:403:1
+// printf is used by the test so we must declare it.
+func printf(format string, args ...interface{}) {
+//line a.gop:405:1
+	fmt.Printf(format, args...)
+}
+//line a.gop:450:1
+func (stringer) String() string {
+//line a.gop:450:1
+	return "string"
+}
+//line a.gop:565:1
+func (s recursiveStringer) String() string {
+//line a.gop:566:1
+	_ = fmt.Sprintf("%d", s)
+//line a.gop:567:1
+	_ = fmt.Sprintf("%#v", s)
+//line a.gop:568:1
+	_ = fmt.Sprintf("%v", s)
+//line a.gop:569:1
+	_ = fmt.Sprintf("%v", &s)
+//line a.gop:570:1
+	_ = fmt.Sprintf("%T", s)
+//line a.gop:571:1
+	return fmt.Sprintln(s)
+}
+//line a.gop:576:1
+func (p *recursivePtrStringer) String() string {
+//line a.gop:577:1
+	_ = fmt.Sprintf("%v", *p)
+//line a.gop:578:1
+	_ = fmt.Sprint(&p)
+//line a.gop:579:1
+	return fmt.Sprintln(p)
+}
+//line a.gop:584:1
+func (s recursiveError) Error() string {
+//line a.gop:585:1
+	_ = fmt.Sprintf("%d", s)
+//line a.gop:586:1
+	_ = fmt.Sprintf("%#v", s)
+//line a.gop:587:1
+	_ = fmt.Sprintf("%v", s)
+//line a.gop:588:1
+	_ = fmt.Sprintf("%v", &s)
+//line a.gop:589:1
+	_ = fmt.Sprintf("%T", s)
+//line a.gop:590:1
+	return fmt.Sprintln(s)
+}
+//line a.gop:595:1
+func (p *recursivePtrError) Error() string {
+//line a.gop:596:1
+	_ = fmt.Sprintf("%v", *p)
+//line a.gop:597:1
+	_ = fmt.Sprint(&p)
+//line a.gop:598:1
+	return fmt.Sprintln(p)
+}
+//line a.gop:603:1
+func (s recursiveStringerAndError) String() string {
+//line a.gop:604:1
+	_ = fmt.Sprintf("%d", s)
+//line a.gop:605:1
+	_ = fmt.Sprintf("%#v", s)
+//line a.gop:606:1
+	_ = fmt.Sprintf("%v", s)
+//line a.gop:607:1
+	_ = fmt.Sprintf("%v", &s)
+//line a.gop:608:1
+	_ = fmt.Sprintf("%T", s)
+//line a.gop:609:1
+	return fmt.Sprintln(s)
+}
+//line a.gop:612:1
+func (s recursiveStringerAndError) Error() string {
+//line a.gop:613:1
+	_ = fmt.Sprintf("%d", s)
+//line a.gop:614:1
+	_ = fmt.Sprintf("%#v", s)
+//line a.gop:615:1
+	_ = fmt.Sprintf("%v", s)
+//line a.gop:616:1
+	_ = fmt.Sprintf("%v", &s)
+//line a.gop:617:1
+	_ = fmt.Sprintf("%T", s)
+//line a.gop:618:1
+	return fmt.Sprintln(s)
+}
+//line a.gop:623:1
+func (p *recursivePtrStringerAndError) String() string {
+//line a.gop:624:1
+	_ = fmt.Sprintf("%v", *p)
+//line a.gop:625:1
+	_ = fmt.Sprint(&p)
+//line a.gop:626:1
+	return fmt.Sprintln(p)
+}
+//line a.gop:629:1
+func (p *recursivePtrStringerAndError) Error() string {
+//line a.gop:630:1
+	_ = fmt.Sprintf("%v", *p)
+//line a.gop:631:1
+	_ = fmt.Sprint(&p)
+//line a.gop:632:1
+	return fmt.Sprintln(p)
+}
+//line a.gop:638:1
+func (s nonStringerWrongReturn) String() (string, error) {
+//line a.gop:639:1
+	return "", fmt.Errorf("%v", s)
+}
+//line a.gop:645:1
+func (s nonStringerWrongArgs) String(i int) string {
+//line a.gop:646:1
+	return fmt.Sprintf("%d%v", i, s)
+}
+//line a.gop:654:1
+func (cons *cons) String() string {
+//line a.gop:655:1
+	if cons == nil {
+//line a.gop:656:1
+		return "nil"
+	}
+//line a.gop:658:1
+	_ = fmt.Sprint(cons.cdr)
+//line a.gop:659:1
+	return fmt.Sprintf("(%d . %v)", cons.car, cons.cdr)
+}
+//line a.gop:725:1
+func (e errorer) Error() string {
+//line a.gop:725:1
+	return "errorer"
+}
+//line a.gop:740:1
+func UnexportedStringerOrError() {
+//line a.gop:741:1
+	fmt.Printf("%s", unexportedInterface{"foo"})
+//line a.gop:742:1
+	fmt.Printf("%s", unexportedInterface{3})
+//line a.gop:744:1
+	us := unexportedStringer{}
+//line a.gop:745:1
+	fmt.Printf("%s", us)
+//line a.gop:746:1
+	fmt.Printf("%s", &us)
+//line a.gop:748:1
+	usf := unexportedStringerOtherFields{s: "foo", S: "bar"}
+//line a.gop:752:1
+	fmt.Printf("%s", usf)
+//line a.gop:753:1
+	fmt.Printf("%s", &usf)
+//line a.gop:755:1
+	ue := unexportedError{e: &errorer{}}
+//line a.gop:758:1
+	fmt.Printf("%s", ue)
+//line a.gop:759:1
+	fmt.Printf("%s", &ue)
+//line a.gop:761:1
+	uef := unexportedErrorOtherFields{s: "foo", e: &errorer{}, S: "bar"}
+//line a.gop:766:1
+	fmt.Printf("%s", uef)
+//line a.gop:767:1
+	fmt.Printf("%s", &uef)
+//line a.gop:769:1
+	uce := unexportedCustomError{e: errorer{}}
+//line a.gop:772:1
+	fmt.Printf("%s", uce)
+//line a.gop:774:1
+	uei := unexportedErrorInterface{}
+//line a.gop:775:1
+	fmt.Printf("%s", uei)
+//line a.gop:776:1
+	fmt.Println("foo\n", "bar")
+//line a.gop:778:1
+	fmt.Println("foo\n")
+//line a.gop:779:1
+	fmt.Println("foo" + "\n")
+//line a.gop:780:1
+	fmt.Println("foo\\n")
+//line a.gop:781:1
+	fmt.Println(`foo\n`)
+//line a.gop:783:1
+	intSlice := []int{3, 4}
+//line a.gop:784:1
+	fmt.Printf("%s", intSlice)
+//line a.gop:785:1
+	nonStringerArray := [1]unexportedStringer{unexportedStringer{}}
+//line a.gop:786:1
+	fmt.Printf("%s", nonStringerArray)
+//line a.gop:787:1
+	fmt.Printf("%s", []stringer{3, 4})
+//line a.gop:788:1
+	fmt.Printf("%s", [2]stringer{3, 4})
+}
+//line a.gop:791:1
+// TODO: Disable complaint about '0' for Go 1.10. To be fixed properly in 1.11.
+// See issues 23598 and 23605.
+func DisableErrorForFlag0() {
+//line a.gop:794:1
+	fmt.Printf("%0t", true)
+}
+//line a.gop:805:1
+func PointersToCompoundTypes() {
+//line a.gop:806:1
+	stringSlice := []string{"a", "b"}
+//line a.gop:807:1
+	fmt.Printf("%s", &stringSlice)
+//line a.gop:809:1
+	intSlice := []int{3, 4}
+//line a.gop:810:1
+	fmt.Printf("%s", &intSlice)
+//line a.gop:812:1
+	stringArray := [2]string{"a", "b"}
+//line a.gop:813:1
+	fmt.Printf("%s", &stringArray)
+//line a.gop:815:1
+	intArray := [2]int{3, 4}
+//line a.gop:816:1
+	fmt.Printf("%s", &intArray)
+//line a.gop:818:1
+	stringStruct := struct {
+		F string
+	}{"foo"}
+//line a.gop:819:1
+	fmt.Printf("%s", &stringStruct)
+//line a.gop:821:1
+	intStruct := struct {
+		F int
+	}{3}
+//line a.gop:822:1
+	fmt.Printf("%s", &intStruct)
+//line a.gop:824:1
+	stringMap := map[string]string{"foo": "bar"}
+//line a.gop:825:1
+	fmt.Printf("%s", &stringMap)
+//line a.gop:827:1
+	intMap := map[int]int{3: 4}
+//line a.gop:828:1
+	fmt.Printf("%s", &intMap)
+//line a.gop:830:1
+	type T2 struct {
+		X string
+	}
+//line a.gop:833:1
+	type T1 struct {
+		X *T2
+	}
+//line a.gop:836:1
+	fmt.Printf("%s\n", T1{&T2{"x"}})
+}
+//line a.gop:839:1
+// Printf wrappers from external package
+func externalPackage() {
+//line a.gop:841:1
+	b.Wrapf("%s", 1)
+//line a.gop:842:1
+	b.Wrap("%s", 1)
+//line a.gop:843:1
+	b.NoWrap("%s", 1)
+//line a.gop:844:1
+	b.Wrapf2("%s", 1)
+}
+//line a.gop:847:1
+func PointerVerbs() {
+//line a.gop:851:1
+	ptr := new(bool)
+//line a.gop:852:1
+	slice := []bool{}
+//line a.gop:853:1
+	array := [3]bool{}
+//line a.gop:854:1
+	map_ := map[bool]bool{}
+//line a.gop:855:1
+	chan_ := make(chan bool)
+//line a.gop:856:1
+	func_ := func(bool) {
+	}
+//line a.gop:859:1
+	fmt.Printf("%p", ptr)
+//line a.gop:860:1
+	fmt.Printf("%b", ptr)
+//line a.gop:861:1
+	fmt.Printf("%d", ptr)
+//line a.gop:862:1
+	fmt.Printf("%o", ptr)
+//line a.gop:863:1
+	fmt.Printf("%O", ptr)
+//line a.gop:864:1
+	fmt.Printf("%x", ptr)
+//line a.gop:865:1
+	fmt.Printf("%X", ptr)
+//line a.gop:868:1
+	fmt.Printf("%p", chan_)
+//line a.gop:869:1
+	fmt.Printf("%b", chan_)
+//line a.gop:870:1
+	fmt.Printf("%d", chan_)
+//line a.gop:871:1
+	fmt.Printf("%o", chan_)
+//line a.gop:872:1
+	fmt.Printf("%O", chan_)
+//line a.gop:873:1
+	fmt.Printf("%x", chan_)
+//line a.gop:874:1
+	fmt.Printf("%X", chan_)
+//line a.gop:877:1
+	fmt.Printf("%p", func_)
+//line a.gop:878:1
+	fmt.Printf("%b", func_)
+//line a.gop:879:1
+	fmt.Printf("%d", func_)
+//line a.gop:880:1
+	fmt.Printf("%o", func_)
+//line a.gop:881:1
+	fmt.Printf("%O", func_)
+//line a.gop:882:1
+	fmt.Printf("%x", func_)
+//line a.gop:883:1
+	fmt.Printf("%X", func_)
+//line a.gop:887:1
+	fmt.Printf("%p", slice)
+//line a.gop:888:1
+	fmt.Printf("%b", slice)
+//line a.gop:890:1
+	fmt.Printf("%d", slice)
+//line a.gop:892:1
+	fmt.Printf("%o", slice)
+//line a.gop:893:1
+	fmt.Printf("%O", slice)
+//line a.gop:895:1
+	fmt.Printf("%x", slice)
+//line a.gop:896:1
+	fmt.Printf("%X", slice)
+//line a.gop:899:1
+	fmt.Printf("%p", array)
+//line a.gop:900:1
+	fmt.Printf("%b", array)
+//line a.gop:901:1
+	fmt.Printf("%d", array)
+//line a.gop:902:1
+	fmt.Printf("%o", array)
+//line a.gop:903:1
+	fmt.Printf("%O", array)
+//line a.gop:904:1
+	fmt.Printf("%x", array)
+//line a.gop:905:1
+	fmt.Printf("%X", array)
+//line a.gop:908:1
+	fmt.Printf("%p", map_)
+//line a.gop:909:1
+	fmt.Printf("%b", map_)
+//line a.gop:911:1
+	fmt.Printf("%d", map_)
+//line a.gop:913:1
+	fmt.Printf("%o", map_)
+//line a.gop:914:1
+	fmt.Printf("%O", map_)
+//line a.gop:916:1
+	fmt.Printf("%x", map_)
+//line a.gop:917:1
+	fmt.Printf("%X", map_)
+}
@@ -0,0 +1,34 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package b
+
+import "fmt"
+
+const _ = true
+//line b.gop:5:1
+// Wrapf is a printf wrapper.
+func Wrapf(format string, args ...interface{}) {
+//line b.gop:7:1
+	fmt.Sprintf(format, args...)
+}
+//line b.gop:10:1
+// Wrap is a print wrapper.
+func Wrap(args ...interface{}) {
+//line b.gop:12:1
+	fmt.Sprint(args...)
+}
+//line b.gop:15:1
+// NoWrap is not a wrapper.
+func NoWrap(format string, args ...interface{}) {
+}
+//line b.gop:19:1
+// Wrapf2 is another printf wrapper.
+func Wrapf2(format string, args ...interface{}) string {
+//line b.gop:26:1
+	if false {
+//line b.gop:27:1
+		fmt.Sprintf(format, args...)
+	}
+//line b.gop:32:1
+	return fmt.Sprintf("("+format+")", args...)
+}
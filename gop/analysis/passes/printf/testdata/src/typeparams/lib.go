@@ -0,0 +1,96 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+import "fmt"
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+// Go+ can't refer to the type parameters of a receiver in a method body
+// either, so the values of type parameter types are fields of the receiver.
+
+type BasicTypeParams[T interface{ ~int }, E error, F fmt.Formatter, S fmt.Stringer, A any] struct {
+	t T
+	e E
+	f F
+	s S
+	a A
+}
+
+type Constraint interface {
+	~int
+}
+
+type NamedConstraints_Issue49597[T Constraint] struct {
+	t T
+}
+
+type NestedTypeParams[T interface{ ~int }, S interface{ ~string }] struct {
+	x struct {
+		f int
+		t T
+	}
+	y struct {
+		f string
+		t S
+	}
+	m1 map[T]T
+	m2 map[S]S
+}
+
+type RecursiveTypeParams[T1 ~[]T2, T2 ~[]T1 | string, T3 ~struct{ F T3 }] struct {
+	t1 T1
+	t2 T2
+	t3 T3
+}
+
+type RecusivePointers[T1 ~*T2, T2 ~*T1] struct {
+	t1 T1
+	t2 T2
+}
+
+type EmptyTypeSet[T interface {
+	int | string
+	float64
+}] struct {
+	t T
+}
+
+type PointerRules[T ~*[]int | *[2]int] struct {
+	t T
+}
+
+type InterfacePromotion[E interface {
+	~int
+	Error() string
+}, S interface {
+	float64
+	String() string
+}] struct {
+	e E
+	s S
+}
+
+type myInt int
+
+type TermReduction[T1 interface{ ~int | string }, T2 interface {
+	~int | string
+	myInt
+}] struct {
+	t1 T1
+	t2 T2
+}
+
+type U[T any] struct{}
+
+type S[T comparable] struct {
+	t T
+}
+
+func (s S[T]) String() T {
+	fmt.Println(s) // Not flagged. We currently do not consider String() T to implement fmt.Stringer (see #55928).
+	return s.t
+}
+
+type N[T any] int
@@ -6,11 +6,11 @@ package printf
 
 import (
 	"fmt"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
 	"golang.org/x/tools/gop/analysis"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
@@ -26,7 +26,7 @@ func matchArgType(pass *analysis.Pass, t printfArgType, arg ast.Expr) (reason st
 		return "", true
 	}
 
-	typ := pass.TypesInfo.Types[arg].Type
+	typ := argType(pass, arg)
 	if typ == nil {
 		return "", true // probably a type check problem
 	}
@@ -36,6 +36,13 @@ func matchArgType(pass *analysis.Pass, t printfArgType, arg ast.Expr) (reason st
 	return m.reason, ok
 }
 
+// argType returns the type of the argument arg. Go+ records the untyped type
+// of a constant argument, which is converted to its default type when passed
+// to an interface parameter.
+func argType(pass *analysis.Pass, arg ast.Expr) types.Type {
+	return types.Default(pass.GopTypesInfo.Types[arg].Type)
+}
+
 // argMatcher recursively matches types against the printfArgType t.
 //
 // To short-circuit recursion, it keeps track of types that have already been
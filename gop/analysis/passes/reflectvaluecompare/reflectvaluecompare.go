@@ -6,10 +6,11 @@ package reflectvaluecompare
 
 import (
 	_ "embed"
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/reflectvaluecompare"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -21,10 +22,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "reflectvaluecompare",
+	Name:     "gopReflectvaluecompare",
 	Doc:      analysisutil.MustExtractDoc(doc, "reflectvaluecompare"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/reflectvaluecompare",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{reflectvaluecompare.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -49,7 +50,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 				}
 			}
 		case *ast.CallExpr:
-			fn, ok := typeutil.Callee(pass.TypesInfo, n).(*types.Func)
+			fn, ok := typeutil.Callee(pass.GopTypesInfo, n).(*types.Func)
 			if !ok {
 				return
 			}
@@ -63,7 +64,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 // isReflectValue reports whether the type of e is reflect.Value.
 func isReflectValue(pass *analysis.Pass, e ast.Expr) bool {
-	tv, ok := pass.TypesInfo.Types[e]
+	tv, ok := pass.GopTypesInfo.Types[e]
 	if !ok { // no type info, something else is wrong
 		return false
 	}
@@ -0,0 +1,70 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "reflect"
+
+const _ = true
+//line a.gop:13:1
+func f() {
+//line a.gop:14:1
+	var x, y reflect.Value
+//line a.gop:15:1
+	var a, b interface{}
+//line a.gop:16:1
+	_ = x == y
+//line a.gop:17:1
+	_ = x == a
+//line a.gop:18:1
+	_ = a == x
+//line a.gop:19:1
+	_ = a == b
+//line a.gop:22:1
+	_ = a == (reflect.Value{})
+//line a.gop:23:1
+	_ = (reflect.Value{}) == a
+//line a.gop:24:1
+	_ = (reflect.Value{}) == (reflect.Value{})
+}
+//line a.gop:26:1
+func g() {
+//line a.gop:27:1
+	var x, y reflect.Value
+//line a.gop:28:1
+	var a, b interface{}
+//line a.gop:29:1
+	_ = x != y
+//line a.gop:30:1
+	_ = x != a
+//line a.gop:31:1
+	_ = a != x
+//line a.gop:32:1
+	_ = a != b
+//line a.gop:35:1
+	_ = a != (reflect.Value{})
+//line a.gop:36:1
+	_ = (reflect.Value{}) != a
+//line a.gop:37:1
+	_ = (reflect.Value{}) != (reflect.Value{})
+}
+//line a.gop:39:1
+func h() {
+//line a.gop:40:1
+	var x, y reflect.Value
+//line a.gop:41:1
+	var a, b interface{}
+//line a.gop:42:1
+	reflect.DeepEqual(x, y)
+//line a.gop:43:1
+	reflect.DeepEqual(x, a)
+//line a.gop:44:1
+	reflect.DeepEqual(a, x)
+//line a.gop:45:1
+	reflect.DeepEqual(a, b)
+//line a.gop:48:1
+	reflect.DeepEqual(reflect.Value{}, a)
+//line a.gop:49:1
+	reflect.DeepEqual(a, reflect.Value{})
+//line a.gop:50:1
+	reflect.DeepEqual(reflect.Value{}, reflect.Value{})
+}
@@ -6,10 +6,11 @@ package shadow
 
 import (
 	_ "embed"
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/shadow"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -22,10 +23,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "shadow",
+	Name:     "gopShadow",
 	Doc:      analysisutil.MustExtractDoc(doc, "shadow"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/shadow",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{shadow.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -40,7 +41,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
 	spans := make(map[types.Object]span)
-	for id, obj := range pass.TypesInfo.Defs {
+	for id, obj := range pass.GopTypesInfo.Defs {
 		// Ignore identifiers that don't denote objects
 		// (package names, symbolic variables such as t
 		// in t := x.(type) of type switch headers).
@@ -48,10 +49,10 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			growSpan(spans, obj, id.Pos(), id.End())
 		}
 	}
-	for id, obj := range pass.TypesInfo.Uses {
+	for id, obj := range pass.GopTypesInfo.Uses {
 		growSpan(spans, obj, id.Pos(), id.End())
 	}
-	for node, obj := range pass.TypesInfo.Implicits {
+	for node, obj := range pass.GopTypesInfo.Implicits {
 		// A type switch with a short variable declaration
 		// such as t := x.(type) doesn't declare the symbolic
 		// variable (t in the example) at the switch header;
@@ -64,6 +65,31 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			growSpan(spans, obj, cc.Colon, cc.Colon)
 		}
 	}
+	// Go+ doesn't record these implicitly declared variables, which have no
+	// position either: look them up in the scopes of the type cases and
+	// assume they are declared at the symbolic variable.
+	inspect.Preorder([]ast.Node{(*ast.TypeSwitchStmt)(nil)}, func(n ast.Node) {
+		ts := n.(*ast.TypeSwitchStmt)
+		a, ok := ts.Assign.(*ast.AssignStmt)
+		if !ok || len(a.Lhs) != 1 {
+			return
+		}
+		id, ok := a.Lhs[0].(*ast.Ident)
+		if !ok {
+			return
+		}
+		for _, stmt := range ts.Body.List {
+			cc, ok := stmt.(*ast.CaseClause)
+			if !ok {
+				continue
+			}
+			if scope := pass.GopTypesInfo.Scopes[cc]; scope != nil {
+				if obj := scope.Lookup(id.Name); obj != nil {
+					growSpan(spans, obj, id.Pos(), cc.Colon)
+				}
+			}
+		}
+	})
 
 	nodeFilter := []ast.Node{
 		(*ast.AssignStmt)(nil),
@@ -229,7 +255,7 @@ func checkShadowing(pass *analysis.Pass, spans map[types.Object]span, ident *ast
 		// Can't shadow the blank identifier.
 		return
 	}
-	obj := pass.TypesInfo.Defs[ident]
+	obj := pass.GopTypesInfo.Defs[ident]
 	if obj == nil {
 		return
 	}
@@ -262,7 +288,11 @@ func checkShadowing(pass *analysis.Pass, spans map[types.Object]span, ident *ast
 	}
 	// Don't complain if the types differ: that implies the programmer really wants two different things.
 	if types.Identical(obj.Type(), shadowed.Type()) {
-		line := pass.Fset.Position(shadowed.Pos()).Line
+		pos := shadowed.Pos()
+		if !pos.IsValid() {
+			pos = spans[shadowed].min // a Go+ type switch variable
+		}
+		line := pass.Fset.Position(pos).Line
 		pass.ReportRangef(ident, "declaration of %q shadows declaration at line %d", obj.Name(), line)
 	}
 }
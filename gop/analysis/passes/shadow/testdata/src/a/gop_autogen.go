@@ -0,0 +1,159 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"fmt"
+	"os"
+)
+
+const _ = true
+//line a.gop:13:1
+func ShadowRead(f *os.File, buf []byte) (err error) {
+//line a.gop:14:1
+	var x int
+//line a.gop:15:1
+	if f != nil {
+//line a.gop:16:1
+		err := 3
+//line a.gop:17:1
+		_ = err
+	}
+//line a.gop:19:1
+	if f != nil {
+//line a.gop:20:1
+		_, err := f.Read(buf)
+//line a.gop:21:1
+		if err != nil {
+//line a.gop:22:1
+			return err
+		}
+//line a.gop:24:1
+		i := 3
+//line a.gop:25:1
+		_ = i
+	}
+//line a.gop:27:1
+	if f != nil {
+//line a.gop:28:1
+		x := one()
+//line a.gop:29:1
+		var _, err = f.Read(buf)
+//line a.gop:30:1
+		if x == 1 && err != nil {
+//line a.gop:31:1
+			return err
+		}
+	}
+//line a.gop:34:1
+	for
+//line a.gop:34:1
+	i := 0; i < 10;
+//line a.gop:34:1
+	i++ {
+//line a.gop:35:1
+		i := i
+//line a.gop:36:1
+		go func() {
+//line a.gop:37:1
+			fmt.Println(i)
+		}()
+	}
+//line a.gop:40:1
+	var shadowTemp interface{}
+//line a.gop:41:1
+	switch shadowTemp := shadowTemp.(type) {
+//line a.gop:42:1
+	case int:
+//line a.gop:43:1
+		fmt.Println("OK")
+//line a.gop:44:1
+		_ = shadowTemp
+	}
+//line a.gop:46:1
+	if
+//line a.gop:46:1
+	shadowTemp := shadowTemp; true {
+//line a.gop:47:1
+		var f *os.File
+//line a.gop:48:1
+		// The declaration of x is a shadow because x is mentioned below.
+		var x int
+//line a.gop:50:1
+		_, _, _ = x, f, shadowTemp
+	}
+//line a.gop:53:1
+	_, _ = err, x
+//line a.gop:54:1
+	return
+}
+//line a.gop:57:1
+func one() int {
+//line a.gop:58:1
+	return 1
+}
+//line a.gop:61:1
+// Must not complain with an internal error for the
+// implicitly declared type switch variable v.
+func issue26725(x interface{}) int {
+//line a.gop:64:1
+	switch v := x.(type) {
+//line a.gop:65:1
+	case int, int32:
+//line a.gop:66:1
+		if
+//line a.gop:66:1
+		v, ok := x.(int); ok {
+//line a.gop:67:1
+			return v
+		}
+//line a.gop:69:1
+	case int64:
+//line a.gop:70:1
+		return int(v)
+	}
+//line a.gop:72:1
+	return 0
+}
+//line a.gop:75:1
+// Verify that implicitly declared variables from
+// type switches are considered in shadowing analysis.
+func shadowTypeSwitch(a interface{}) {
+//line a.gop:78:1
+	switch t := a.(type) {
+//line a.gop:79:1
+	case int:
+//line a.gop:82:1
+		{
+//line a.gop:81:1
+			t := 0
+//line a.gop:82:1
+			_ = t
+		}
+//line a.gop:84:1
+		_ = t
+//line a.gop:85:1
+	case uint:
+//line a.gop:88:1
+		{
+//line a.gop:87:1
+			t := uint(0)
+//line a.gop:88:1
+			_ = t
+		}
+	}
+}
+//line a.gop:93:1
+func shadowBlock() {
+//line a.gop:94:1
+	var a int
+//line a.gop:97:1
+	{
+//line a.gop:96:1
+		var a = 3
+//line a.gop:97:1
+		_ = a
+	}
+//line a.gop:99:1
+	_ = a
+}
@@ -11,26 +11,27 @@ package shift
 // expressions (such as runtime.GOARCH=="386").
 
 import (
-	"go/ast"
 	"go/constant"
-	"go/token"
 	"go/types"
 	"math"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/shift"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/gop/ast/inspector"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 const Doc = "check for shifts that equal or exceed the width of the integer"
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "shift",
+	Name:     "gopShift",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/shift",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{shift.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -45,7 +46,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		// TODO(adonovan): move updateDead into this file.
-		updateDead(pass.TypesInfo, dead, n)
+		updateDead(pass.GopTypesInfo, dead, n)
 	})
 
 	nodeFilter = []ast.Node{
@@ -78,14 +79,14 @@ func run(pass *analysis.Pass) (interface{}, error) {
 // checkLongShift checks if shift or shift-assign operations shift by more than
 // the length of the underlying variable.
 func checkLongShift(pass *analysis.Pass, node ast.Node, x, y ast.Expr) {
-	if pass.TypesInfo.Types[x].Value != nil {
+	if pass.GopTypesInfo.Types[x].Value != nil {
 		// Ignore shifts of constants.
 		// These are frequently used for bit-twiddling tricks
 		// like ^uint(0) >> 63 for 32/64 bit detection and compatibility.
 		return
 	}
 
-	v := pass.TypesInfo.Types[y].Value
+	v := pass.GopTypesInfo.Types[y].Value
 	if v == nil {
 		return
 	}
@@ -93,7 +94,7 @@ func checkLongShift(pass *analysis.Pass, node ast.Node, x, y ast.Expr) {
 	if !ok {
 		return
 	}
-	t := pass.TypesInfo.Types[x].Type
+	t := pass.GopTypesInfo.Types[x].Type
 	if t == nil {
 		return
 	}
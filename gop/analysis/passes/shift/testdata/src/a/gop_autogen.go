@@ -0,0 +1,259 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package shift
+
+import "unsafe"
+
+const _ = true
+//line a.gop:13:1
+func ShiftTest() {
+//line a.gop:14:1
+	var i8 int8
+//line a.gop:15:1
+	_ = i8 << 7
+//line a.gop:16:1
+	_ = (i8 + 1) << 8
+//line a.gop:17:1
+	_ = i8 << (7 + 1)
+//line a.gop:18:1
+	_ = i8 >> 8
+//line a.gop:19:1
+	i8 <<= 8
+//line a.gop:20:1
+	i8 >>= 8
+//line a.gop:21:1
+	var i16 int16
+//line a.gop:22:1
+	_ = i16 << 15
+//line a.gop:23:1
+	_ = i16 << 16
+//line a.gop:24:1
+	_ = i16 >> 16
+//line a.gop:25:1
+	i16 <<= 16
+//line a.gop:26:1
+	i16 >>= 16
+//line a.gop:27:1
+	var i32 int32
+//line a.gop:28:1
+	_ = i32 << 31
+//line a.gop:29:1
+	_ = i32 << 32
+//line a.gop:30:1
+	_ = i32 >> 32
+//line a.gop:31:1
+	i32 <<= 32
+//line a.gop:32:1
+	i32 >>= 32
+//line a.gop:33:1
+	var i64 int64
+//line a.gop:34:1
+	_ = i64 << 63
+//line a.gop:35:1
+	_ = i64 << 64
+//line a.gop:36:1
+	_ = i64 >> 64
+//line a.gop:37:1
+	i64 <<= 64
+//line a.gop:38:1
+	i64 >>= 64
+//line a.gop:39:1
+	var u8 uint8
+//line a.gop:40:1
+	_ = u8 << 7
+//line a.gop:41:1
+	_ = u8 << 8
+//line a.gop:42:1
+	_ = u8 >> 8
+//line a.gop:43:1
+	u8 <<= 8
+//line a.gop:44:1
+	u8 >>= 8
+//line a.gop:45:1
+	var u16 uint16
+//line a.gop:46:1
+	_ = u16 << 15
+//line a.gop:47:1
+	_ = u16 << 16
+//line a.gop:48:1
+	_ = u16 >> 16
+//line a.gop:49:1
+	u16 <<= 16
+//line a.gop:50:1
+	u16 >>= 16
+//line a.gop:51:1
+	var u32 uint32
+//line a.gop:52:1
+	_ = u32 << 31
+//line a.gop:53:1
+	_ = u32 << 32
+//line a.gop:54:1
+	_ = u32 >> 32
+//line a.gop:55:1
+	u32 <<= 32
+//line a.gop:56:1
+	u32 >>= 32
+//line a.gop:57:1
+	var u64 uint64
+//line a.gop:58:1
+	_ = u64 << 63
+//line a.gop:59:1
+	_ = u64 << 64
+//line a.gop:60:1
+	_ = u64 >> 64
+//line a.gop:61:1
+	u64 <<= 64
+//line a.gop:62:1
+	u64 >>= 64
+//line a.gop:63:1
+	_ = u64 << u64
+//line a.gop:65:1
+	var i int
+//line a.gop:66:1
+	_ = i << 31
+//line a.gop:67:1
+	const in = 8 * unsafe.Sizeof(i)
+//line a.gop:68:1
+	_ = i << in
+//line a.gop:69:1
+	_ = i >> in
+//line a.gop:70:1
+	i <<= in
+//line a.gop:71:1
+	i >>= in
+//line a.gop:72:1
+	const ix = 8*unsafe.Sizeof(i) - 1
+//line a.gop:73:1
+	_ = i << ix
+//line a.gop:74:1
+	_ = i >> ix
+//line a.gop:75:1
+	i <<= ix
+//line a.gop:76:1
+	i >>= ix
+//line a.gop:78:1
+	var u uint
+//line a.gop:79:1
+	_ = u << 31
+//line a.gop:80:1
+	const un = 8 * unsafe.Sizeof(u)
+//line a.gop:81:1
+	_ = u << un
+//line a.gop:82:1
+	_ = u >> un
+//line a.gop:83:1
+	u <<= un
+//line a.gop:84:1
+	u >>= un
+//line a.gop:85:1
+	const ux = 8*unsafe.Sizeof(u) - 1
+//line a.gop:86:1
+	_ = u << ux
+//line a.gop:87:1
+	_ = u >> ux
+//line a.gop:88:1
+	u <<= ux
+//line a.gop:89:1
+	u >>= ux
+//line a.gop:91:1
+	var p uintptr
+//line a.gop:92:1
+	_ = p << 31
+//line a.gop:93:1
+	const pn = 8 * unsafe.Sizeof(p)
+//line a.gop:94:1
+	_ = p << pn
+//line a.gop:95:1
+	_ = p >> pn
+//line a.gop:96:1
+	p <<= pn
+//line a.gop:97:1
+	p >>= pn
+//line a.gop:98:1
+	const px = 8*unsafe.Sizeof(p) - 1
+//line a.gop:99:1
+	_ = p << px
+//line a.gop:100:1
+	_ = p >> px
+//line a.gop:101:1
+	p <<= px
+//line a.gop:102:1
+	p >>= px
+//line a.gop:104:1
+	const oneIf64Bit = ^uint(0) >> 63
+//line a.gop:106:1
+	var h uintptr
+//line a.gop:107:1
+	h = h<<8 | h>>(8*(unsafe.Sizeof(h)-1))
+//line a.gop:108:1
+	h <<= 8 * unsafe.Sizeof(h)
+//line a.gop:109:1
+	h >>= 7 * unsafe.Alignof(h)
+//line a.gop:110:1
+	h >>= 8 * unsafe.Alignof(h)
+}
+//line a.gop:113:1
+func ShiftDeadCode() {
+//line a.gop:114:1
+	var i int
+//line a.gop:115:1
+	const iBits = 8 * unsafe.Sizeof(i)
+//line a.gop:117:1
+	if false {
+//line a.gop:118:1
+		if iBits == 16 {
+//line a.gop:119:1
+			_ = i >> 8
+		} else {
+//line a.gop:121:1
+			_ = i >> 16
+		}
+	} else {
+//line a.gop:124:1
+		_ = i >> 32
+	}
+//line a.gop:127:1
+	if true {
+//line a.gop:128:1
+		_ = i << 32
+//line a.gop:129:1
+		if iBits == 128 {
+//line a.gop:130:1
+			_ = i << 64
+		}
+	} else {
+//line a.gop:133:1
+		_ = i << 16
+	}
+//line a.gop:136:1
+	if iBits == 64 {
+//line a.gop:137:1
+		_ = i << 32
+	}
+//line a.gop:140:1
+	switch iBits {
+//line a.gop:141:1
+	case 128, 64:
+//line a.gop:142:1
+		_ = i << 32
+//line a.gop:143:1
+	default:
+//line a.gop:144:1
+		_ = i << 16
+	}
+//line a.gop:147:1
+	switch {
+//line a.gop:148:1
+	case false:
+//line a.gop:149:1
+		_ = i << 16
+//line a.gop:150:1
+	case false:
+//line a.gop:151:1
+		_ = i << 64
+//line a.gop:152:1
+	default:
+//line a.gop:153:1
+		_ = i << 64
+	}
+}
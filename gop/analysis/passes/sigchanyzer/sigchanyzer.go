@@ -9,11 +9,12 @@ package sigchanyzer
 import (
 	"bytes"
 	_ "embed"
-	"go/ast"
-	"go/format"
-	"go/token"
-	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/sigchanyzer"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -25,15 +26,15 @@ var doc string
 
 // Analyzer describes sigchanyzer analysis function detector.
 var Analyzer = &analysis.Analyzer{
-	Name:     "sigchanyzer",
+	Name:     "gopSigchanyzer",
 	Doc:      analysisutil.MustExtractDoc(doc, "sigchanyzer"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/sigchanyzer",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{sigchanyzer.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	if !analysisutil.Imports(pass.Pkg, "os/signal") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "os/signal") {
 		return nil, nil // doesn't directly import signal
 	}
 
@@ -44,7 +45,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		if !isSignalNotify(pass.TypesInfo, call) {
+		if !isSignalNotify(pass.GopTypesInfo, call) {
 			return
 		}
 		var chanDecl *ast.CallExpr
@@ -56,7 +57,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		case *ast.CallExpr:
 			// Only signal.Notify(make(chan os.Signal), os.Interrupt) is safe,
 			// conservatively treat others as not safe, see golang/go#45043
-			if isBuiltinMake(pass.TypesInfo, arg) {
+			if isBuiltinMake(pass.GopTypesInfo, arg) {
 				return
 			}
 			chanDecl = arg
@@ -96,7 +97,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	return nil, nil
 }
 
-func isSignalNotify(info *types.Info, call *ast.CallExpr) bool {
+func isSignalNotify(info *typesutil.Info, call *ast.CallExpr) bool {
 	check := func(id *ast.Ident) bool {
 		obj := info.ObjectOf(id)
 		return obj.Name() == "Notify" && obj.Pkg().Path() == "os/signal"
@@ -145,7 +146,7 @@ func findDecl(arg *ast.Ident) ast.Node {
 	return nil
 }
 
-func isBuiltinMake(info *types.Info, call *ast.CallExpr) bool {
+func isBuiltinMake(info *typesutil.Info, call *ast.CallExpr) bool {
 	typVal := info.Types[call.Fun]
 	if !typVal.IsBuiltin() {
 		return false
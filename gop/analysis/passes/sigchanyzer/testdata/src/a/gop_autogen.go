@@ -0,0 +1,74 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package p
+
+import (
+	"os"
+	"os/signal"
+)
+
+const _ = true
+
+var c = make(chan os.Signal)
+var d = make(chan os.Signal)
+//line a.gop:12:1
+func f() {
+//line a.gop:13:1
+	c := make(chan os.Signal, 1)
+//line a.gop:14:1
+	signal.Notify(c, os.Interrupt)
+//line a.gop:15:1
+	_ = <-c
+}
+//line a.gop:18:1
+func g() {
+//line a.gop:19:1
+	c := make(chan os.Signal)
+//line a.gop:20:1
+	signal.Notify(c, os.Interrupt)
+//line a.gop:21:1
+	_ = <-c
+}
+//line a.gop:24:1
+func h() {
+//line a.gop:25:1
+	c := make(chan os.Signal)
+//line a.gop:26:1
+	signal.Notify(c, os.Interrupt)
+//line a.gop:27:1
+	_ = <-c
+}
+//line a.gop:30:1
+func i() {
+//line a.gop:31:1
+	signal.Notify(d, os.Interrupt)
+}
+//line a.gop:34:1
+func j() {
+//line a.gop:35:1
+	c := make(chan os.Signal)
+//line a.gop:36:1
+	f := signal.Notify
+//line a.gop:37:1
+	f(c, os.Interrupt)
+}
+//line a.gop:40:1
+func k() {
+//line a.gop:41:1
+	signal.Notify(make(chan os.Signal), os.Interrupt)
+}
+//line a.gop:44:1
+func l() {
+//line a.gop:45:1
+	signal.Notify(make(chan os.Signal, 1), os.Interrupt)
+}
+//line a.gop:48:1
+func m() {
+//line a.gop:49:1
+	signal.Notify(make(chan os.Signal, 1), os.Interrupt)
+}
+//line a.gop:52:1
+func n() {
+//line a.gop:53:1
+	signal.Notify(make(chan os.Signal), os.Interrupt)
+}
@@ -11,13 +11,16 @@ package slog
 import (
 	_ "embed"
 	"fmt"
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/slog"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/gop/ast/astutil"
 	"golang.org/x/tools/gop/ast/inspector"
 	"golang.org/x/tools/gop/types/typeutil"
 )
@@ -26,10 +29,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "slog",
+	Name:     "gopSlog",
 	Doc:      analysisutil.MustExtractDoc(doc, "slog"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/slog",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{slog.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -54,7 +57,7 @@ func run(pass *analysis.Pass) (any, error) {
 	}
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
 		call := node.(*ast.CallExpr)
-		fn := typeutil.StaticCallee(pass.TypesInfo, call)
+		fn := typeutil.StaticCallee(pass.GopTypesInfo, call)
 		if fn == nil {
 			return // not a static call
 		}
@@ -66,7 +69,7 @@ func run(pass *analysis.Pass) (any, error) {
 			// Not a slog function that takes key-value pairs.
 			return
 		}
-		if isMethodExpr(pass.TypesInfo, call) {
+		if isMethodExpr(pass.GopTypesInfo, call) {
 			// Call is to a method value. Skip the first argument.
 			skipArgs++
 		}
@@ -80,7 +83,8 @@ func run(pass *analysis.Pass) (any, error) {
 		pos := key
 		var unknownArg ast.Expr // nil or the last unknown argument
 		for _, arg := range call.Args[skipArgs:] {
-			t := pass.TypesInfo.Types[arg].Type
+			// Go+ records the untyped type of a constant argument.
+			t := types.Default(pass.GopTypesInfo.Types[arg].Type)
 			switch pos {
 			case key:
 				// Expect a string or Attr.
@@ -224,13 +228,40 @@ var kvFuncs = map[string]map[string]int{
 }
 
 // isMethodExpr reports whether a call is to a MethodExpr.
-func isMethodExpr(info *types.Info, c *ast.CallExpr) bool {
+func isMethodExpr(info *typesutil.Info, c *ast.CallExpr) bool {
 	s, ok := c.Fun.(*ast.SelectorExpr)
 	if !ok {
 		return false
 	}
 	sel := info.Selections[s]
-	return sel != nil && sel.Kind() == types.MethodExpr
+	if sel == nil {
+		// Go+ doesn't record Selections: a method expression selects a
+		// method from a type name.
+		fn, ok := info.Uses[s.Sel].(*types.Func)
+		return ok && fn.Type().(*types.Signature).Recv() != nil && isTypeName(info, s.X)
+	}
+	return sel.Kind() == types.MethodExpr
+}
+
+// isTypeName reports whether e denotes a named type T or a pointer *T to it.
+// Go+ doesn't record type expressions as types, so the object of the name is
+// used instead.
+func isTypeName(info *typesutil.Info, e ast.Expr) bool {
+	e = astutil.Unparen(e)
+	if star, ok := e.(*ast.StarExpr); ok {
+		e = astutil.Unparen(star.X)
+	}
+	var id *ast.Ident
+	switch e := e.(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	_, ok := info.Uses[id].(*types.TypeName)
+	return ok
 }
 
 // isNamed reports whether t is exactly a named type in a package with a given path.
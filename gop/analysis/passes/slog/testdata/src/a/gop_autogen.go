@@ -0,0 +1,174 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+const _ = true
+//line a.gop:18:1
+func F() {
+//line a.gop:19:1
+	var l *slog.Logger
+//line a.gop:19:1
+	var r slog.Record
+//line a.gop:25:1
+	fmt.Println("ok")
+//line a.gop:28:1
+	slog.Info("msg")
+//line a.gop:29:1
+	slog.Info("msg", "a", 1)
+//line a.gop:30:1
+	slog.Info("", "a", 1, "b", "two")
+//line a.gop:31:1
+	l.Debug("msg", "a", 1)
+//line a.gop:32:1
+	l.With("a", 1)
+//line a.gop:33:1
+	slog.Warn("msg", slog.Int("a", 1))
+//line a.gop:34:1
+	slog.Warn("msg", slog.Int("a", 1), "k", 2)
+//line a.gop:35:1
+	l.WarnContext(nil, "msg", "a", 1, slog.Int("b", 2), slog.Int("c", 3), "d", 4)
+//line a.gop:36:1
+	l.DebugContext(nil, "msg", "a", 1, slog.Int("b", 2), slog.Int("c", 3), "d", 4, slog.Int("e", 5))
+//line a.gop:37:1
+	r.Add("a", 1, "b", 2)
+//line a.gop:38:1
+	(*slog.Logger).Debug(l, "msg", "a", 1, "b", 2)
+//line a.gop:40:1
+	var key string
+//line a.gop:41:1
+	r.Add(key, 1)
+//line a.gop:44:1
+	slog.Info("msg", 1)
+//line a.gop:45:1
+	l.Info("msg", 2)
+//line a.gop:46:1
+	slog.Debug("msg", "a")
+//line a.gop:47:1
+	slog.Warn("msg", slog.Int("a", 1), "k")
+//line a.gop:48:1
+	slog.ErrorContext(nil, "msg", "a", 1, "b")
+//line a.gop:49:1
+	r.Add("K", "v", "k")
+//line a.gop:50:1
+	l.With("a", "b", 2)
+//line a.gop:53:1
+	slog.Debug("msg", "a", 1, 2, 3, 4)
+//line a.gop:54:1
+	slog.Debug("msg", "a", 1, 2, 3, 4)
+//line a.gop:56:1
+	slog.Log(nil, slog.LevelWarn, "msg", "a", "b", 2)
+//line a.gop:59:1
+	(*slog.Logger).Debug(l, "msg", "a", 1, 2, 3)
+//line a.gop:61:1
+	// Skip calls with spread args.
+	var args []interface{}
+//line a.gop:63:1
+	slog.Info("msg", args...) 
+//line a.gop:65:1
+	type MyString string
+//line a.gop:67:1
+	myKey := MyString("a")
+//line a.gop:68:1
+	slog.Info("", myKey, 1)
+//line a.gop:70:1
+	// The variadic part of all the calls below begins with an argument of
+	// static type any, followed by an integer.
+	// Even though the we don't know the dynamic type of the first arg, and thus
+	// whether it is a key, an Attr, or something else, the fact that the
+	// following integer arg cannot be a key allows us to assume that we should
+	// expect a key to follow.
+	var a interface{} = "key"
+//line a.gop:79:1
+	slog.Info("msg", a, 7, "key2", 5)
+//line a.gop:83:1
+	slog.Info("msg", a, 7, "key2")
+//line a.gop:88:1
+	a = slog.Int("a", 1)
+//line a.gop:89:1
+	slog.Info("msg", a, 7, "key2")
+//line a.gop:93:1
+	slog.Info("msg", a, 7, "key2", 5)
+//line a.gop:96:1
+	a = 1
+//line a.gop:97:1
+	slog.Info("msg", a, 7, "b", 5)
+//line a.gop:102:1
+	slog.Debug("msg", nil, 2)
+//line a.gop:103:1
+	slog.Debug("msg", interface{}(nil), 2)
+//line a.gop:106:1
+	slog.Debug("msg", interface{}(nil), "a")
+//line a.gop:107:1
+	slog.Debug("msg", interface{}(nil), "a", 2)
+//line a.gop:108:1
+	slog.Debug("msg", interface{}(nil), "a", 2, "b")
+//line a.gop:109:1
+	slog.Debug("msg", interface{}(nil), 2, 3, 4)
+}
+//line a.gop:112:1
+func All() {
+//line a.gop:113:1
+	// Test all functions and methods at least once.
+	var l *slog.Logger
+//line a.gop:113:1
+	// Test all functions and methods at least once.
+	var r slog.Record
+//line a.gop:113:1
+	// Test all functions and methods at least once.
+	var ctx context.Context
+//line a.gop:119:1
+	slog.Debug("msg", 1, 2)
+//line a.gop:120:1
+	slog.Error("msg", 1, 2)
+//line a.gop:121:1
+	slog.Info("msg", 1, 2)
+//line a.gop:122:1
+	slog.Warn("msg", 1, 2)
+//line a.gop:124:1
+	slog.DebugContext(ctx, "msg", 1, 2)
+//line a.gop:125:1
+	slog.ErrorContext(ctx, "msg", 1, 2)
+//line a.gop:126:1
+	slog.InfoContext(ctx, "msg", 1, 2)
+//line a.gop:127:1
+	slog.WarnContext(ctx, "msg", 1, 2)
+//line a.gop:129:1
+	slog.Log(ctx, slog.LevelDebug, "msg", 1, 2)
+//line a.gop:131:1
+	l.Debug("msg", 1, 2)
+//line a.gop:132:1
+	l.Error("msg", 1, 2)
+//line a.gop:133:1
+	l.Info("msg", 1, 2)
+//line a.gop:134:1
+	l.Warn("msg", 1, 2)
+//line a.gop:136:1
+	l.DebugContext(ctx, "msg", 1, 2)
+//line a.gop:137:1
+	l.ErrorContext(ctx, "msg", 1, 2)
+//line a.gop:138:1
+	l.InfoContext(ctx, "msg", 1, 2)
+//line a.gop:139:1
+	l.WarnContext(ctx, "msg", 1, 2)
+//line a.gop:141:1
+	l.Log(ctx, slog.LevelDebug, "msg", 1, 2)
+//line a.gop:143:1
+	_ = l.With(1, 2)
+//line a.gop:145:1
+	r.Add(1, 2)
+//line a.gop:147:1
+	_ = slog.Group("key", "a", 1, "b", 2)
+//line a.gop:148:1
+	_ = slog.Group("key", "a", 1, 2, 3)
+//line a.gop:150:1
+	slog.Error("foo", "err", errors.New("oops"))
+}
+// Used in tests by package b.
+var MyLogger = slog.Default()
@@ -9,10 +9,11 @@ package sortslice
 import (
 	"bytes"
 	"fmt"
-	"go/ast"
-	"go/format"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"golang.org/x/tools/go/analysis/passes/sortslice"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -26,15 +27,15 @@ sort.Slice requires an argument of a slice type. Check that
 the interface{} value passed to sort.Slice is actually a slice.`
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "sortslice",
+	Name:     "gopSortslice",
 	Doc:      Doc,
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/sortslice",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{sortslice.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	if !analysisutil.Imports(pass.Pkg, "sort") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "sort") {
 		return nil, nil // doesn't directly import sort
 	}
 
@@ -46,7 +47,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		fn, _ := typeutil.Callee(pass.GopTypesInfo, call).(*types.Func)
 		if fn == nil {
 			return
 		}
@@ -57,7 +58,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 
 		arg := call.Args[0]
-		typ := pass.TypesInfo.Types[arg].Type
+		typ := pass.GopTypesInfo.Types[arg].Type
 
 		if tuple, ok := typ.(*types.Tuple); ok {
 			typ = tuple.At(0).Type() // special case for Slice(f(...))
@@ -70,7 +71,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 		// Restore typ to the original type, we may unwrap the tuple above,
 		// typ might not be the type of arg.
-		typ = pass.TypesInfo.Types[arg].Type
+		typ = pass.GopTypesInfo.Types[arg].Type
 
 		var fixes []analysis.SuggestedFix
 		switch v := typ.Underlying().(type) {
@@ -0,0 +1,132 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "sort"
+
+const _ = true
+
+type slicecompare interface {
+	compare(i int, j int) bool
+}
+type intslice []int
+type mySlice []int
+//line a.gop:5:1
+// IncorrectSort tries to sort an integer.
+func IncorrectSort() {
+//line a.gop:7:1
+	i := 5
+//line a.gop:8:1
+	sortFn := func(i int, j int) bool {
+//line a.gop:8:1
+		return false
+	}
+//line a.gop:9:1
+	sort.Slice(i, sortFn)
+//line a.gop:10:1
+	sort.SliceStable(i, sortFn)
+//line a.gop:11:1
+	sort.SliceIsSorted(i, sortFn)
+}
+//line a.gop:14:1
+// CorrectSort sorts integers. It should not produce a diagnostic.
+func CorrectSort() {
+//line a.gop:16:1
+	s := []int{2, 3, 5, 6}
+//line a.gop:17:1
+	sortFn := func(i int, j int) bool {
+//line a.gop:17:1
+		return s[i] < s[j]
+	}
+//line a.gop:18:1
+	sort.Slice(s, sortFn)
+//line a.gop:19:1
+	sort.SliceStable(s, sortFn)
+//line a.gop:20:1
+	sort.SliceIsSorted(s, sortFn)
+}
+//line a.gop:23:1
+// CorrectInterface sorts an interface with a slice
+// as the concrete type. It should not produce a diagnostic.
+func CorrectInterface() {
+//line a.gop:26:1
+	var s interface{}
+//line a.gop:27:1
+	s = interface{}([]int{2, 1, 0})
+//line a.gop:28:1
+	sortFn := func(i int, j int) bool {
+//line a.gop:28:1
+		return s.([]int)[i] < s.([]int)[j]
+	}
+//line a.gop:29:1
+	sort.Slice(s, sortFn)
+//line a.gop:30:1
+	sort.SliceStable(s, sortFn)
+//line a.gop:31:1
+	sort.SliceIsSorted(s, sortFn)
+}
+//line a.gop:40:1
+func (s intslice) compare(i int, j int) bool {
+//line a.gop:41:1
+	return s[i] < s[j]
+}
+//line a.gop:44:1
+// UnderlyingInterface sorts an interface with a slice
+// as the concrete type. It should not produce a diagnostic.
+func UnderlyingInterface() {
+//line a.gop:47:1
+	var s slicecompare
+//line a.gop:48:1
+	s = intslice([]int{2, 1, 0})
+//line a.gop:49:1
+	sort.Slice(s, s.compare)
+//line a.gop:50:1
+	sort.SliceStable(s, s.compare)
+//line a.gop:51:1
+	sort.SliceIsSorted(s, s.compare)
+}
+//line a.gop:56:1
+// UnderlyingSlice sorts a type with an underlying type of
+// slice of ints. It should not produce a diagnostic.
+func UnderlyingSlice() {
+//line a.gop:59:1
+	s := mySlice{2, 3, 5, 6}
+//line a.gop:60:1
+	sortFn := func(i int, j int) bool {
+//line a.gop:60:1
+		return s[i] < s[j]
+	}
+//line a.gop:61:1
+	sort.Slice(s, sortFn)
+//line a.gop:62:1
+	sort.SliceStable(s, sortFn)
+//line a.gop:63:1
+	sort.SliceIsSorted(s, sortFn)
+}
+//line a.gop:66:1
+// FunctionResultsAsArguments passes a function which returns two values
+// that satisfy sort.Slice signature. It should not produce a diagnostic.
+func FunctionResultsAsArguments() {
+//line a.gop:69:1
+	s := []string{"a", "z", "ooo"}
+//line a.gop:75:1
+	sort.Slice(less(s))
+//line a.gop:81:1
+	sort.Slice(lessPtr(s))
+}
+//line a.gop:74:1
+func less(s []string) ([]string, func(i int, j int) bool) {
+//line a.gop:75:1
+	return s, func(i int, j int) bool {
+//line a.gop:76:1
+		return s[i] < s[j]
+	}
+}
+//line a.gop:80:1
+func lessPtr(s []string) (*[]string, func(i int, j int) bool) {
+//line a.gop:81:1
+	return &s, func(i int, j int) bool {
+//line a.gop:82:1
+		return s[i] < s[j]
+	}
+}
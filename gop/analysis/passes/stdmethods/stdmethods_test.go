@@ -9,16 +9,11 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/stdmethods"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	pkgs := []string{"a"}
-	if typeparams.Enabled {
-		pkgs = append(pkgs, "typeparams")
-	}
-	analysistest.Run(t, testdata, stdmethods.Analyzer, pkgs...)
+	analysistest.Run(t, testdata, stdmethods.Analyzer, "a", "typeparams")
 }
 
 func TestAnalyzeEncodingXML(t *testing.T) {
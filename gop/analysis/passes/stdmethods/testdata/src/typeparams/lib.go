@@ -0,0 +1,17 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type T[P any] int
+
+type U[P any] int
+
+type V[P any] int // V does not implement error.
+
+type E[P any] int
+
+type F[P any] int
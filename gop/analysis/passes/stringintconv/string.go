@@ -7,25 +7,26 @@ package stringintconv
 import (
 	_ "embed"
 	"fmt"
-	"go/ast"
 	"go/types"
 	"strings"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/stringintconv"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/gop/ast/inspector"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 //go:embed doc.go
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "stringintconv",
+	Name:     "gopStringintconv",
 	Doc:      analysisutil.MustExtractDoc(doc, "stringintconv"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/stringintconv",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{stringintconv.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -85,9 +86,9 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		var tname *types.TypeName
 		switch fun := call.Fun.(type) {
 		case *ast.Ident:
-			tname, _ = pass.TypesInfo.Uses[fun].(*types.TypeName)
+			tname, _ = pass.GopTypesInfo.Uses[fun].(*types.TypeName)
 		case *ast.SelectorExpr:
-			tname, _ = pass.TypesInfo.Uses[fun.Sel].(*types.TypeName)
+			tname, _ = pass.GopTypesInfo.Uses[fun.Sel].(*types.TypeName)
 		}
 		if tname == nil {
 			return
@@ -123,7 +124,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 		// Next, find a type V0 in V that has an underlying integral type that is
 		// not byte or rune.
-		V := pass.TypesInfo.TypeOf(arg)
+		V := pass.GopTypesInfo.TypeOf(arg)
 		vtypes, err := structuralTypes(V)
 		if err != nil {
 			return // invalid type
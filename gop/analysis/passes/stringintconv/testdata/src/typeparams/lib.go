@@ -0,0 +1,24 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+// Go+ can't refer to the type parameters of a receiver in a method body
+// either, so there are no conversions to them, and the values of type
+// parameter types are fields of the receiver.
+
+// String is named Str as Go+ resolves string to String in a package that
+// declares both.
+type (
+	Int     int
+	Uintptr = uintptr
+	Str     string
+)
+
+type T[AllString ~string, MaybeString ~string | ~int, NotString ~int | byte, NamedString Str | Int] struct {
+	M MaybeString
+	N NotString
+	S NamedString
+}
@@ -8,14 +8,15 @@ package structtag
 
 import (
 	"errors"
-	"go/ast"
-	"go/token"
 	"go/types"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/structtag"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/ast/inspector"
@@ -26,10 +27,10 @@ const Doc = `check that struct field tags conform to reflect.StructTag.Get
 Also report certain struct tags (json, xml) used with unexported fields.`
 
 var Analyzer = &analysis.Analyzer{
-	Name:             "structtag",
+	Name:             "gopStructtag",
 	Doc:              Doc,
 	URL:              "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/structtag",
-	Requires:         []*analysis.Analyzer{inspect.Analyzer},
+	Requires:         []analysis.IAnalyzer{structtag.Analyzer, inspect.Analyzer},
 	RunDespiteErrors: true,
 	Run:              run,
 }
@@ -41,7 +42,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		(*ast.StructType)(nil),
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
-		styp, ok := pass.TypesInfo.Types[n.(*ast.StructType)].Type.(*types.Struct)
+		styp, ok := pass.GopTypesInfo.Types[n.(*ast.StructType)].Type.(*types.Struct)
 		// Type information may be incomplete.
 		if !ok {
 			return
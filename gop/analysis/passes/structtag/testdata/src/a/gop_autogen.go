@@ -0,0 +1,120 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"encoding/xml"
+	"a/b"
+)
+
+const _ = true
+
+type StructTagTest struct {
+	A   int `hello`
+	B   int `	x:"y"`
+	C   int `x:"y"	x:"y"`
+	D   int "x:`y`"
+	E   int `ctrl:"char"`
+	F   int `:"emptykey"`
+	G   int `x:"noEndQuote`
+	H   int `x:"trunc\x0"`
+	I   int `x:"foo",y:"bar"`
+	J   int `x:"foo"y:"bar"`
+	OK0 int `x:"y" u:"v" w:""`
+	OK1 int `x:"y:z" u:"v" w:""`
+	OK2 int `k0:"values contain spaces" k1:"literal	tabs" k2:"and\tescaped\tabs"`
+	OK3 int `under_scores:"and" CAPS:"ARE_OK"`
+}
+type UnexportedEncodingTagTest struct {
+	x int `json:"xx"`
+	y int `xml:"yy"`
+	z int
+	A int `json:"aa" xml:"bb"`
+	b int `json:"-"`
+	C int `json:"-"`
+}
+type unexp struct {
+}
+type JSONEmbeddedField struct {
+	UnexportedEncodingTagTest `is:"embedded"`
+	unexp                     `is:"embedded,notexported" json:"unexp"`
+}
+type AnonymousJSON struct {
+}
+type AnonymousXML struct {
+}
+type AnonymousJSONField struct {
+	DuplicateAnonJSON int `json:"a"`
+	A                 int `hello`
+}
+type AnonymousJSONField2 struct {
+	DuplicateAnonJSON int `json:"a"`
+}
+type AnonymousJSONField3 struct {
+	DuplicateAnonJSON int `json:"a"`
+}
+type DuplicateJSONFields struct {
+	JSON              int `json:"a"`
+	DuplicateJSON     int `json:"a"`
+	IgnoredJSON       int `json:"-"`
+	OtherIgnoredJSON  int `json:"-"`
+	OmitJSON          int `json:",omitempty"`
+	OtherOmitJSON     int `json:",omitempty"`
+	DuplicateOmitJSON int `json:"a,omitempty"`
+	NonJSON           int `foo:"a"`
+	DuplicateNonJSON  int `foo:"a"`
+	Embedded          struct {
+		DuplicateJSON int `json:"a"`
+	}
+	AnonymousJSON    `json:"a"`
+	XML              int `xml:"a"`
+	DuplicateXML     int `xml:"a"`
+	IgnoredXML       int `xml:"-"`
+	OtherIgnoredXML  int `xml:"-"`
+	OmitXML          int `xml:",omitempty"`
+	OtherOmitXML     int `xml:",omitempty"`
+	DuplicateOmitXML int `xml:"a,omitempty"`
+	NonXML           int `foo:"a"`
+	DuplicateNonXML  int `foo:"a"`
+	Embedded2        struct {
+		DuplicateXML int `xml:"a"`
+	}
+	AnonymousXML `xml:"a"`
+	Attribute    struct {
+		XMLName      xml.Name `xml:"b"`
+		NoDup        int      `xml:"b"`
+		Attr         int      `xml:"b,attr"`
+		DupAttr      int      `xml:"b,attr"`
+		DupOmitAttr  int      `xml:"b,omitempty,attr"`
+		AnonymousXML `xml:"b,attr"`
+	}
+	AnonymousJSONField2 `json:"not_anon"`
+	AnonymousJSONField3 `json:"-"`
+}
+type UnexpectedSpacetest struct {
+	A int `json:"a,omitempty"`
+	B int `json:"b, omitempty"`
+	C int `json:"c ,omitempty"`
+	D int `json:"d,omitempty, string"`
+	E int `xml:"e local"`
+	F int `xml:"f "`
+	G int `xml:" g"`
+	H int `xml:"h ,omitempty"`
+	I int `xml:"i, omitempty"`
+	J int `xml:"j local ,omitempty"`
+	K int `xml:"k local, omitempty"`
+	L int `xml:" l local,omitempty"`
+	M int `xml:"m  local,omitempty"`
+	N int `xml:" "`
+	O int `xml:""`
+	P int `xml:","`
+	Q int `foo:" doesn't care "`
+}
+type ShadowingJsonFieldName struct {
+	AnonymousJSONField
+	ShadowingAnonJSON int `json:"a"`
+}
+type DuplicateWithAnotherPackage struct {
+	b.AnonymousJSONField
+	AnonymousJSONField2
+}
@@ -0,0 +1,470 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"log"
+	"sync"
+	"testing"
+)
+
+const _ = true
+
+type customType int
+//line a.gop:13:1
+func TestBadFatalf(t *testing.T) {
+//line a.gop:14:1
+	var wg sync.WaitGroup
+//line a.gop:15:1
+	defer wg.Wait()
+//line a.gop:17:1
+	for
+//line a.gop:17:1
+	i := 0; i < 2;
+//line a.gop:17:1
+	i++ {
+//line a.gop:18:1
+		wg.Add(1)
+//line a.gop:19:1
+		go func(id int) {
+//line a.gop:20:1
+			defer wg.Done()
+//line a.gop:21:1
+			t.Fatalf("TestFailed: id = %v\n", id)
+		}(i)
+	}
+}
+//line a.gop:26:1
+func TestOKErrorf(t *testing.T) {
+//line a.gop:27:1
+	var wg sync.WaitGroup
+//line a.gop:28:1
+	defer wg.Wait()
+//line a.gop:30:1
+	for
+//line a.gop:30:1
+	i := 0; i < 2;
+//line a.gop:30:1
+	i++ {
+//line a.gop:31:1
+		wg.Add(1)
+//line a.gop:32:1
+		go func(id int) {
+//line a.gop:33:1
+			defer wg.Done()
+//line a.gop:34:1
+			t.Errorf("TestFailed: id = %v\n", id)
+		}(i)
+	}
+}
+//line a.gop:39:1
+func TestBadFatal(t *testing.T) {
+//line a.gop:40:1
+	var wg sync.WaitGroup
+//line a.gop:41:1
+	defer wg.Wait()
+//line a.gop:43:1
+	for
+//line a.gop:43:1
+	i := 0; i < 2;
+//line a.gop:43:1
+	i++ {
+//line a.gop:44:1
+		wg.Add(1)
+//line a.gop:45:1
+		go func(id int) {
+//line a.gop:46:1
+			defer wg.Done()
+//line a.gop:47:1
+			t.Fatal("TestFailed")
+		}(i)
+	}
+}
+//line a.gop:52:1
+func f(t *testing.T, _ string) {
+//line a.gop:53:1
+	t.Fatal("TestFailed")
+}
+//line a.gop:56:1
+func g() {
+}
+//line a.gop:58:1
+func TestBadFatalIssue47470(t *testing.T) {
+//line a.gop:59:1
+	go f(t, "failed test 1")
+//line a.gop:61:1
+	g := func(t *testing.T, _ string) {
+//line a.gop:62:1
+		t.Fatal("TestFailed")
+	}
+//line a.gop:64:1
+	go g(t, "failed test 2")
+}
+//line a.gop:67:1
+func BenchmarkBadFatalf(b *testing.B) {
+//line a.gop:68:1
+	var wg sync.WaitGroup
+//line a.gop:69:1
+	defer wg.Wait()
+//line a.gop:71:1
+	for
+//line a.gop:71:1
+	i := 0; i < b.N;
+//line a.gop:71:1
+	i++ {
+//line a.gop:72:1
+		wg.Add(1)
+//line a.gop:73:1
+		go func(id int) {
+//line a.gop:74:1
+			defer wg.Done()
+//line a.gop:75:1
+			b.Fatalf("TestFailed: id = %v\n", id)
+		}(i)
+	}
+}
+//line a.gop:80:1
+func BenchmarkBadFatal(b *testing.B) {
+//line a.gop:81:1
+	var wg sync.WaitGroup
+//line a.gop:82:1
+	defer wg.Wait()
+//line a.gop:84:1
+	for
+//line a.gop:84:1
+	i := 0; i < b.N;
+//line a.gop:84:1
+	i++ {
+//line a.gop:85:1
+		wg.Add(1)
+//line a.gop:86:1
+		go func(id int) {
+//line a.gop:87:1
+			defer wg.Done()
+//line a.gop:88:1
+			b.Fatal("TestFailed")
+		}(i)
+	}
+}
+//line a.gop:93:1
+func BenchmarkOKErrorf(b *testing.B) {
+//line a.gop:94:1
+	var wg sync.WaitGroup
+//line a.gop:95:1
+	defer wg.Wait()
+//line a.gop:97:1
+	for
+//line a.gop:97:1
+	i := 0; i < b.N;
+//line a.gop:97:1
+	i++ {
+//line a.gop:98:1
+		wg.Add(1)
+//line a.gop:99:1
+		go func(id int) {
+//line a.gop:100:1
+			defer wg.Done()
+//line a.gop:101:1
+			b.Errorf("TestFailed: %d", i)
+		}(i)
+	}
+}
+//line a.gop:106:1
+func BenchmarkBadFatalGoGo(b *testing.B) {
+//line a.gop:107:1
+	var wg sync.WaitGroup
+//line a.gop:108:1
+	defer wg.Wait()
+//line a.gop:110:1
+	for
+//line a.gop:110:1
+	i := 0; i < b.N;
+//line a.gop:110:1
+	i++ {
+//line a.gop:111:1
+		wg.Add(1)
+//line a.gop:112:1
+		go func(id int) {
+//line a.gop:113:1
+			go func() {
+//line a.gop:114:1
+				defer wg.Done()
+//line a.gop:115:1
+				b.Fatal("TestFailed")
+			}()
+		}(i)
+	}
+//line a.gop:120:1
+	if false {
+//line a.gop:121:1
+		defer b.Fatal("here")
+	}
+//line a.gop:124:1
+	if true {
+//line a.gop:125:1
+		go func() {
+//line a.gop:126:1
+			b.Fatal("in here")
+		}()
+	}
+//line a.gop:130:1
+	func() {
+//line a.gop:131:1
+		func() {
+//line a.gop:132:1
+			func() {
+//line a.gop:133:1
+				func() {
+//line a.gop:134:1
+					go func() {
+//line a.gop:135:1
+						b.Fatal("Here")
+					}()
+				}()
+			}()
+		}()
+	}()
+//line a.gop:142:1
+	_ = 10 * 10
+//line a.gop:143:1
+	_ = func() bool {
+//line a.gop:144:1
+		go b.Fatal("Failed")
+//line a.gop:145:1
+		return true
+	}
+//line a.gop:148:1
+	defer func() {
+//line a.gop:149:1
+		go b.Fatal("Here")
+	}()
+}
+//line a.gop:153:1
+func BenchmarkBadSkip(b *testing.B) {
+//line a.gop:154:1
+	for
+//line a.gop:154:1
+	i := 0; i < b.N;
+//line a.gop:154:1
+	i++ {
+//line a.gop:155:1
+		if i == 100 {
+//line a.gop:156:1
+			go b.Skip("Skipping")
+		}
+//line a.gop:158:1
+		if i == 22 {
+//line a.gop:159:1
+			go func() {
+//line a.gop:160:1
+				go func() {
+//line a.gop:161:1
+					b.Skip("Skipping now")
+				}()
+			}()
+		}
+	}
+}
+//line a.gop:168:1
+func TestBadSkip(t *testing.T) {
+//line a.gop:169:1
+	for
+//line a.gop:169:1
+	i := 0; i < 1000;
+//line a.gop:169:1
+	i++ {
+//line a.gop:170:1
+		if i == 100 {
+//line a.gop:171:1
+			go t.Skip("Skipping")
+		}
+//line a.gop:173:1
+		if i == 22 {
+//line a.gop:174:1
+			go func() {
+//line a.gop:175:1
+				go func() {
+//line a.gop:176:1
+					t.Skip("Skipping now")
+				}()
+			}()
+		}
+	}
+}
+//line a.gop:183:1
+func BenchmarkBadFailNow(b *testing.B) {
+//line a.gop:184:1
+	for
+//line a.gop:184:1
+	i := 0; i < b.N;
+//line a.gop:184:1
+	i++ {
+//line a.gop:185:1
+		if i == 100 {
+//line a.gop:186:1
+			go b.FailNow()
+		}
+//line a.gop:188:1
+		if i == 22 {
+//line a.gop:189:1
+			go func() {
+//line a.gop:190:1
+				go func() {
+//line a.gop:191:1
+					b.FailNow()
+				}()
+			}()
+		}
+	}
+}
+//line a.gop:198:1
+func TestBadFailNow(t *testing.T) {
+//line a.gop:199:1
+	for
+//line a.gop:199:1
+	i := 0; i < 1000;
+//line a.gop:199:1
+	i++ {
+//line a.gop:200:1
+		if i == 100 {
+//line a.gop:201:1
+			go t.FailNow()
+		}
+//line a.gop:203:1
+		if i == 22 {
+//line a.gop:204:1
+			go func() {
+//line a.gop:205:1
+				go func() {
+//line a.gop:206:1
+					t.FailNow()
+				}()
+			}()
+		}
+	}
+}
+//line a.gop:213:1
+func TestBadWithLoopCond(ty *testing.T) {
+//line a.gop:214:1
+	var wg sync.WaitGroup
+//line a.gop:215:1
+	defer wg.Wait()
+//line a.gop:217:1
+	for
+//line a.gop:217:1
+	i := 0; i < 10;
+//line a.gop:217:1
+	i++ {
+//line a.gop:218:1
+		wg.Add(1)
+//line a.gop:219:1
+		go func(id int) {
+//line a.gop:220:1
+			defer ty.Fatalf("Why")
+//line a.gop:221:1
+			go func() {
+//line a.gop:222:1
+				for
+//line a.gop:222:1
+				j := 0; j < 2;
+//line a.gop:222:1
+				ty.FailNow() {
+//line a.gop:223:1
+					j++
+//line a.gop:224:1
+					ty.Errorf("Done here")
+				}
+			}()
+		}(i)
+	}
+}
+//line a.gop:233:1
+func (ct *customType) Fatalf(fmtSpec string, args ...interface{}) {
+//line a.gop:234:1
+	if fmtSpec == "" {
+//line a.gop:235:1
+		panic("empty format specifier")
+	}
+}
+//line a.gop:239:1
+func (ct *customType) FailNow() {
+}
+//line a.gop:240:1
+func (ct *customType) Skip() {
+}
+//line a.gop:242:1
+func TestWithLogFatalf(t *testing.T) {
+//line a.gop:243:1
+	var wg sync.WaitGroup
+//line a.gop:244:1
+	defer wg.Wait()
+//line a.gop:246:1
+	for
+//line a.gop:246:1
+	i := 0; i < 10;
+//line a.gop:246:1
+	i++ {
+//line a.gop:247:1
+		wg.Add(1)
+//line a.gop:248:1
+		go func(id int) {
+//line a.gop:249:1
+			go func() {
+//line a.gop:250:1
+				for
+//line a.gop:250:1
+				j := 0; j < 2;
+//line a.gop:250:1
+				j++ {
+//line a.gop:251:1
+					log.Fatal("Done here")
+				}
+			}()
+		}(i)
+	}
+}
+//line a.gop:258:1
+func TestWithCustomType(t *testing.T) {
+//line a.gop:259:1
+	var wg sync.WaitGroup
+//line a.gop:260:1
+	defer wg.Wait()
+//line a.gop:262:1
+	ct := new(customType)
+//line a.gop:263:1
+	defer ct.FailNow()
+//line a.gop:264:1
+	defer ct.Skip()
+//line a.gop:266:1
+	for
+//line a.gop:266:1
+	i := 0; i < 10;
+//line a.gop:266:1
+	i++ {
+//line a.gop:267:1
+		wg.Add(1)
+//line a.gop:268:1
+		go func(id int) {
+//line a.gop:269:1
+			go func() {
+//line a.gop:270:1
+				for
+//line a.gop:270:1
+				j := 0; j < 2;
+//line a.gop:270:1
+				j++ {
+//line a.gop:271:1
+					ct.Fatalf("Done here: %d", i)
+				}
+			}()
+		}(i)
+	}
+}
+//line a.gop:278:1
+func TestIssue48124(t *testing.T) {
+//line a.gop:279:1
+	go h()
+}
+//line b.gop:7:1
+func h() {
+}
@@ -0,0 +1,15 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+import (
+	"testing"
+)
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+func f[P any](t *testing.T) {
+	t.Fatal("failed")
+}
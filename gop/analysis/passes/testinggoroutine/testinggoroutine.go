@@ -6,23 +6,26 @@ package testinggoroutine
 
 import (
 	_ "embed"
-	"go/ast"
+	goast "go/ast"
+	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/testinggoroutine"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/gop/ast/inspector"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 //go:embed doc.go
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "testinggoroutine",
+	Name:     "gopTestinggoroutine",
 	Doc:      analysisutil.MustExtractDoc(doc, "testinggoroutine"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/testinggoroutine",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{testinggoroutine.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -38,7 +41,7 @@ var forbidden = map[string]bool{
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	if !analysisutil.Imports(pass.Pkg, "testing") {
+	if !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "testing") {
 		return nil, nil
 	}
 
@@ -147,6 +150,10 @@ func goStmtFun(goStmt *ast.GoStmt) ast.Node {
 // use of the forbidden *testing.(B, T) methods.
 func checkGoStmt(pass *analysis.Pass, goStmt *ast.GoStmt) {
 	fn := goStmtFun(goStmt)
+	if decl := goFuncDecl(pass, fn); decl != nil {
+		checkGoFuncDecl(pass, goStmt, decl)
+		return
+	}
 	// Otherwise examine the goroutine to check for the forbidden methods.
 	ast.Inspect(fn, func(n ast.Node) bool {
 		selExpr, ok := n.(*ast.SelectorExpr)
@@ -181,3 +188,74 @@ func checkGoStmt(pass *analysis.Pass, goStmt *ast.GoStmt) {
 		return true
 	})
 }
+
+// goFuncDecl returns the declaration of the generic function instantiated by
+// call if it is declared in a Go file of the package. Go+ can't declare
+// generic functions, so this is the only way a goroutine starts one.
+func goFuncDecl(pass *analysis.Pass, call ast.Node) *goast.FuncDecl {
+	expr, ok := call.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	x, _, _, _ := typeparams.UnpackIndexExpr(expr.Fun)
+	id, _ := x.(*ast.Ident)
+	if id == nil {
+		return nil
+	}
+	fn, ok := pass.GopTypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() != pass.Pkg {
+		return nil
+	}
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*goast.FuncDecl); ok && pass.TypesInfo.Defs[decl.Name] == fn {
+				return decl
+			}
+		}
+	}
+	return nil
+}
+
+// checkGoFuncDecl checks for the use of the forbidden *testing.(B, T) methods
+// in the Go function decl started by goStmt.
+func checkGoFuncDecl(pass *analysis.Pass, goStmt *ast.GoStmt, decl *goast.FuncDecl) {
+	goast.Inspect(decl, func(n goast.Node) bool {
+		selExpr, ok := n.(*goast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if _, bad := forbidden[selExpr.Sel.Name]; !bad {
+			return true
+		}
+		ident, ok := selExpr.X.(*goast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok {
+			return true
+		}
+		if typeName, ok := testingTOrB(v.Type()); ok {
+			pass.ReportRangef(goStmt, "call to (*%s).%s from a non-test goroutine", typeName, selExpr.Sel)
+		}
+		return true
+	})
+}
+
+// testingTOrB reports whether typ is *testing.T or *testing.B.
+func testingTOrB(typ types.Type) (string, bool) {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok {
+		return "", false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return "", false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != "testing" {
+		return "", false
+	}
+	typeName := obj.Name()
+	return typeName, typeName == "B" || typeName == "T"
+}
@@ -0,0 +1,258 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "testing"
+// Buf is a ...
+type Buf []byte
+//line a_test.gop:10:1
+// Append ...
+func (*Buf) Append([]byte) {
+}
+//line a_test.gop:13:1
+func (Buf) Reset() {
+}
+//line a_test.gop:15:1
+func (Buf) Len() int {
+//line a_test.gop:15:1
+	return 0
+}
+//line a_test.gop:98:1
+func (Buf) TesthasReceiver() {
+}
+// DefaultBuf is a ...
+var DefaultBuf Buf
+//line a_test.gop:20:1
+func Example() {
+}
+//line a_test.gop:22:1
+func Example_goodSuffix() {
+}
+//line a_test.gop:24:1
+func Example_BadSuffix() {
+}
+//line a_test.gop:26:1
+func ExampleBuf() {
+}
+//line a_test.gop:28:1
+func ExampleBuf_Append() {
+}
+//line a_test.gop:30:1
+func ExampleBuf_Clear() {
+}
+//line a_test.gop:32:1
+func ExampleBuf_suffix() {
+}
+//line a_test.gop:34:1
+func ExampleBuf_Append_Bad() {
+}
+//line a_test.gop:36:1
+func ExampleBuf_Append_suffix() {
+}
+//line a_test.gop:38:1
+func ExampleDefaultBuf() {
+}
+//line a_test.gop:40:1
+func ExampleBuf_Reset() bool {
+//line a_test.gop:40:1
+	return true
+}
+//line a_test.gop:42:1
+func ExampleBuf_Len(i int) {
+}
+//line a_test.gop:46:1
+func ExamplePuffer() {
+}
+//line a_test.gop:48:1
+func ExamplePuffer_Append() {
+}
+//line a_test.gop:50:1
+func ExamplePuffer_suffix() {
+}
+//line a_test.gop:52:1
+func ExampleFoo() {
+}
+//line a_test.gop:54:1
+func ExampleBar() {
+}
+//line a_test.gop:56:1
+func Example_withOutput() {
+}
+//line a_test.gop:61:1
+func Example_withBadOutput() {
+}
+//line a_test.gop:68:1
+func Example_withBadUnorderedOutput() {
+}
+//line a_test.gop:75:1
+func Example_withCommentAfterFunc() {
+}
+//line a_test.gop:80:1
+func Example_withOutputCommentAfterFunc() {
+}
+//line a_test.gop:85:1
+func Example_withMultipleOutputs() {
+}
+//line a_test.gop:96:1
+func nonTest() {
+}
+//line a_test.gop:100:1
+func TestOKSuffix(*testing.T) {
+}
+//line a_test.gop:102:1
+func TestÜnicodeWorks(*testing.T) {
+}
+//line a_test.gop:104:1
+func TestbadSuffix(*testing.T) {
+}
+//line a_test.gop:106:1
+func TestemptyImportBadSuffix(*testing.T) {
+}
+//line a_test.gop:108:1
+func Test(*testing.T) {
+}
+//line a_test.gop:110:1
+func Testify() {
+}
+//line a_test.gop:112:1
+func TesttooManyParams(*testing.T, string) {
+}
+//line a_test.gop:114:1
+func TesttooManyNames(a *testing.T, b *testing.T) {
+}
+//line a_test.gop:116:1
+func TestnoTParam(string) {
+}
+//line a_test.gop:118:1
+func BenchmarkbadSuffix(*testing.B) {
+}
+//line go118_test.gop:10:1
+func Fuzzfoo(*testing.F) {
+}
+//line go118_test.gop:12:1
+func FuzzBoo(*testing.F) {
+}
+//line go118_test.gop:14:1
+func FuzzCallDifferentFunc(f *testing.F) {
+//line go118_test.gop:15:1
+	f.Name()
+}
+//line go118_test.gop:18:1
+func FuzzFunc(f *testing.F) {
+//line go118_test.gop:19:1
+	f.Fuzz(func(t *testing.T) {
+	})
+}
+//line go118_test.gop:22:1
+func FuzzFuncWithArgs(f *testing.F) {
+//line go118_test.gop:23:1
+	f.Add()
+//line go118_test.gop:24:1
+	f.Add(1, 2, 3, 4)
+//line go118_test.gop:25:1
+	f.Add(5, 5)
+//line go118_test.gop:26:1
+	f.Add([]byte("hello"), 5)
+//line go118_test.gop:27:1
+	f.Add(5, []byte("hello"))
+//line go118_test.gop:28:1
+	f.Fuzz(func(t *testing.T, i int, b []byte) {
+//line go118_test.gop:29:1
+		f.Add(5, []byte("hello"))
+//line go118_test.gop:30:1
+		f.Name()
+//line go118_test.gop:31:1
+		f.Failed()
+//line go118_test.gop:32:1
+		f.Fuzz(func(t *testing.T) {
+		})
+	})
+}
+//line go118_test.gop:36:1
+func FuzzArgFunc(f *testing.F) {
+//line go118_test.gop:37:1
+	f.Fuzz(0)
+}
+//line go118_test.gop:40:1
+func FuzzFuncWithReturn(f *testing.F) {
+//line go118_test.gop:41:1
+	f.Fuzz(func(t *testing.T) bool {
+//line go118_test.gop:41:1
+		return true
+	})
+}
+//line go118_test.gop:44:1
+func FuzzFuncNoArg(f *testing.F) {
+//line go118_test.gop:45:1
+	f.Fuzz(func() {
+	})
+}
+//line go118_test.gop:48:1
+func FuzzFuncFirstArgNotTesting(f *testing.F) {
+//line go118_test.gop:49:1
+	f.Fuzz(func(i int64) {
+	})
+}
+//line go118_test.gop:52:1
+func FuzzFuncFirstArgTestingNotT(f *testing.F) {
+//line go118_test.gop:53:1
+	f.Fuzz(func(t *testing.F) {
+	})
+}
+//line go118_test.gop:56:1
+func FuzzFuncSecondArgNotAllowed(f *testing.F) {
+//line go118_test.gop:57:1
+	f.Fuzz(func(t *testing.T, i complex64) {
+	})
+}
+//line go118_test.gop:60:1
+func FuzzFuncSecondArgArrNotAllowed(f *testing.F) {
+//line go118_test.gop:61:1
+	f.Fuzz(func(t *testing.T, i []int) {
+	})
+}
+//line go118_test.gop:64:1
+func FuzzFuncConsecutiveArgNotAllowed(f *testing.F) {
+//line go118_test.gop:65:1
+	f.Fuzz(func(t *testing.T, i string, j string, k complex64) {
+	})
+}
+//line go118_test.gop:68:1
+func FuzzFuncInner(f *testing.F) {
+//line go118_test.gop:69:1
+	innerFunc := func(t *testing.T, i float32) {
+	}
+//line go118_test.gop:70:1
+	f.Fuzz(innerFunc)
+}
+//line go118_test.gop:73:1
+func FuzzArrayOfFunc(f *testing.F) {
+//line go118_test.gop:74:1
+	var funcs = []func(t *testing.T, i int){func(t *testing.T, i int) {
+	}}
+//line go118_test.gop:75:1
+	f.Fuzz(funcs[0])
+}
+//line go118_test.gop:78:1
+func FuzzGenericFunc(f *testing.F) {
+//line go118_test.gop:79:1
+	g := GenericSlice[func(t *testing.T, i int)]{func(t *testing.T, i int) {
+	}}
+//line go118_test.gop:80:1
+	f.Fuzz(g[0])
+}
+//line go118_test.gop:83:1
+func FuzzObjectMethod(f *testing.F) {
+//line go118_test.gop:84:1
+	obj := myType{myVar: func(t *testing.T, i int32) {
+	}}
+//line go118_test.gop:87:1
+	f.Fuzz(obj.myVar)
+}
+//line go118_test.gop:90:1
+// Test for golang/go#56505: checking fuzz arguments should not panic on *error.
+func FuzzIssue56505(f *testing.F) {
+//line go118_test.gop:92:1
+	f.Fuzz(func(e *error) {
+	})
+}
@@ -0,0 +1,20 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import "testing"
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+
+type GenericSlice[T any] []T
+
+// gop puts the imports of the types declared in Go+ test files into
+// gop_autogen.go, where they are unused: these types are declared in Go.
+
+type F func(t *testing.T, i int32)
+
+type myType struct {
+	myVar F
+}
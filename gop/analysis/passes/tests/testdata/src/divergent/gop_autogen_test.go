@@ -0,0 +1,50 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package buf
+//line buf_test.gop:5:1
+func Example() {
+}
+//line buf_test.gop:7:1
+func Example_suffix() {
+}
+//line buf_test.gop:9:1
+func Example_BadSuffix() {
+}
+//line buf_test.gop:11:1
+func ExampleBuf() {
+}
+//line buf_test.gop:13:1
+func ExampleBuf_Append() {
+}
+//line buf_test.gop:15:1
+func ExampleBuf_Clear() {
+}
+//line buf_test.gop:17:1
+func ExampleBuf_suffix() {
+}
+//line buf_test.gop:19:1
+func ExampleBuf_Append_Bad() {
+}
+//line buf_test.gop:21:1
+func ExampleBuf_Append_suffix() {
+}
+//line buf_test.gop:23:1
+func ExampleDefaultBuf() {
+}
+//line buf_test.gop:25:1
+func ExampleBuf_Reset() bool {
+//line buf_test.gop:25:1
+	return true
+}
+//line buf_test.gop:27:1
+func ExampleBuf_Len(i int) {
+}
+//line buf_test.gop:31:1
+func ExamplePuffer() {
+}
+//line buf_test.gop:33:1
+func ExamplePuffer_Append() {
+}
+//line buf_test.gop:35:1
+func ExamplePuffer_suffix() {
+}
@@ -1,9 +1,11 @@
-// Copyright 2021 The Go Authors. All rights reserved.
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
 package typeparams
 
+// Go+ can't declare generic types and functions: they are declared in Go.
+
 func Zero[T any]() T {
 	var zero T
 	return zero
@@ -7,27 +7,29 @@ package tests
 import (
 	_ "embed"
 	"fmt"
-	"go/ast"
-	"go/token"
 	"go/types"
 	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/tests"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 //go:embed doc.go
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name: "tests",
-	Doc:  analysisutil.MustExtractDoc(doc, "tests"),
-	URL:  "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/tests",
-	Run:  run,
+	Name:     "gopTests",
+	Doc:      analysisutil.MustExtractDoc(doc, "tests"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/tests",
+	Run:      run,
+	Requires: []analysis.IAnalyzer{tests.Analyzer},
 }
 
 var acceptedFuzzTypes = []types.Type{
@@ -49,8 +51,8 @@ var acceptedFuzzTypes = []types.Type{
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
-	for _, f := range pass.Files {
-		if !strings.HasSuffix(pass.Fset.File(f.Pos()).Name(), "_test.go") {
+	for _, f := range pass.GopFiles {
+		if !strings.HasSuffix(pass.Fset.File(f.Pos()).Name(), "_test.gop") {
 			continue
 		}
 		for _, decl := range f.Decls {
@@ -109,10 +111,10 @@ func checkFuzzCall(pass *analysis.Pass, fn *ast.FuncDecl) (params *types.Tuple)
 				return true
 			}
 			expr := call.Args[0]
-			if pass.TypesInfo.Types[expr].Type == nil {
+			if pass.GopTypesInfo.Types[expr].Type == nil {
 				return true
 			}
-			t := pass.TypesInfo.Types[expr].Type.Underlying()
+			t := pass.GopTypesInfo.Types[expr].Type.Underlying()
 			tSign, argOk := t.(*types.Signature)
 			// Argument should be a function
 			if !argOk {
@@ -171,10 +173,11 @@ func checkAddCalls(pass *analysis.Pass, fn *ast.FuncDecl, params *types.Tuple) {
 			}
 			var mismatched []int
 			for i, expr := range call.Args {
-				if pass.TypesInfo.Types[expr].Type == nil {
+				if pass.GopTypesInfo.Types[expr].Type == nil {
 					return true
 				}
-				t := pass.TypesInfo.Types[expr].Type
+				// goxls: Go+ records the untyped type of constant arguments.
+				t := types.Default(pass.GopTypesInfo.Types[expr].Type)
 				if !types.Identical(t, params.At(i+1).Type()) {
 					mismatched = append(mismatched, i)
 				}
@@ -184,12 +187,12 @@ func checkAddCalls(pass *analysis.Pass, fn *ast.FuncDecl, params *types.Tuple) {
 			if len(mismatched) == 1 {
 				i := mismatched[0]
 				expr := call.Args[i]
-				t := pass.TypesInfo.Types[expr].Type
+				t := types.Default(pass.GopTypesInfo.Types[expr].Type)
 				pass.ReportRangef(expr, fmt.Sprintf("mismatched type in call to (*testing.F).Add: %v, fuzz target expects %v", t, params.At(i+1).Type()))
 			} else if len(mismatched) > 1 {
 				var gotArgs, wantArgs []types.Type
 				for i := 0; i < len(call.Args); i++ {
-					gotArgs, wantArgs = append(gotArgs, pass.TypesInfo.Types[call.Args[i]].Type), append(wantArgs, params.At(i+1).Type())
+					gotArgs, wantArgs = append(gotArgs, types.Default(pass.GopTypesInfo.Types[call.Args[i]].Type)), append(wantArgs, params.At(i+1).Type())
 				}
 				pass.ReportRangef(call, fmt.Sprintf("mismatched types in call to (*testing.F).Add: %v, fuzz target expects %v", gotArgs, wantArgs))
 			}
@@ -211,7 +214,7 @@ func isFuzzTargetDotAdd(pass *analysis.Pass, call *ast.CallExpr) bool {
 // isFuzzTargetDot reports whether call is (*testing.F).<name>().
 func isFuzzTargetDot(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
 	if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok {
-		if !isTestingType(pass.TypesInfo.Types[selExpr.X].Type, "F") {
+		if !isTestingType(pass.GopTypesInfo.Types[selExpr.X].Type, "F") {
 			return false
 		}
 		if name == "" || selExpr.Sel.Name == name {
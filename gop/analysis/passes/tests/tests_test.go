@@ -9,7 +9,6 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/tests"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
@@ -18,9 +17,7 @@ func Test(t *testing.T) {
 		"a",        // loads "a", "a [a.test]", and "a.test"
 		"b_x_test", // loads "b" and "b_x_test"
 		"divergent",
-	}
-	if typeparams.Enabled {
-		pkgs = append(pkgs, "typeparams")
+		"typeparams",
 	}
 	analysistest.Run(t, testdata, tests.Analyzer, pkgs...)
 }
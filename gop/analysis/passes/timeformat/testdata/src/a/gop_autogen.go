@@ -0,0 +1,57 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"b"
+	"time"
+)
+
+const _ = true
+//line a.gop:15:1
+func hasError() {
+//line a.gop:16:1
+	a, _ := time.Parse("2006-02-01 15:04:05", "2021-01-01 00:00:00")
+//line a.gop:17:1
+	a.Format(`2006-02-01`)
+//line a.gop:18:1
+	a.Format("2006-02-01 15:04:05")
+//line a.gop:20:1
+	const c = "2006-02-01"
+//line a.gop:21:1
+	a.Format(c)
+}
+//line a.gop:24:1
+func notHasError() {
+//line a.gop:25:1
+	a, _ := time.Parse("2006-01-02 15:04:05", "2021-01-01 00:00:00")
+//line a.gop:26:1
+	a.Format("2006-01-02")
+//line a.gop:28:1
+	const c = "2006-01-02"
+//line a.gop:29:1
+	a.Format(c)
+//line a.gop:31:1
+	v := "2006-02-01"
+//line a.gop:32:1
+	a.Format(v)
+//line a.gop:34:1
+	m := map[string]string{"y": "2006-02-01"}
+//line a.gop:37:1
+	a.Format(m["y"])
+//line a.gop:39:1
+	s := []string{"2006-02-01"}
+//line a.gop:40:1
+	a.Format(s[0])
+//line a.gop:49:1
+	a.Format(badFormat())
+//line a.gop:44:1
+	o := b.Parse("2006-02-01 15:04:05", "2021-01-01 00:00:00")
+//line a.gop:45:1
+	o.Format("2006-02-01")
+}
+//line a.gop:48:1
+func badFormat() string {
+//line a.gop:49:1
+	return "2006-02-01"
+}
@@ -8,12 +8,14 @@ package timeformat
 
 import (
 	_ "embed"
-	"go/ast"
 	"go/constant"
-	"go/token"
 	"go/types"
 	"strings"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/timeformat"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -28,17 +30,17 @@ const goodFormat = "2006-01-02"
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "timeformat",
+	Name:     "gopTimeformat",
 	Doc:      analysisutil.MustExtractDoc(doc, "timeformat"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/timeformat",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{timeformat.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	// Note: (time.Time).Format is a method and can be a typeutil.Callee
 	// without directly importing "time". So we cannot just skip this package
-	// when !analysisutil.Imports(pass.Pkg, "time").
+	// when !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "time").
 	// TODO(taking): Consider using a prepass to collect typeutil.Callees.
 
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
@@ -48,7 +50,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		fn, ok := typeutil.Callee(pass.GopTypesInfo, call).(*types.Func)
 		if !ok {
 			return
 		}
@@ -57,7 +59,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 		if len(call.Args) > 0 {
 			arg := call.Args[0]
-			badAt := badFormatAt(pass.TypesInfo, arg)
+			badAt := badFormatAt(pass.GopTypesInfo, arg)
 
 			if badAt > -1 {
 				// Check if it's a literal string, otherwise we can't suggest a fix.
@@ -114,7 +116,7 @@ func isTimeDotParse(f *types.Func) bool {
 }
 
 // badFormatAt return the start of a bad format in e or -1 if no bad format is found.
-func badFormatAt(info *types.Info, e ast.Expr) int {
+func badFormatAt(info *typesutil.Info, e ast.Expr) int {
 	tv, ok := info.Types[e]
 	if !ok { // no type info, assume good
 		return -1
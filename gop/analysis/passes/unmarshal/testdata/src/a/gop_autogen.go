@@ -0,0 +1,97 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package testdata
+
+import (
+	"encoding/asn1"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+)
+
+const _ = true
+//line a.gop:17:1
+func _() {
+//line a.gop:18:1
+	type t struct {
+		a int
+	}
+//line a.gop:21:1
+	var v t
+//line a.gop:22:1
+	var r io.Reader
+//line a.gop:24:1
+	json.Unmarshal([]byte{}, v)
+//line a.gop:25:1
+	json.Unmarshal([]byte{}, &v)
+//line a.gop:26:1
+	json.NewDecoder(r).Decode(v)
+//line a.gop:27:1
+	json.NewDecoder(r).Decode(&v)
+//line a.gop:28:1
+	gob.NewDecoder(r).Decode(v)
+//line a.gop:29:1
+	gob.NewDecoder(r).Decode(&v)
+//line a.gop:30:1
+	xml.Unmarshal([]byte{}, v)
+//line a.gop:31:1
+	xml.Unmarshal([]byte{}, &v)
+//line a.gop:32:1
+	xml.NewDecoder(r).Decode(v)
+//line a.gop:33:1
+	xml.NewDecoder(r).Decode(&v)
+//line a.gop:34:1
+	asn1.Unmarshal([]byte{}, v)
+//line a.gop:35:1
+	asn1.Unmarshal([]byte{}, &v)
+//line a.gop:37:1
+	var p *t
+//line a.gop:38:1
+	json.Unmarshal([]byte{}, p)
+//line a.gop:39:1
+	json.Unmarshal([]byte{}, *p)
+//line a.gop:40:1
+	json.NewDecoder(r).Decode(p)
+//line a.gop:41:1
+	json.NewDecoder(r).Decode(*p)
+//line a.gop:42:1
+	gob.NewDecoder(r).Decode(p)
+//line a.gop:43:1
+	gob.NewDecoder(r).Decode(*p)
+//line a.gop:44:1
+	xml.Unmarshal([]byte{}, p)
+//line a.gop:45:1
+	xml.Unmarshal([]byte{}, *p)
+//line a.gop:46:1
+	xml.NewDecoder(r).Decode(p)
+//line a.gop:47:1
+	xml.NewDecoder(r).Decode(*p)
+//line a.gop:48:1
+	asn1.Unmarshal([]byte{}, p)
+//line a.gop:49:1
+	asn1.Unmarshal([]byte{}, *p)
+//line a.gop:51:1
+	var i interface{}
+//line a.gop:52:1
+	json.Unmarshal([]byte{}, i)
+//line a.gop:53:1
+	json.NewDecoder(r).Decode(i)
+//line a.gop:55:1
+	json.Unmarshal([]byte{}, nil)
+//line a.gop:56:1
+	json.Unmarshal([]byte{}, []t{})
+//line a.gop:57:1
+	json.Unmarshal([]byte{}, map[string]int{})
+//line a.gop:58:1
+	json.NewDecoder(r).Decode(nil)
+//line a.gop:59:1
+	json.NewDecoder(r).Decode([]t{})
+//line a.gop:60:1
+	json.NewDecoder(r).Decode(map[string]int{})
+//line a.gop:62:1
+	json.Unmarshal(func() ([]byte, interface{}) {
+//line a.gop:62:1
+		return []byte{}, v
+	}())
+}
@@ -6,25 +6,26 @@ package unmarshal
 
 import (
 	_ "embed"
-	"go/ast"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/go/analysis/passes/unmarshal"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/gop/ast/inspector"
 	"golang.org/x/tools/gop/types/typeutil"
-	"golang.org/x/tools/internal/typeparams"
+	"golang.org/x/tools/internal/gop/typeparams"
 )
 
 //go:embed doc.go
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "unmarshal",
+	Name:     "gopUnmarshal",
 	Doc:      analysisutil.MustExtractDoc(doc, "unmarshal"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/unmarshal",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{unmarshal.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -39,7 +40,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	// Note: (*"encoding/json".Decoder).Decode, (* "encoding/gob".Decoder).Decode
 	// and (* "encoding/xml".Decoder).Decode are methods and can be a typeutil.Callee
 	// without directly importing their packages. So we cannot just skip this package
-	// when !analysisutil.Imports(pass.Pkg, "encoding/...").
+	// when !analysisutil.GopImports(pass.Pkg, pass.GopFiles, "encoding/...").
 	// TODO(taking): Consider using a prepass to collect typeutil.Callees.
 
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
@@ -49,7 +50,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
-		fn := typeutil.StaticCallee(pass.TypesInfo, call)
+		fn := typeutil.StaticCallee(pass.GopTypesInfo, call)
 		if fn == nil {
 			return // not a static call
 		}
@@ -90,7 +91,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			return // not enough arguments, e.g. called with return values of another function
 		}
 
-		t := pass.TypesInfo.Types[call.Args[argidx]].Type
+		t := pass.GopTypesInfo.Types[call.Args[argidx]].Type
 		switch t.Underlying().(type) {
 		case *types.Pointer, *types.Interface, *typeparams.TypeParam:
 			return
@@ -8,10 +8,11 @@ package unreachable
 
 import (
 	_ "embed"
-	"go/ast"
-	"go/token"
 	"log"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis/passes/unreachable"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -22,10 +23,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:             "unreachable",
+	Name:             "gopUnreachable",
 	Doc:              analysisutil.MustExtractDoc(doc, "unreachable"),
 	URL:              "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/unreachable",
-	Requires:         []*analysis.Analyzer{inspect.Analyzer},
+	Requires:         []analysis.IAnalyzer{unreachable.Analyzer, inspect.Analyzer},
 	RunDespiteErrors: true,
 	Run:              run,
 }
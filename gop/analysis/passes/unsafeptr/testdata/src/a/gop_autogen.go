@@ -0,0 +1,130 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+const _ = true
+
+type V interface {
+	Pointer() uintptr
+	UnsafeAddr() uintptr
+}
+type StringHeader struct {
+	Data uintptr
+}
+type SliceHeader struct {
+	Data uintptr
+}
+//line a.gop:12:1
+func f() {
+//line a.gop:13:1
+	var x unsafe.Pointer
+//line a.gop:14:1
+	var y uintptr
+//line a.gop:15:1
+	x = unsafe.Pointer(y)
+//line a.gop:16:1
+	y = uintptr(x)
+//line a.gop:20:1
+	x = unsafe.Pointer(uintptr(x) + 1)
+//line a.gop:21:1
+	x = unsafe.Pointer(uintptr(x) + 1)
+//line a.gop:22:1
+	x = unsafe.Pointer(1 + uintptr(x))
+//line a.gop:23:1
+	x = unsafe.Pointer(uintptr(x) + uintptr(x))
+//line a.gop:24:1
+	x = unsafe.Pointer(uintptr(x) - 1)
+//line a.gop:25:1
+	x = unsafe.Pointer(1 - uintptr(x))
+//line a.gop:26:1
+	x = unsafe.Pointer(uintptr(x) &^ 3)
+//line a.gop:27:1
+	x = unsafe.Pointer(1 &^ uintptr(x))
+//line a.gop:29:1
+	// certain uses of reflect are okay
+	var v reflect.Value
+//line a.gop:31:1
+	x = unsafe.Pointer(v.Pointer())
+//line a.gop:32:1
+	x = unsafe.Pointer(v.Pointer() + 1)
+//line a.gop:33:1
+	x = unsafe.Pointer(v.UnsafeAddr())
+//line a.gop:34:1
+	x = unsafe.Pointer(v.UnsafeAddr())
+//line a.gop:35:1
+	var s1 *reflect.StringHeader
+//line a.gop:36:1
+	x = unsafe.Pointer(s1.Data)
+//line a.gop:37:1
+	x = unsafe.Pointer(s1.Data + 1)
+//line a.gop:38:1
+	var s2 *reflect.SliceHeader
+//line a.gop:39:1
+	x = unsafe.Pointer(s2.Data)
+//line a.gop:40:1
+	var s3 reflect.StringHeader
+//line a.gop:41:1
+	x = unsafe.Pointer(s3.Data)
+//line a.gop:42:1
+	var s4 reflect.SliceHeader
+//line a.gop:43:1
+	x = unsafe.Pointer(s4.Data)
+//line a.gop:45:1
+	// but only in reflect
+	var vv V
+//line a.gop:47:1
+	x = unsafe.Pointer(vv.Pointer())
+//line a.gop:48:1
+	x = unsafe.Pointer(vv.UnsafeAddr())
+//line a.gop:49:1
+	var ss1 *StringHeader
+//line a.gop:50:1
+	x = unsafe.Pointer(ss1.Data)
+//line a.gop:51:1
+	var ss2 *SliceHeader
+//line a.gop:52:1
+	x = unsafe.Pointer(ss2.Data)
+}
+//line issue40701.gop:12:1
+// Explicitly allocating a variable of type reflect.SliceHeader.
+func _(p *byte, n int) []byte {
+//line issue40701.gop:14:1
+	var sh reflect.SliceHeader
+//line issue40701.gop:15:1
+	sh.Data = uintptr(unsafe.Pointer(p))
+//line issue40701.gop:16:1
+	sh.Len = n
+//line issue40701.gop:17:1
+	sh.Cap = n
+//line issue40701.gop:18:1
+	return *(*[]byte)(unsafe.Pointer(&sh))
+}
+//line issue40701.gop:21:1
+// Implicitly allocating a variable of type reflect.SliceHeader.
+func _(p *byte, n int) []byte {
+//line issue40701.gop:23:1
+	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{Data: uintptr(unsafe.Pointer(p)), Len: n, Cap: n}))
+}
+//line issue40701.gop:30:1
+// Use reflect.StringHeader as a composite literal value.
+func _(p *byte, n int) []byte {
+//line issue40701.gop:32:1
+	var res []byte
+//line issue40701.gop:33:1
+	*(*reflect.StringHeader)(unsafe.Pointer(&res)) = reflect.StringHeader{Data: uintptr(unsafe.Pointer(p)), Len: n}
+//line issue40701.gop:37:1
+	return res
+}
+//line issue40701.gop:40:1
+func _() {
+//line issue40701.gop:41:1
+	// don't crash when obj.Pkg() == nil
+	var err error
+//line issue40701.gop:43:1
+	_ = &err
+}
@@ -0,0 +1,15 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeparams
+
+// Go+ can't declare generic types and functions: they are declared in Go.
+// Go+ can't refer to the type parameters of a receiver in a method body
+// either, so the values of type parameter types are fields of the receiver.
+
+type Ptrs[IntPtr ~uintptr, RealPtr *T, AnyPtr uintptr | *T, T any] struct {
+	i IntPtr
+	r RealPtr
+	a AnyPtr
+}
@@ -8,10 +8,12 @@ package unsafeptr
 
 import (
 	_ "embed"
-	"go/ast"
-	"go/token"
 	"go/types"
 
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/go/analysis/passes/unsafeptr"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/inspect"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
@@ -22,10 +24,10 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "unsafeptr",
+	Name:     "gopUnsafeptr",
 	Doc:      analysisutil.MustExtractDoc(doc, "unsafeptr"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/unsafeptr",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []analysis.IAnalyzer{unsafeptr.Analyzer, inspect.Analyzer},
 	Run:      run,
 }
 
@@ -41,20 +43,20 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		switch x := n.(type) {
 		case *ast.CallExpr:
 			if len(x.Args) == 1 &&
-				hasBasicType(pass.TypesInfo, x.Fun, types.UnsafePointer) &&
-				hasBasicType(pass.TypesInfo, x.Args[0], types.Uintptr) &&
-				!isSafeUintptr(pass.TypesInfo, x.Args[0]) {
+				hasBasicType(pass.GopTypesInfo, x.Fun, types.UnsafePointer) &&
+				hasBasicType(pass.GopTypesInfo, x.Args[0], types.Uintptr) &&
+				!isSafeUintptr(pass.GopTypesInfo, x.Args[0]) {
 				pass.ReportRangef(x, "possible misuse of unsafe.Pointer")
 			}
 		case *ast.StarExpr:
-			if t := pass.TypesInfo.Types[x].Type; isReflectHeader(t) {
+			if t := pass.GopTypesInfo.Types[x].Type; isReflectHeader(t) {
 				pass.ReportRangef(x, "possible misuse of %s", t)
 			}
 		case *ast.UnaryExpr:
 			if x.Op != token.AND {
 				return
 			}
-			if t := pass.TypesInfo.Types[x.X].Type; isReflectHeader(t) {
+			if t := pass.GopTypesInfo.Types[x.X].Type; isReflectHeader(t) {
 				pass.ReportRangef(x, "possible misuse of %s", t)
 			}
 		}
@@ -64,7 +66,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 // isSafeUintptr reports whether x - already known to be a uintptr -
 // is safe to convert to unsafe.Pointer.
-func isSafeUintptr(info *types.Info, x ast.Expr) bool {
+func isSafeUintptr(info *typesutil.Info, x ast.Expr) bool {
 	// Check unsafe.Pointer safety rules according to
 	// https://golang.org/pkg/unsafe/#Pointer.
 
@@ -117,7 +119,7 @@ func isSafeUintptr(info *types.Info, x ast.Expr) bool {
 
 // isSafeArith reports whether x is a pointer arithmetic expression that is safe
 // to convert to unsafe.Pointer.
-func isSafeArith(info *types.Info, x ast.Expr) bool {
+func isSafeArith(info *typesutil.Info, x ast.Expr) bool {
 	switch x := analysisutil.Unparen(x).(type) {
 	case *ast.CallExpr:
 		// Base case: initial conversion from unsafe.Pointer to uintptr.
@@ -142,7 +144,7 @@ func isSafeArith(info *types.Info, x ast.Expr) bool {
 }
 
 // hasBasicType reports whether x's type is a types.Basic with the given kind.
-func hasBasicType(info *types.Info, x ast.Expr, kind types.BasicKind) bool {
+func hasBasicType(info *typesutil.Info, x ast.Expr, kind types.BasicKind) bool {
 	t := info.Types[x].Type
 	if t != nil {
 		t = t.Underlying()
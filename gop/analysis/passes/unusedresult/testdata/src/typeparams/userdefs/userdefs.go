@@ -1,9 +1,9 @@
 // Copyright 2015 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
-//
-//go:build go1.18
 
+// Package userdefs is a Go package as Go+ can't declare generic types and
+// functions.
 package userdefs
 
 func MustUse[T interface{ ~int }](v T) T {
@@ -25,4 +25,4 @@ type MultiTypeParam[T any, U any] struct {
 
 func (_ *MultiTypeParam[T, U]) String() string {
 	return "MultiTypeParam"
-}
\ No newline at end of file
+}
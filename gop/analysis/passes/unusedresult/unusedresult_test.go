@@ -9,16 +9,11 @@ import (
 
 	"golang.org/x/tools/gop/analysis/analysistest"
 	"golang.org/x/tools/gop/analysis/passes/unusedresult"
-	"golang.org/x/tools/internal/typeparams"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	funcs := "typeparams/userdefs.MustUse,errors.New,fmt.Errorf,fmt.Sprintf,fmt.Sprint"
 	unusedresult.Analyzer.Flags.Set("funcs", funcs)
-	tests := []string{"a"}
-	if typeparams.Enabled {
-		tests = append(tests, "typeparams")
-	}
-	analysistest.Run(t, testdata, unusedresult.Analyzer, tests...)
+	analysistest.Run(t, testdata, unusedresult.Analyzer, "a", "typeparams")
 }
@@ -0,0 +1,102 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import "fmt"
+
+const _ = true
+
+type T1 struct {
+	x int
+}
+type T2 struct {
+	x int
+	y int
+}
+type T3 struct {
+	y *T1
+}
+//line unusedwrite.gop:35:1
+func (t T1) BadValueReceiverWrite(v T2) {
+//line unusedwrite.gop:36:1
+	t.x = 10
+//line unusedwrite.gop:37:1
+	v.y = 20
+}
+//line unusedwrite.gop:72:1
+func (t *T1) GoodPointerReceiverWrite(v *T2) {
+//line unusedwrite.gop:73:1
+	t.x = 10
+//line unusedwrite.gop:74:1
+	v.y = 20
+}
+//line unusedwrite.gop:12:1
+func BadWrites() {
+//line unusedwrite.gop:13:1
+	// Test struct field writes.
+	var s1 T1
+//line unusedwrite.gop:15:1
+	s1.x = 10
+//line unusedwrite.gop:17:1
+	// Test array writes.
+	var s2 [10]int
+//line unusedwrite.gop:19:1
+	s2[1] = 10
+//line unusedwrite.gop:22:1
+	s3 := []T1{T1{x: 100}}
+	for
+//line unusedwrite.gop:23:1
+	i, v := range s3 {
+//line unusedwrite.gop:24:1
+		v.x = i
+	}
+//line unusedwrite.gop:28:1
+	s4 := []T2{T2{x: 1, y: 2}}
+	for
+//line unusedwrite.gop:29:1
+	i, v := range s4 {
+//line unusedwrite.gop:30:1
+		v.x = i
+//line unusedwrite.gop:31:1
+		_ = v.y
+	}
+}
+//line unusedwrite.gop:40:1
+func GoodWrites(m map[int]int) {
+//line unusedwrite.gop:42:1
+	m[1] = 10
+//line unusedwrite.gop:44:1
+	// Test struct field writes.
+	var s1 T1
+//line unusedwrite.gop:46:1
+	s1.x = 10
+//line unusedwrite.gop:47:1
+	fmt.Print(s1.x)
+//line unusedwrite.gop:49:1
+	// Test array writes.
+	var s2 [10]int
+//line unusedwrite.gop:51:1
+	s2[1] = 10
+//line unusedwrite.gop:53:1
+	_ = s2[2]
+//line unusedwrite.gop:56:1
+	s3 := []T1{T1{x: 100}}
+	for
+//line unusedwrite.gop:57:1
+	i, v := range s3 {
+//line unusedwrite.gop:58:1
+		v.x = i
+//line unusedwrite.gop:59:1
+		_ = v.x
+	}
+//line unusedwrite.gop:63:1
+	o := &T2{x: 10, y: 20}
+//line unusedwrite.gop:64:1
+	fmt.Print(o)
+//line unusedwrite.gop:67:1
+	t1 := &T1{x: 10}
+//line unusedwrite.gop:68:1
+	t2 := &T3{y: t1}
+//line unusedwrite.gop:69:1
+	fmt.Print(t2)
+}
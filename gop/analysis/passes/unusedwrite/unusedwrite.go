@@ -9,10 +9,10 @@ import (
 	"fmt"
 	"go/types"
 
+	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/passes/buildssa"
 	"golang.org/x/tools/gop/analysis/passes/internal/analysisutil"
-	"golang.org/x/tools/gop/ssa"
 )
 
 //go:embed doc.go
@@ -21,10 +21,10 @@ var doc string
 // Analyzer reports instances of writes to struct fields and arrays
 // that are never read.
 var Analyzer = &analysis.Analyzer{
-	Name:     "unusedwrite",
+	Name:     "gopUnusedwrite",
 	Doc:      analysisutil.MustExtractDoc(doc, "unusedwrite"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/gop/analysis/passes/unusedwrite",
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Requires: []analysis.IAnalyzer{buildssa.Analyzer},
 	Run:      run,
 }
 
@@ -34,13 +34,18 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		// TODO(taking): Iterate over fn._Instantiations() once exported. If so, have 1 report per Pos().
 		reports := checkStores(fn)
 		for _, store := range reports {
+			// goxls: report at the position in the Go+ files.
+			pos := ssainput.Pos(store.Pos())
+			if !pos.IsValid() {
+				continue
+			}
 			switch addr := store.Addr.(type) {
 			case *ssa.FieldAddr:
-				pass.Reportf(store.Pos(),
+				pass.Reportf(pos,
 					"unused write to field %s",
 					getFieldName(addr.X.Type(), addr.Field))
 			case *ssa.IndexAddr:
-				pass.Reportf(store.Pos(),
+				pass.Reportf(pos,
 					"unused write to array index %s", addr.Index)
 			}
 		}
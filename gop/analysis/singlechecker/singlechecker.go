@@ -36,11 +36,12 @@ import (
 )
 
 // Main is the main function for a checker command for a single analysis.
-func Main(a *analysis.Analyzer) {
+func Main(a analysis.IAnalyzer) {
+	name := analysis.Name(a)
 	log.SetFlags(0)
-	log.SetPrefix(a.Name + ": ")
+	log.SetPrefix(name + ": ")
 
-	analyzers := []*analysis.Analyzer{a}
+	analyzers := []analysis.IAnalyzer{a}
 
 	if err := analysis.Validate(analyzers); err != nil {
 		log.Fatal(err)
@@ -49,9 +50,9 @@ func Main(a *analysis.Analyzer) {
 	checker.RegisterFlags()
 
 	flag.Usage = func() {
-		paras := strings.Split(a.Doc, "\n\n")
-		fmt.Fprintf(os.Stderr, "%s: %s\n\n", a.Name, paras[0])
-		fmt.Fprintf(os.Stderr, "Usage: %s [-flag] [package]\n\n", a.Name)
+		paras := strings.Split(analysis.Doc(a), "\n\n")
+		fmt.Fprintf(os.Stderr, "%s: %s\n\n", name, paras[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [-flag] [package]\n\n", name)
 		if len(paras) > 1 {
 			fmt.Fprintln(os.Stderr, strings.Join(paras[1:], "\n\n"))
 		}
@@ -19,17 +19,17 @@ package main
 import (
 	"golang.org/x/tools/gop/analysis/unitchecker"
 
-	"golang.org/x/tools/gop/analysis/passes/asmdecl"
+	"golang.org/x/tools/go/analysis/passes/asmdecl"
+	"golang.org/x/tools/go/analysis/passes/buildtag"
+	"golang.org/x/tools/go/analysis/passes/cgocall"
+	"golang.org/x/tools/go/analysis/passes/directive"
+	"golang.org/x/tools/go/analysis/passes/framepointer"
 	"golang.org/x/tools/gop/analysis/passes/assign"
 	"golang.org/x/tools/gop/analysis/passes/atomic"
 	"golang.org/x/tools/gop/analysis/passes/bools"
-	"golang.org/x/tools/gop/analysis/passes/buildtag"
-	"golang.org/x/tools/gop/analysis/passes/cgocall"
 	"golang.org/x/tools/gop/analysis/passes/composite"
 	"golang.org/x/tools/gop/analysis/passes/copylock"
-	"golang.org/x/tools/gop/analysis/passes/directive"
 	"golang.org/x/tools/gop/analysis/passes/errorsas"
-	"golang.org/x/tools/gop/analysis/passes/framepointer"
 	"golang.org/x/tools/gop/analysis/passes/httpresponse"
 	"golang.org/x/tools/gop/analysis/passes/ifaceassert"
 	"golang.org/x/tools/gop/analysis/passes/loopclosure"
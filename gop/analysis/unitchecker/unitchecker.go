@@ -4,6 +4,9 @@
 
 // The unitchecker package defines the main function for an analysis
 // driver that analyzes a single compilation unit during a build.
+// The Go+ files of a unit, whose Go form is its gop_autogen.go files,
+// are parsed and type-checked along with its Go files, so that Go+
+// analyzers report diagnostics at positions of the Go+ files.
 // It is invoked by a build system such as "go vet":
 //
 //	$ go vet -vettool=$(which vet)
@@ -27,7 +30,6 @@ package unitchecker
 //   printf checker.
 
 import (
-	"encoding/gob"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -48,8 +50,10 @@ import (
 	"sync"
 	"time"
 
+	gopackages "golang.org/x/tools/go/packages"
 	"golang.org/x/tools/gop/analysis"
 	"golang.org/x/tools/gop/analysis/internal/analysisflags"
+	"golang.org/x/tools/gop/packages"
 	"golang.org/x/tools/internal/facts"
 	"golang.org/x/tools/internal/typeparams"
 )
@@ -84,7 +88,7 @@ type Config struct {
 //	-V=full         describe executable for build caching
 //	foo.cfg         perform separate modular analyze on the single
 //	                unit described by a JSON config file foo.cfg.
-func Main(analyzers ...*analysis.Analyzer) {
+func Main(analyzers ...analysis.IAnalyzer) {
 	progname := filepath.Base(os.Args[0])
 	log.SetFlags(0)
 	log.SetPrefix(progname + ": ")
@@ -123,7 +127,7 @@ Usage of %[1]s:
 // Run reads the *.cfg file, runs the analysis,
 // and calls os.Exit with an appropriate error code.
 // It assumes flags have already been set.
-func Run(configFile string, analyzers []*analysis.Analyzer) {
+func Run(configFile string, analyzers []analysis.IAnalyzer) {
 	cfg, err := readConfig(configFile)
 	if err != nil {
 		log.Fatal(err)
@@ -141,7 +145,7 @@ func Run(configFile string, analyzers []*analysis.Analyzer) {
 			// JSON output
 			tree := make(analysisflags.JSONTree)
 			for _, res := range results {
-				tree.Add(fset, cfg.ID, res.a.Name, res.diagnostics, res.err)
+				tree.Add(fset, cfg.ID, analysis.Name(res.a), res.diagnostics, res.err)
 			}
 			tree.Print()
 		} else {
@@ -184,7 +188,7 @@ func readConfig(filename string) (*Config, error) {
 	return cfg, nil
 }
 
-func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]result, error) {
+func run(fset *token.FileSet, cfg *Config, analyzers []analysis.IAnalyzer) ([]result, error) {
 	// Load, parse, typecheck.
 	var files []*ast.File
 	for _, name := range cfg.GoFiles {
@@ -242,6 +246,31 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 		return nil, err
 	}
 
+	// goxls: parse and typecheck the Go+ files of the package, if any.
+	gopkg := &packages.Package{
+		Package: gopackages.Package{
+			ID:              cfg.ID,
+			Name:            pkg.Name(),
+			PkgPath:         cfg.ImportPath,
+			GoFiles:         cfg.GoFiles,
+			CompiledGoFiles: cfg.GoFiles,
+			OtherFiles:      cfg.NonGoFiles,
+			IgnoredFiles:    cfg.IgnoredFiles,
+			Fset:            fset,
+			Syntax:          files,
+			Types:           pkg,
+			TypesInfo:       info,
+			TypesSizes:      tc.Sizes,
+		},
+	}
+	packages.AddGopFiles(gopkg, importer)
+	if len(gopkg.Errors) > 0 {
+		if cfg.SucceedOnTypecheckFailure {
+			return nil, nil
+		}
+		return nil, gopkg.Errors[0]
+	}
+
 	// Register fact types with gob.
 	// In VetxOnly mode, analyzers are only for their facts,
 	// so we can skip any analysis that neither produces facts
@@ -258,18 +287,15 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 		usesFacts   bool // (transitively uses)
 		diagnostics []analysis.Diagnostic
 	}
-	actions := make(map[*analysis.Analyzer]*action)
-	var registerFacts func(a *analysis.Analyzer) bool
-	registerFacts = func(a *analysis.Analyzer) bool {
+	actions := make(map[analysis.IAnalyzer]*action)
+	var registerFacts func(a analysis.IAnalyzer) bool
+	registerFacts = func(a analysis.IAnalyzer) bool {
 		act, ok := actions[a]
 		if !ok {
 			act = new(action)
-			var usesFacts bool
-			for _, f := range a.FactTypes {
-				usesFacts = true
-				gob.Register(f)
-			}
-			for _, req := range a.Requires {
+			usesFacts := len(analysis.FactTypes(a)) > 0
+			analysis.RegisterFacts(a)
+			for _, req := range analysis.Requires(a) {
 				if registerFacts(req) {
 					usesFacts = true
 				}
@@ -279,7 +305,7 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 		}
 		return act.usesFacts
 	}
-	var filtered []*analysis.Analyzer
+	var filtered []analysis.IAnalyzer
 	for _, a := range analyzers {
 		if registerFacts(a) || !cfg.VetxOnly {
 			filtered = append(filtered, a)
@@ -300,24 +326,26 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 	}
 
 	// In parallel, execute the DAG of analyzers.
-	var exec func(a *analysis.Analyzer) *action
-	var execAll func(analyzers []*analysis.Analyzer)
-	exec = func(a *analysis.Analyzer) *action {
+	var exec func(a analysis.IAnalyzer) *action
+	var execAll func(analyzers []analysis.IAnalyzer)
+	exec = func(a analysis.IAnalyzer) *action {
 		act := actions[a]
 		act.once.Do(func() {
-			execAll(a.Requires) // prefetch dependencies in parallel
+			requires := analysis.Requires(a)
+			execAll(requires) // prefetch dependencies in parallel
 
 			// The inputs to this analysis are the
 			// results of its prerequisites.
 			inputs := make(map[*analysis.Analyzer]interface{})
+			goInputs := make(map[*analysis.GoAnalyzer]interface{})
 			var failed []string
-			for _, req := range a.Requires {
+			for _, req := range requires {
 				reqact := exec(req)
 				if reqact.err != nil {
 					failed = append(failed, req.String())
 					continue
 				}
-				inputs[req] = reqact.result
+				analysis.SetResult(inputs, goInputs, req, reqact.result)
 			}
 
 			// Report an error if any dependency failed.
@@ -328,32 +356,37 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 			}
 
 			factFilter := make(map[reflect.Type]bool)
-			for _, f := range a.FactTypes {
+			for _, f := range analysis.FactTypes(a) {
 				factFilter[reflect.TypeOf(f)] = true
 			}
 
 			pass := &analysis.Pass{
-				Analyzer:          a,
-				Fset:              fset,
-				Files:             files,
-				OtherFiles:        cfg.NonGoFiles,
-				IgnoredFiles:      cfg.IgnoredFiles,
-				Pkg:               pkg,
-				TypesInfo:         info,
-				TypesSizes:        tc.Sizes,
-				TypeErrors:        nil, // unitchecker doesn't RunDespiteErrors
-				ResultOf:          inputs,
-				Report:            func(d analysis.Diagnostic) { act.diagnostics = append(act.diagnostics, d) },
-				ImportObjectFact:  facts.ImportObjectFact,
-				ExportObjectFact:  facts.ExportObjectFact,
-				AllObjectFacts:    func() []analysis.ObjectFact { return facts.AllObjectFacts(factFilter) },
-				ImportPackageFact: facts.ImportPackageFact,
-				ExportPackageFact: facts.ExportPackageFact,
-				AllPackageFacts:   func() []analysis.PackageFact { return facts.AllPackageFacts(factFilter) },
+				GoPass: analysis.GoPass{
+					Fset:              fset,
+					Files:             gopkg.NongenSyntax, // goxls: not gop_autogen.go
+					OtherFiles:        cfg.NonGoFiles,
+					IgnoredFiles:      cfg.IgnoredFiles,
+					Pkg:               pkg,
+					TypesInfo:         info,
+					TypesSizes:        tc.Sizes,
+					TypeErrors:        nil, // unitchecker doesn't RunDespiteErrors
+					ResultOf:          goInputs,
+					Report:            func(d analysis.Diagnostic) { act.diagnostics = append(act.diagnostics, d) },
+					ImportObjectFact:  facts.ImportObjectFact,
+					ExportObjectFact:  facts.ExportObjectFact,
+					AllObjectFacts:    func() []analysis.ObjectFact { return facts.AllObjectFacts(factFilter) },
+					ImportPackageFact: facts.ImportPackageFact,
+					ExportPackageFact: facts.ExportPackageFact,
+					AllPackageFacts:   func() []analysis.PackageFact { return facts.AllPackageFacts(factFilter) },
+				},
+				ResultOf:     inputs,
+				GopFiles:     gopkg.GopSyntax,
+				GopTypesInfo: gopkg.GopTypesInfo,
 			}
+			pass.SetAnalyzer(a)
 
 			t0 := time.Now()
-			act.result, act.err = a.Run(pass)
+			act.result, act.err = pass.Run()
 
 			if act.err == nil { // resolve URLs on diagnostics.
 				for i := range act.diagnostics {
@@ -370,11 +403,11 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 		})
 		return act
 	}
-	execAll = func(analyzers []*analysis.Analyzer) {
+	execAll = func(analyzers []analysis.IAnalyzer) {
 		var wg sync.WaitGroup
 		for _, a := range analyzers {
 			wg.Add(1)
-			go func(a *analysis.Analyzer) {
+			go func(a analysis.IAnalyzer) {
 				_ = exec(a)
 				wg.Done()
 			}(a)
@@ -402,7 +435,7 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 }
 
 type result struct {
-	a           *analysis.Analyzer
+	a           analysis.IAnalyzer
 	diagnostics []analysis.Diagnostic
 	err         error
 }
@@ -47,6 +47,8 @@ func minivet() {
 
 // This is a very basic integration test of modular
 // analysis with facts using unitchecker under "go vet".
+// The diagnostics must be reported in the Go+ files,
+// not in their Go form gop_autogen.go.
 // It fork/execs the main function above.
 func TestIntegration(t *testing.T) { packagestest.TestAll(t, testIntegration) }
 func testIntegration(t *testing.T, exporter packagestest.Exporter) {
@@ -57,15 +59,34 @@ func testIntegration(t *testing.T, exporter packagestest.Exporter) {
 	exported := packagestest.Export(t, exporter, []packagestest.Module{{
 		Name: "golang.org/fake",
 		Files: map[string]interface{}{
-			"a/a.go": `package a
+			"a/a.gop": `package a
+
+import "fmt"
 
 func _() {
 	MyFunc123()
 }
 
 func MyFunc123() {}
+
+func Printf(format string, args ...any) {
+	fmt.Printf(format, args...)
+}
 `,
-			"b/b.go": `package b
+			"a/gop_autogen.go": `package a
+
+import "fmt"
+
+func _() {
+	MyFunc123()
+}
+func MyFunc123() {
+}
+func Printf(format string, args ...interface{}) {
+	fmt.Printf(format, args...)
+}
+`,
+			"b/b.gop": `package b
 
 import "golang.org/fake/a"
 
@@ -76,41 +97,78 @@ func _() {
 
 func MyFunc123() {}
 `,
-			"c/c.go": `package c
+			"b/gop_autogen.go": `package b
+
+import "golang.org/fake/a"
+
+func _() {
+	a.MyFunc123()
+	MyFunc123()
+}
+func MyFunc123() {
+}
+`,
+			"c/c.gop": `package c
 
 func _() {
     i := 5
     i = i
 }
+`,
+			"c/gop_autogen.go": `package c
+
+func _() {
+	i := 5
+	i = i
+}
+`,
+			"d/d.gop": `package d
+
+import "golang.org/fake/a"
+
+func _() {
+	a.Printf("%d", "hello")
+}
+`,
+			"d/gop_autogen.go": `package d
+
+import "golang.org/fake/a"
+
+func _() {
+	a.Printf("%d", "hello")
+}
 `,
 		}}})
 	defer exported.Cleanup()
 
 	const wantA = `# golang.org/fake/a
-([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.go:4:11: call of MyFunc123\(...\)
+([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.gop:6:11: call of MyFunc123\(...\)
 `
 	const wantB = `# golang.org/fake/b
-([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?b/b.go:6:13: call of MyFunc123\(...\)
-([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?b/b.go:7:11: call of MyFunc123\(...\)
+([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?b/b.gop:6:13: call of MyFunc123\(...\)
+([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?b/b.gop:7:11: call of MyFunc123\(...\)
 `
 	const wantC = `# golang.org/fake/c
-([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.go:5:5: self-assignment of i to i
+([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.gop:5:5: self-assignment of i to i
+`
+	const wantD = `# golang.org/fake/d
+([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?d/d.gop:6:2: golang.org/fake/a.Printf format %d has arg "hello" of wrong type string
 `
 	const wantAJSON = `# golang.org/fake/a
 \{
 	"golang.org/fake/a": \{
-		"findcall": \[
+		"gopFindcall": \[
 			\{
-				"posn": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.go:4:11",
+				"posn": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.gop:6:11",
 				"message": "call of MyFunc123\(...\)",
 				"suggested_fixes": \[
 					\{
 						"message": "Add '_TEST_'",
 						"edits": \[
 							\{
-								"filename": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.go",
-								"start": 32,
-								"end": 32,
+								"filename": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?a/a.gop",
+								"start": 46,
+								"end": 46,
 								"new": "_TEST_"
 							\}
 						\]
@@ -124,16 +182,16 @@ func _() {
 	const wantCJSON = `# golang.org/fake/c
 \{
 	"golang.org/fake/c": \{
-		"assign": \[
+		"gopAssign": \[
 			\{
-				"posn": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.go:5:5",
+				"posn": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.gop:5:5",
 				"message": "self-assignment of i to i",
 				"suggested_fixes": \[
 					\{
 						"message": "Remove",
 						"edits": \[
 							\{
-								"filename": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.go",
+								"filename": "([/._\-a-zA-Z0-9]+[\\/]fake[\\/])?c/c.gop",
 								"start": 37,
 								"end": 42,
 								"new": ""
@@ -154,12 +212,13 @@ func _() {
 		{args: "golang.org/fake/a", wantOut: wantA, wantExitError: true},
 		{args: "golang.org/fake/b", wantOut: wantB, wantExitError: true},
 		{args: "golang.org/fake/c", wantOut: wantC, wantExitError: true},
+		{args: "golang.org/fake/d", wantOut: wantD, wantExitError: true},
 		{args: "golang.org/fake/a golang.org/fake/b", wantOut: wantA + wantB, wantExitError: true},
 		{args: "-json golang.org/fake/a", wantOut: wantAJSON, wantExitError: false},
 		{args: "-json golang.org/fake/c", wantOut: wantCJSON, wantExitError: false},
-		{args: "-c=0 golang.org/fake/a", wantOut: wantA + "4		MyFunc123\\(\\)\n", wantExitError: true},
+		{args: "-c=0 golang.org/fake/a", wantOut: wantA + "6		MyFunc123\\(\\)\n", wantExitError: true},
 	} {
-		cmd := exec.Command("go", "vet", "-vettool="+os.Args[0], "-findcall.name=MyFunc123")
+		cmd := exec.Command("go", "vet", "-vettool="+os.Args[0], "-gopFindcall.name=MyFunc123")
 		cmd.Args = append(cmd.Args, strings.Fields(test.args)...)
 		cmd.Env = append(exported.Config.Env, "ENTRYPOINT=minivet")
 		cmd.Dir = exported.Config.Dir
@@ -225,9 +225,11 @@ func childrenOf(n ast.Node) []ast.Node {
 			tok(n.TokPos, len(n.Tok.String())))
 
 	case *ast.CallExpr:
-		children = append(children,
-			tok(n.Lparen, len("(")),
-			tok(n.Rparen, len(")")))
+		if !n.IsCommand() { // goxls: command-style calls have no parens
+			children = append(children,
+				tok(n.Lparen, len("(")),
+				tok(n.Rparen, len(")")))
+		}
 		if n.Ellipsis != 0 {
 			children = append(children, tok(n.Ellipsis, len("...")))
 		}
@@ -467,6 +469,56 @@ func childrenOf(n ast.Node) []ast.Node {
 
 	case *ast.BadDecl, *ast.BadExpr, *ast.BadStmt:
 		// nop
+
+	// goxls: Go+ extended nodes
+	case *ast.OverloadFuncDecl:
+		children = append(children,
+			tok(n.Func, len("func")),
+			tok(n.Assign, len("=")))
+		if n.Lparen != 0 {
+			children = append(children,
+				tok(n.Lparen, len("(")),
+				tok(n.Rparen, len(")")))
+		}
+
+	case *ast.SliceLit:
+		children = append(children,
+			tok(n.Lbrack, len("[")),
+			tok(n.Rbrack, len("]")))
+
+	case *ast.LambdaExpr:
+		children = append(children, tok(n.Rarrow, len("=>")))
+
+	case *ast.LambdaExpr2:
+		children = append(children, tok(n.Rarrow, len("=>")))
+
+	case *ast.ComprehensionExpr:
+		children = append(children,
+			tok(n.Lpos, len("[")),
+			tok(n.Rpos, len("]")))
+
+	case *ast.ForPhrase:
+		children = append(children, tok(n.For, len("for")))
+		if n.TokPos != 0 {
+			children = append(children, tok(n.TokPos, len("<-")))
+		}
+
+	case *ast.RangeExpr:
+		children = append(children, tok(n.To, len(":")))
+		if n.Colon2 != 0 {
+			children = append(children, tok(n.Colon2, len(":")))
+		}
+
+	case *ast.ErrWrapExpr:
+		children = append(children, tok(n.TokPos, len(n.Tok.String())))
+
+	case *ast.EnvExpr:
+		children = append(children, tok(n.TokPos, len("$")))
+		if n.HasBrace() {
+			children = append(children,
+				tok(n.Lbrace, len("{")),
+				tok(n.Rbrace, len("}")))
+		}
 	}
 
 	// TODO(adonovan): opt: merge the logic of ast.Inspect() into
@@ -641,6 +693,29 @@ func NodeDescription(n ast.Node) string {
 	case *ast.ValueSpec:
 		return "value specification"
 
+	// goxls: Go+ extended nodes
+	case *ast.OverloadFuncDecl:
+		return "overload function declaration"
+	case *ast.SliceLit:
+		return "slice literal"
+	case *ast.MatrixLit:
+		return "matrix literal"
+	case *ast.ElemEllipsis:
+		return "element ellipsis"
+	case *ast.LambdaExpr, *ast.LambdaExpr2:
+		return "lambda expression"
+	case *ast.ComprehensionExpr:
+		return "comprehension expression"
+	case *ast.ForPhrase:
+		return "for phrase"
+	case *ast.ForPhraseStmt:
+		return "for phrase loop"
+	case *ast.RangeExpr:
+		return "range expression"
+	case *ast.ErrWrapExpr:
+		return fmt.Sprintf("error wrap %s operation", n.Tok)
+	case *ast.EnvExpr:
+		return "environment variable"
 	}
 	panic(fmt.Sprintf("unexpected node type: %T", n))
 }
@@ -6,13 +6,14 @@ package astutil
 
 import (
 	"bytes"
-	"go/ast"
-	"go/format"
-	"go/parser"
-	"go/token"
 	"reflect"
 	"strconv"
 	"testing"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
 )
 
 var fset = token.NewFileSet()
@@ -1048,7 +1049,7 @@ import (
 	"os"
 	"utf8"
 
-	"go/format"
+	"github.com/goplus/gop/format"
 )
 `,
 		out: `package main
@@ -1059,7 +1060,7 @@ import (
 	"os"
 	"utf8"
 
-	"go/format"
+	"github.com/goplus/gop/format"
 )
 `,
 	},
@@ -1075,7 +1076,7 @@ import (
 	"os"   // c
 	"utf8" // d
 
-	"go/format" // e
+	"github.com/goplus/gop/format" // e
 )
 `,
 		out: `package main
@@ -1087,7 +1088,7 @@ import (
 	"os"   // c
 	"utf8" // d
 
-	"go/format" // e
+	"github.com/goplus/gop/format" // e
 )
 `,
 	},
@@ -6,11 +6,10 @@ package astutil
 
 import (
 	"fmt"
-	"go/ast"
 	"reflect"
 	"sort"
 
-	"golang.org/x/tools/internal/typeparams"
+	"github.com/goplus/gop/ast"
 )
 
 // An ApplyFunc is invoked by Apply for each node n, even if n is nil,
@@ -41,6 +40,13 @@ type ApplyFunc func(*Cursor) bool
 // Children are traversed in the order in which they appear in the
 // respective node's struct definition. A package's files are
 // traversed in the filenames' alphabetical order.
+//
+// goxls: Go+ specific nodes are traversed like in ast.Walk: the
+// signature of a shadow *ast.FuncDecl and the name of a *ast.File
+// without a package clause are skipped, and so are the Go files of a
+// *ast.Package. The expressions embedded in a Go+ string literal are
+// children of its *ast.BasicLit, named "Parts", and the elements of a
+// *ast.MatrixLit are traversed row by row.
 func Apply(root ast.Node, pre, post ApplyFunc) (result ast.Node) {
 	parent := &struct{ ast.Node }{root}
 	defer func() {
@@ -64,8 +70,12 @@ var abort = new(int) // singleton, to signal termination of Apply
 // c.Parent(), and f is the field identifier with name c.Name(),
 // the following invariants hold:
 //
-//	p.f            == c.Node()  if c.Index() <  0
-//	p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//	p.f                      == c.Node()  if c.Index() <  0
+//	p.f[c.Index()]           == c.Node()  if c.Index() >= 0 && c.Row() < 0
+//	p.f[c.Row()][c.Index()]  == c.Node()  if c.Row() >= 0
+//
+// except for the expressions of a Go+ string literal, where p.f is
+// p.Extra.Parts.
 //
 // The methods Replace, Delete, InsertBefore, and InsertAfter
 // can be used to change the AST without disrupting Apply.
@@ -98,9 +108,35 @@ func (c *Cursor) Index() int {
 	return -1
 }
 
+// Row reports the row index >= 0 of the current Node in the elements of
+// a *ast.MatrixLit, or a value < 0 otherwise. Index then reports the
+// column of the current Node in that row.
+func (c *Cursor) Row() int {
+	if c.iter != nil {
+		return c.iter.row
+	}
+	return -1
+}
+
 // field returns the current node's parent field value.
 func (c *Cursor) field() reflect.Value {
-	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+	return fieldValue(c.parent, c.name, c.Row())
+}
+
+// fieldValue returns the value of the field name of parent, or of its
+// row-th element if row >= 0.
+func fieldValue(parent ast.Node, name string, row int) reflect.Value {
+	var v reflect.Value
+	if lit, ok := parent.(*ast.BasicLit); ok && name == "Parts" {
+		// goxls: the parts of a Go+ string literal
+		v = reflect.ValueOf(lit.Extra).Elem().FieldByName(name)
+	} else {
+		v = reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+	}
+	if row >= 0 {
+		v = v.Index(row)
+	}
+	return v
 }
 
 // Replace replaces the current Node with n.
@@ -202,7 +238,7 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 	}
 
 	// walk children
-	// (the order of the cases matches the order of the corresponding node types in go/ast)
+	// (the order of the cases matches the order of the corresponding node types in gop/ast)
 	switch n := n.(type) {
 	case nil:
 		// nothing to do
@@ -227,9 +263,14 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 		a.applyList(n, "List")
 
 	// Expressions
-	case *ast.BadExpr, *ast.Ident, *ast.BasicLit:
+	case *ast.BadExpr, *ast.Ident:
 		// nothing to do
 
+	case *ast.BasicLit:
+		if n.Extra != nil { // goxls: Go+ string literal with embedded expressions
+			a.applyList(n, "Parts")
+		}
+
 	case *ast.Ellipsis:
 		a.apply(n, "Elt", nil, n.Elt)
 
@@ -252,7 +293,7 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 		a.apply(n, "X", nil, n.X)
 		a.apply(n, "Index", nil, n.Index)
 
-	case *typeparams.IndexListExpr:
+	case *ast.IndexListExpr:
 		a.apply(n, "X", nil, n.X)
 		a.applyList(n, "Indices")
 
@@ -293,9 +334,7 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 		a.apply(n, "Fields", nil, n.Fields)
 
 	case *ast.FuncType:
-		if tparams := typeparams.ForFuncType(n); tparams != nil {
-			a.apply(n, "TypeParams", nil, tparams)
-		}
+		a.apply(n, "TypeParams", nil, n.TypeParams)
 		a.apply(n, "Params", nil, n.Params)
 		a.apply(n, "Results", nil, n.Results)
 
@@ -408,9 +447,7 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 	case *ast.TypeSpec:
 		a.apply(n, "Doc", nil, n.Doc)
 		a.apply(n, "Name", nil, n.Name)
-		if tparams := typeparams.ForTypeSpec(n); tparams != nil {
-			a.apply(n, "TypeParams", nil, tparams)
-		}
+		a.apply(n, "TypeParams", nil, n.TypeParams)
 		a.apply(n, "Type", nil, n.Type)
 		a.apply(n, "Comment", nil, n.Comment)
 
@@ -422,16 +459,20 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 		a.applyList(n, "Specs")
 
 	case *ast.FuncDecl:
-		a.apply(n, "Doc", nil, n.Doc)
-		a.apply(n, "Recv", nil, n.Recv)
-		a.apply(n, "Name", nil, n.Name)
-		a.apply(n, "Type", nil, n.Type)
+		if !n.Shadow { // goxls: the signature of a shadow entry isn't in the source
+			a.apply(n, "Doc", nil, n.Doc)
+			a.apply(n, "Recv", nil, n.Recv)
+			a.apply(n, "Name", nil, n.Name)
+			a.apply(n, "Type", nil, n.Type)
+		}
 		a.apply(n, "Body", nil, n.Body)
 
 	// Files and packages
 	case *ast.File:
 		a.apply(n, "Doc", nil, n.Doc)
-		a.apply(n, "Name", nil, n.Name)
+		if !n.NoPkgDecl { // goxls: check has package
+			a.apply(n, "Name", nil, n.Name)
+		}
 		a.applyList(n, "Decls")
 		// Don't walk n.Comments; they have either been walked already if
 		// they are Doc comments, or they can be easily walked explicitly.
@@ -446,6 +487,58 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 		for _, name := range names {
 			a.apply(n, name, nil, n.Files[name])
 		}
+		// goxls: n.GoFiles are Go syntax trees, not Go+ ones
+
+	// goxls: Go+ extended nodes
+	case *ast.OverloadFuncDecl:
+		a.apply(n, "Doc", nil, n.Doc)
+		a.apply(n, "Recv", nil, n.Recv)
+		a.apply(n, "Name", nil, n.Name)
+		a.applyList(n, "Funcs")
+
+	case *ast.EnvExpr:
+		a.apply(n, "Name", nil, n.Name)
+
+	case *ast.SliceLit:
+		a.applyList(n, "Elts")
+
+	case *ast.MatrixLit:
+		a.applyMatrix(n, "Elts")
+
+	case *ast.ElemEllipsis:
+		a.apply(n, "Elt", nil, n.Elt)
+
+	case *ast.ErrWrapExpr:
+		a.apply(n, "X", nil, n.X)
+		a.apply(n, "Default", nil, n.Default)
+
+	case *ast.LambdaExpr:
+		a.applyList(n, "Lhs")
+		a.applyList(n, "Rhs")
+
+	case *ast.LambdaExpr2:
+		a.applyList(n, "Lhs")
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.ForPhrase:
+		a.apply(n, "Key", nil, n.Key)
+		a.apply(n, "Value", nil, n.Value)
+		a.apply(n, "X", nil, n.X)
+		a.apply(n, "Init", nil, n.Init)
+		a.apply(n, "Cond", nil, n.Cond)
+
+	case *ast.ComprehensionExpr:
+		a.apply(n, "Elt", nil, n.Elt)
+		a.applyList(n, "Fors")
+
+	case *ast.ForPhraseStmt:
+		a.apply(n, "ForPhrase", nil, n.ForPhrase)
+		a.apply(n, "Body", nil, n.Body)
+
+	case *ast.RangeExpr:
+		a.apply(n, "First", nil, n.First)
+		a.apply(n, "Last", nil, n.Last)
+		a.apply(n, "Expr3", nil, n.Expr3)
 
 	default:
 		panic(fmt.Sprintf("Apply: unexpected node type %T", n))
@@ -461,15 +554,32 @@ func (a *application) apply(parent ast.Node, name string, iter *iterator, n ast.
 // An iterator controls iteration over a slice of nodes.
 type iterator struct {
 	index, step int
+	row         int // row of a *ast.MatrixLit; or -1
 }
 
 func (a *application) applyList(parent ast.Node, name string) {
+	a.applyRow(parent, name, -1)
+}
+
+// applyMatrix applies to the elements of the [][]ast.Expr field name of
+// parent, row by row.
+func (a *application) applyMatrix(parent ast.Node, name string) {
+	rows := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name).Len()
+	for row := 0; row < rows; row++ {
+		a.applyRow(parent, name, row)
+	}
+}
+
+// applyRow applies to the elements of the slice field name of parent,
+// or of its row-th element if row >= 0.
+func (a *application) applyRow(parent ast.Node, name string, row int) {
 	// avoid heap-allocating a new iterator for each applyList call; reuse a.iter instead
 	saved := a.iter
 	a.iter.index = 0
+	a.iter.row = row
 	for {
 		// must reload parent.name each time, since cursor modifications might change it
-		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		v := fieldValue(parent, name, row)
 		if a.iter.index >= v.Len() {
 			break
 		}
@@ -477,6 +587,11 @@ func (a *application) applyList(parent ast.Node, name string) {
 		// element x may be nil in a bad AST - be cautious
 		var x ast.Node
 		if e := v.Index(a.iter.index); e.IsValid() {
+			if _, ok := e.Interface().(string); ok {
+				// goxls: skip the string parts of a Go+ string literal
+				a.iter.index++
+				continue
+			}
 			x = e.Interface().(ast.Node)
 		}
 
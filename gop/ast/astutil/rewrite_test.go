@@ -0,0 +1,568 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gop/ast/astutil"
+)
+
+type rewriteTest struct {
+	name       string
+	orig, want string
+	pre, post  astutil.ApplyFunc
+}
+
+var rewriteTests = []rewriteTest{
+	{name: "nop", orig: "package p\n", want: "package p\n"},
+
+	{name: "replace",
+		orig: `package p
+
+var x int
+`,
+		want: `package p
+
+var t T
+`,
+		post: func(c *astutil.Cursor) bool {
+			if _, ok := c.Node().(*ast.ValueSpec); ok {
+				c.Replace(valspec("t", "T"))
+				return false
+			}
+			return true
+		},
+	},
+
+	{name: "set doc strings",
+		orig: `package p
+
+const z = 0
+
+type T struct{}
+
+var x int
+`,
+		want: `package p
+// a foo is a foo
+const z = 0
+// a foo is a foo
+type T struct{}
+// a foo is a foo
+var x int
+`,
+		post: func(c *astutil.Cursor) bool {
+			if _, ok := c.Parent().(*ast.GenDecl); ok && c.Name() == "Doc" && c.Node() == nil {
+				c.Replace(&ast.CommentGroup{List: []*ast.Comment{{Text: "// a foo is a foo"}}})
+			}
+			return true
+		},
+	},
+
+	{name: "insert names",
+		orig: `package p
+
+const a = 1
+`,
+		want: `package p
+
+const a, b, c = 1, 2, 3
+`,
+		pre: func(c *astutil.Cursor) bool {
+			if _, ok := c.Parent().(*ast.ValueSpec); ok {
+				switch c.Name() {
+				case "Names":
+					c.InsertAfter(ast.NewIdent("c"))
+					c.InsertAfter(ast.NewIdent("b"))
+				case "Values":
+					c.InsertAfter(&ast.BasicLit{Kind: token.INT, Value: "3"})
+					c.InsertAfter(&ast.BasicLit{Kind: token.INT, Value: "2"})
+				}
+			}
+			return true
+		},
+	},
+
+	{name: "insert",
+		orig: `package p
+
+var (
+	x int
+	y int
+)
+`,
+		want: `package p
+
+var before1 int
+var before2 int
+
+var (
+	x int
+	y int
+)
+var after2 int
+var after1 int
+`,
+		pre: func(c *astutil.Cursor) bool {
+			if _, ok := c.Node().(*ast.GenDecl); ok {
+				c.InsertBefore(vardecl("before1", "int"))
+				c.InsertAfter(vardecl("after1", "int"))
+				c.InsertAfter(vardecl("after2", "int"))
+				c.InsertBefore(vardecl("before2", "int"))
+			}
+			return true
+		},
+	},
+
+	{name: "delete",
+		orig: `package p
+
+var x int
+var y int
+var z int
+`,
+		want: `package p
+
+var y int
+var z int
+`,
+		pre: func(c *astutil.Cursor) bool {
+			n := c.Node()
+			if d, ok := n.(*ast.GenDecl); ok && d.Specs[0].(*ast.ValueSpec).Names[0].Name == "x" {
+				c.Delete()
+			}
+			return true
+		},
+	},
+
+	{name: "insertafter-delete",
+		orig: `package p
+
+var x int
+var y int
+var z int
+`,
+		want: `package p
+
+var x1 int
+
+var y int
+var z int
+`,
+		pre: func(c *astutil.Cursor) bool {
+			n := c.Node()
+			if d, ok := n.(*ast.GenDecl); ok && d.Specs[0].(*ast.ValueSpec).Names[0].Name == "x" {
+				c.InsertAfter(vardecl("x1", "int"))
+				c.Delete()
+			}
+			return true
+		},
+	},
+
+	{name: "delete-insertafter",
+		orig: `package p
+
+var x int
+var y int
+var z int
+`,
+		want: `package p
+
+var y int
+var x1 int
+var z int
+`,
+		pre: func(c *astutil.Cursor) bool {
+			n := c.Node()
+			if d, ok := n.(*ast.GenDecl); ok && d.Specs[0].(*ast.ValueSpec).Names[0].Name == "x" {
+				c.Delete()
+				// The cursor is now effectively atop the 'var y int' node.
+				c.InsertAfter(vardecl("x1", "int"))
+			}
+			return true
+		},
+	},
+
+	// Go+ extended nodes
+	{name: "lambda",
+		orig: `apply x => x * x
+apply (x, y) => {
+	println x, y
+}
+`,
+		want: `apply v => v * v
+apply (v) => {
+	println v
+}
+`,
+		post: func(c *astutil.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *ast.Ident:
+				switch n.Name {
+				case "x":
+					c.Replace(ast.NewIdent("v"))
+				case "y":
+					c.Delete()
+				}
+			}
+			return true
+		},
+	},
+
+	{name: "comprehension",
+		orig: `echo [x*x for x <- 1:5 if x > 1], {k: v for k, v <- m}
+for x <- 1:5:2 {
+}
+`,
+		want: `echo [x*x for x <- 1:10 if x > 1], {k: v for k, v <- m}
+for x <- 1:10:2 {
+}
+`,
+		post: func(c *astutil.Cursor) bool {
+			if _, ok := c.Parent().(*ast.RangeExpr); ok && c.Name() == "Last" {
+				c.Replace(&ast.BasicLit{Kind: token.INT, Value: "10"})
+			}
+			return true
+		},
+	},
+
+	{name: "errwrap",
+		orig: `n := strconv.Atoi(s)!
+m := strconv.Atoi(s)?:0
+`,
+		want: `n := strconv.Atoi(s)
+m := strconv.Atoi(s)?:-1
+`,
+		post: func(c *astutil.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *ast.ErrWrapExpr:
+				if n.Tok == token.NOT {
+					c.Replace(n.X)
+				}
+			case *ast.BasicLit:
+				if c.Name() == "Default" {
+					c.Replace(&ast.BasicLit{Kind: token.INT, Value: "-1"})
+				}
+			}
+			return true
+		},
+	},
+
+	{name: "matrix",
+		orig: `echo [
+	1, 2
+	3, 4
+]
+`,
+		want: `echo [
+	1, 2
+	0, 4, 5
+]
+`,
+		pre: func(c *astutil.Cursor) bool {
+			if _, ok := c.Parent().(*ast.MatrixLit); ok && c.Row() == 1 {
+				switch c.Index() {
+				case 0:
+					c.Replace(&ast.BasicLit{Kind: token.INT, Value: "0"})
+				case 1:
+					c.InsertAfter(&ast.BasicLit{Kind: token.INT, Value: "5"})
+				}
+			}
+			return true
+		},
+	},
+
+	{name: "overload",
+		orig: `func add = (
+	addInt
+	addFloat
+)
+`,
+		want: `func add = (
+	addInt
+	addString
+	addFloat
+)
+`,
+		pre: func(c *astutil.Cursor) bool {
+			if id, ok := c.Node().(*ast.Ident); ok && id.Name == "addInt" {
+				c.InsertAfter(ast.NewIdent("addString"))
+			}
+			return true
+		},
+	},
+}
+
+func valspec(name, typ string) *ast.ValueSpec {
+	return &ast.ValueSpec{Names: []*ast.Ident{ast.NewIdent(name)},
+		Type: ast.NewIdent(typ),
+	}
+}
+
+func vardecl(name, typ string) *ast.GenDecl {
+	return &ast.GenDecl{
+		Tok:   token.VAR,
+		Specs: []ast.Spec{valspec(name, typ)},
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	t.Run("*", func(t *testing.T) {
+		for _, test := range rewriteTests {
+			test := test
+			t.Run(test.name, func(t *testing.T) {
+				t.Parallel()
+				fset := token.NewFileSet()
+				f, err := parser.ParseFile(fset, test.name, test.orig, parser.ParseComments)
+				if err != nil {
+					t.Fatal(err)
+				}
+				n := astutil.Apply(f, test.pre, test.post)
+				var buf bytes.Buffer
+				if err := format.Node(&buf, fset, n); err != nil {
+					t.Fatal(err)
+				}
+				got := buf.String()
+				if got != test.want {
+					t.Errorf("got:\n\n%s\nwant:\n\n%s\n", got, test.want)
+				}
+			})
+		}
+	})
+}
+
+var sink ast.Node
+
+func BenchmarkRewrite(b *testing.B) {
+	for _, test := range rewriteTests {
+		b.Run(test.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fset := token.NewFileSet()
+				f, err := parser.ParseFile(fset, test.name, test.orig, parser.ParseComments)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+				sink = astutil.Apply(f, test.pre, test.post)
+			}
+		})
+	}
+}
+
+// allNodesSrc is a Go+ file using every node type of gop/ast but the ones
+// that can't be parsed: see TestApplyAllNodes.
+const allNodesSrc = `// Package main uses every node type.
+package main
+
+import "strconv"
+
+type T struct {
+	a int ` + "`json:\"a\"`" + ` // a
+}
+
+type I interface {
+	M()
+}
+
+func (t *T) M() {}
+
+func add = (
+	func(a, b int) int {
+		return a + b
+	}
+	addFloat
+)
+
+func f(ch chan int, m map[string]int, args ...int) (n int, err error) {
+	const c = 1
+	var arr [2]int
+	var p *T
+	x := [1, 2, 3]
+	y := [v * 2 for v <- x if v > 1]
+	z := {k: v for k, v <- m}
+	echo [
+		1, 2
+		x...
+	]
+	n = strconv.Atoi("1")?
+	echo "a${n}b", ${HOME}, y, z
+	apply(v => v * v)
+	apply(v => {
+		echo v
+	})
+	for i <- 1:10:2 {
+		echo i
+	}
+	for i := range :10 {
+		echo i
+	}
+	for i := 0; i < 2; i++ {
+		continue
+	}
+	if v, ok := any(p).(I); ok {
+		v.M()
+	} else {
+		p = &T{a: -c}
+	}
+	switch c {
+	case 1:
+	}
+	switch t := any(p).(type) {
+	default:
+		_ = t
+	}
+	select {
+	case ch <- 1:
+	}
+	go func() {}()
+	defer f(nil, nil)
+	_ = (arr[1:2])[0]
+	{
+	L:
+	}
+	return
+}
+`
+
+func TestApplyAllNodes(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "a.gop", allNodesSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// These nodes can't be parsed by the Go+ parser.
+	f.Decls = append(f.Decls, &ast.BadDecl{}, &ast.FuncDecl{
+		Name: ast.NewIdent("g"),
+		Type: &ast.FuncType{
+			TypeParams: &ast.FieldList{List: []*ast.Field{{
+				Names: []*ast.Ident{ast.NewIdent("P")},
+				Type:  ast.NewIdent("any"),
+			}}},
+			Params: &ast.FieldList{},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.BadStmt{},
+			&ast.ExprStmt{X: &ast.IndexListExpr{
+				X:       ast.NewIdent("h"),
+				Indices: []ast.Expr{ast.NewIdent("int"), &ast.BadExpr{}},
+			}},
+		}},
+	})
+	pkg := &ast.Package{Name: "main", Files: map[string]*ast.File{"a.gop": f}}
+
+	seen := make(map[reflect.Type]bool)
+	astutil.Apply(pkg, func(c *astutil.Cursor) bool {
+		n := c.Node()
+		if n == nil {
+			return true
+		}
+		seen[reflect.TypeOf(n)] = true
+		// Replacing a node by itself checks that the Cursor addresses it.
+		c.Replace(n)
+		if _, ok := n.(*ast.Ident); ok && c.Name() == "Parts" {
+			c.Replace(ast.NewIdent("m"))
+		}
+		return true
+	}, nil)
+
+	for _, n := range []ast.Node{
+		(*ast.Comment)(nil),
+		(*ast.CommentGroup)(nil),
+		(*ast.Field)(nil),
+		(*ast.FieldList)(nil),
+		(*ast.BadExpr)(nil),
+		(*ast.Ident)(nil),
+		(*ast.BasicLit)(nil),
+		(*ast.Ellipsis)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.ParenExpr)(nil),
+		(*ast.SelectorExpr)(nil),
+		(*ast.IndexExpr)(nil),
+		(*ast.IndexListExpr)(nil),
+		(*ast.SliceExpr)(nil),
+		(*ast.TypeAssertExpr)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.StarExpr)(nil),
+		(*ast.UnaryExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.KeyValueExpr)(nil),
+		(*ast.ArrayType)(nil),
+		(*ast.StructType)(nil),
+		(*ast.FuncType)(nil),
+		(*ast.InterfaceType)(nil),
+		(*ast.MapType)(nil),
+		(*ast.ChanType)(nil),
+		(*ast.BadStmt)(nil),
+		(*ast.DeclStmt)(nil),
+		(*ast.EmptyStmt)(nil),
+		(*ast.LabeledStmt)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.SendStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.GoStmt)(nil),
+		(*ast.DeferStmt)(nil),
+		(*ast.ReturnStmt)(nil),
+		(*ast.BranchStmt)(nil),
+		(*ast.BlockStmt)(nil),
+		(*ast.IfStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.SwitchStmt)(nil),
+		(*ast.TypeSwitchStmt)(nil),
+		(*ast.CommClause)(nil),
+		(*ast.SelectStmt)(nil),
+		(*ast.ForStmt)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.ImportSpec)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.TypeSpec)(nil),
+		(*ast.BadDecl)(nil),
+		(*ast.GenDecl)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.File)(nil),
+		(*ast.Package)(nil),
+		(*ast.OverloadFuncDecl)(nil),
+		(*ast.EnvExpr)(nil),
+		(*ast.SliceLit)(nil),
+		(*ast.MatrixLit)(nil),
+		(*ast.ElemEllipsis)(nil),
+		(*ast.ErrWrapExpr)(nil),
+		(*ast.LambdaExpr)(nil),
+		(*ast.LambdaExpr2)(nil),
+		(*ast.ForPhrase)(nil),
+		(*ast.ComprehensionExpr)(nil),
+		(*ast.ForPhraseStmt)(nil),
+		(*ast.RangeExpr)(nil),
+	} {
+		if typ := reflect.TypeOf(n); !seen[typ] {
+			t.Errorf("Apply didn't visit any %v", typ)
+		}
+	}
+
+	// The expressions of a Go+ string literal are replaced in its parts.
+	// (ast.Inspect doesn't support MatrixLit.)
+	var parts []any
+	astutil.Apply(f, func(c *astutil.Cursor) bool {
+		if lit, ok := c.Node().(*ast.BasicLit); ok && lit.Extra != nil {
+			parts = lit.Extra.Parts
+		}
+		return parts == nil
+	}, nil)
+	if len(parts) != 3 || parts[0] != "a" || parts[2] != "b" {
+		t.Fatalf("unexpected parts of the string literal: %v", parts)
+	}
+	if id, ok := parts[1].(*ast.Ident); !ok || id.Name != "m" {
+		t.Errorf("part 1 of the string literal = %v, want m", parts[1])
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil
+
+import (
+	"strings"
+
+	"github.com/goplus/gop/ast"
+)
+
+// StringLitValue returns the Value of a Go+ string literal made of parts,
+// the Parts of its Extra: raw strings and embedded expressions, such as
+// "Hello, ${name}!". format returns the source of an embedded expression.
+//
+// The printer prints a Go+ string literal from its Value, so the Value of
+// a literal whose parts were rewritten must be recomputed.
+func StringLitValue(parts []any, format func(ast.Expr) string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, part := range parts {
+		switch part := part.(type) {
+		case string:
+			b.WriteString(part)
+		case ast.Expr:
+			b.WriteString("${")
+			b.WriteString(format(part))
+			b.WriteByte('}')
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
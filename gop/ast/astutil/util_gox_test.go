@@ -0,0 +1,20 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil_test
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/gop/ast/astutil"
+)
+
+func TestStringLitValue(t *testing.T) {
+	parts := []any{"Hello, ", &ast.Ident{Name: "name"}, "!\\n"}
+	format := func(x ast.Expr) string { return "<" + x.(*ast.Ident).Name + ">" }
+	if got, want := astutil.StringLitValue(parts, format), `"Hello, ${<name>}!\n"`; got != want {
+		t.Errorf("StringLitValue = %s, want %s", got, want)
+	}
+}
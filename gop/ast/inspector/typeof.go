@@ -72,6 +72,18 @@ const (
 	nTypeSwitchStmt
 	nUnaryExpr
 	nValueSpec
+
+	// Go+ extended nodes. Only 64 node types fit in a mask, so the rarely
+	// filtered ones (EnvExpr, ElemEllipsis, MatrixLit, OverloadFuncDecl)
+	// have none and are only visited by unfiltered traversals.
+	nComprehensionExpr
+	nErrWrapExpr
+	nForPhrase
+	nForPhraseStmt
+	nLambdaExpr
+	nLambdaExpr2
+	nRangeExpr
+	nSliceLit
 )
 
 // typeOf returns a distinct single-bit value that represents the type of n.
@@ -212,6 +224,22 @@ func typeOf(n ast.Node) uint64 {
 		return 1 << nUnaryExpr
 	case *ast.ValueSpec:
 		return 1 << nValueSpec
+	case *ast.ComprehensionExpr:
+		return 1 << nComprehensionExpr
+	case *ast.ErrWrapExpr:
+		return 1 << nErrWrapExpr
+	case *ast.ForPhrase:
+		return 1 << nForPhrase
+	case *ast.ForPhraseStmt:
+		return 1 << nForPhraseStmt
+	case *ast.LambdaExpr:
+		return 1 << nLambdaExpr
+	case *ast.LambdaExpr2:
+		return 1 << nLambdaExpr2
+	case *ast.RangeExpr:
+		return 1 << nRangeExpr
+	case *ast.SliceLit:
+		return 1 << nSliceLit
 	}
 	return 0
 }
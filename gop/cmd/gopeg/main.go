@@ -0,0 +1,207 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The gopeg command performs example-based refactoring of Go+ code.
+// For documentation, run the command, or see Help in
+// golang.org/x/tools/gop/refactor/eg.
+package main // import "golang.org/x/tools/gop/cmd/gopeg"
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/gogen/packages"
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	exec "golang.org/x/sys/execabs"
+	goppackages "golang.org/x/tools/gop/packages"
+	"golang.org/x/tools/gop/refactor/eg"
+)
+
+var (
+	beforeeditFlag = flag.String("beforeedit", "", "A command to exec before each file is edited (e.g. chmod, checkout).  Whitespace delimits argument words.  The string '{}' is replaced by the file name.")
+	helpFlag       = flag.Bool("help", false, "show detailed help message")
+	templateFlag   = flag.String("t", "", "template.gop (or template.go) file specifying the refactoring")
+	transitiveFlag = flag.Bool("transitive", false, "apply refactoring to all dependencies too")
+	writeFlag      = flag.Bool("w", false, "rewrite input files in place (by default, the results are printed to standard output)")
+	verboseFlag    = flag.Bool("v", false, "show verbose matcher diagnostics")
+)
+
+const usage = `gopeg: an example-based refactoring tool for Go+.
+
+Usage: gopeg -t template.gop [-w] [-transitive] <packages>
+
+-help            show detailed help message
+-t template.gop	 specifies the template file (use -help to see explanation)
+-w          	 causes files to be re-written in place.
+-transitive 	 causes all dependencies to be refactored too.
+-v               show verbose matcher diagnostics
+-beforeedit cmd  a command to exec before each file is modified.
+                 "{}" represents the name of the file.
+`
+
+func main() {
+	if err := doMain(); err != nil {
+		fmt.Fprintf(os.Stderr, "gopeg: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func doMain() error {
+	flag.Parse()
+	args := flag.Args()
+
+	if *helpFlag {
+		help := eg.Help // hide %s from vet
+		fmt.Fprint(os.Stderr, help)
+		os.Exit(2)
+	}
+
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	if *templateFlag == "" {
+		return fmt.Errorf("no -t template.gop file specified")
+	}
+
+	tAbs, err := filepath.Abs(*templateFlag)
+	if err != nil {
+		return err
+	}
+	template, err := os.ReadFile(tAbs)
+	if err != nil {
+		return err
+	}
+
+	cfg := &goppackages.Config{
+		Fset:  token.NewFileSet(),
+		Mode:  goppackages.NeedTypesInfo | goppackages.NeedName | goppackages.NeedTypes | goppackages.NeedSyntax | goppackages.NeedImports | goppackages.NeedDeps | goppackages.NeedCompiledGoFiles,
+		Tests: true,
+	}
+
+	pkgs, err := goppackages.LoadEx(nil, cfg, args...)
+	if err != nil {
+		return err
+	}
+
+	tFile, err := parser.ParseFile(cfg.Fset, tAbs, template, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	// Type-check the template.
+	tInfo := typesutil.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Overloads:  make(map[*ast.Ident]types.Object),
+	}
+	conf := typesutil.CheckConfig{
+		Importer: &pkgsImporter{pkgs, packages.NewImporter(cfg.Fset)},
+	}
+	tPkg, err := conf.Check("egtemplate", cfg.Fset, []*ast.File{tFile}, &tInfo)
+	if err != nil {
+		return err
+	}
+
+	// Analyze the template.
+	xform, err := eg.NewTransformer(cfg.Fset, tPkg, tFile, &tInfo, *verboseFlag)
+	if err != nil {
+		return err
+	}
+
+	// Apply it to the input packages.
+	var all []*goppackages.Package
+	if *transitiveFlag {
+		goppackages.Visit(pkgs, nil, func(p *goppackages.Package) { all = append(all, p) })
+	} else {
+		all = pkgs
+	}
+	var hadErrors bool
+	seen := make(map[string]bool) // the files of a package are also in its test variant
+	for _, pkg := range all {
+		// Only the Go+ files are refactored: gop_autogen.go is regenerated
+		// from them.
+		for _, file := range pkg.GopSyntax {
+			filename := cfg.Fset.File(file.Pos()).Name()
+			if filename == tAbs {
+				// Don't rewrite the template file.
+				continue
+			}
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+			n := xform.Transform(pkg.GopTypesInfo, pkg.Types, file)
+			if n == 0 {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "=== %s (%d matches)\n", filename, n)
+			if *writeFlag {
+				// Run the before-edit command (e.g. "chmod +w",  "checkout") if any.
+				if *beforeeditFlag != "" {
+					args := strings.Fields(*beforeeditFlag)
+					// Replace "{}" with the filename, like find(1).
+					for i := range args {
+						if i > 0 {
+							args[i] = strings.Replace(args[i], "{}", filename, -1)
+						}
+					}
+					cmd := exec.Command(args[0], args[1:]...)
+					cmd.Stdout = os.Stdout
+					cmd.Stderr = os.Stderr
+					if err := cmd.Run(); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: edit hook %q failed (%s)\n",
+							args, err)
+					}
+				}
+				if err := eg.WriteAST(cfg.Fset, filename, file); err != nil {
+					fmt.Fprintf(os.Stderr, "gopeg: %s\n", err)
+					hadErrors = true
+				}
+			} else if err := format.Node(os.Stdout, cfg.Fset, file); err != nil {
+				fmt.Fprintf(os.Stderr, "gopeg: %s: %s\n", filename, err)
+				hadErrors = true
+			}
+		}
+	}
+	if hadErrors {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// pkgsImporter resolves the imports of the template from the loaded
+// packages, and the ones they don't have by gop.
+type pkgsImporter struct {
+	pkgs []*goppackages.Package
+	gop  types.Importer
+}
+
+func (p *pkgsImporter) Import(path string) (tpkg *types.Package, err error) {
+	goppackages.Visit(p.pkgs, func(pkg *goppackages.Package) bool {
+		if pkg.PkgPath == path {
+			tpkg = pkg.Types
+			return false
+		}
+		return true
+	}, nil)
+	if tpkg != nil {
+		return tpkg, nil
+	}
+	return p.gop.Import(path)
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The gopvet command runs all the Go+ analyzers over the specified
+// packages, such as:
+//
+//	$ gopvet ./...
+//	$ gopvet -fix ./...
+//
+// The analyzers that are off by default in gopls, gopFieldalignment,
+// gopLambdaparams, gopNilness, gopShadow and gopUnusedwrite, are disabled by
+// default too: they run only when enabled by their flag, such as:
+//
+//	$ gopvet -gopShadow ./...
+//
+// Run 'gopvet help' for the list of analyzers and their flags.
+//
+// gopvet can also be run by go vet:
+//
+//	$ go vet -vettool=$(which gopvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/multichecker"
+
+	"golang.org/x/tools/go/analysis/passes/asmdecl"
+	"golang.org/x/tools/go/analysis/passes/cgocall"
+	"golang.org/x/tools/go/analysis/passes/framepointer"
+	"golang.org/x/tools/gop/analysis/passes/appends"
+	"golang.org/x/tools/gop/analysis/passes/assign"
+	"golang.org/x/tools/gop/analysis/passes/atomic"
+	"golang.org/x/tools/gop/analysis/passes/atomicalign"
+	"golang.org/x/tools/gop/analysis/passes/bools"
+	"golang.org/x/tools/gop/analysis/passes/buildtag"
+	"golang.org/x/tools/gop/analysis/passes/classshadow"
+	"golang.org/x/tools/gop/analysis/passes/composite"
+	"golang.org/x/tools/gop/analysis/passes/copylock"
+	"golang.org/x/tools/gop/analysis/passes/deepequalerrors"
+	"golang.org/x/tools/gop/analysis/passes/defers"
+	"golang.org/x/tools/gop/analysis/passes/directive"
+	"golang.org/x/tools/gop/analysis/passes/errorsas"
+	"golang.org/x/tools/gop/analysis/passes/errwrap"
+	"golang.org/x/tools/gop/analysis/passes/fieldalignment"
+	"golang.org/x/tools/gop/analysis/passes/httpresponse"
+	"golang.org/x/tools/gop/analysis/passes/ifaceassert"
+	"golang.org/x/tools/gop/analysis/passes/lambdaparams"
+	"golang.org/x/tools/gop/analysis/passes/loopclosure"
+	"golang.org/x/tools/gop/analysis/passes/lostcancel"
+	"golang.org/x/tools/gop/analysis/passes/nilfunc"
+	"golang.org/x/tools/gop/analysis/passes/nilness"
+	"golang.org/x/tools/gop/analysis/passes/overload"
+	"golang.org/x/tools/gop/analysis/passes/printf"
+	"golang.org/x/tools/gop/analysis/passes/reflectvaluecompare"
+	"golang.org/x/tools/gop/analysis/passes/shadow"
+	"golang.org/x/tools/gop/analysis/passes/shift"
+	"golang.org/x/tools/gop/analysis/passes/sigchanyzer"
+	"golang.org/x/tools/gop/analysis/passes/slog"
+	"golang.org/x/tools/gop/analysis/passes/sortslice"
+	"golang.org/x/tools/gop/analysis/passes/stdmethods"
+	"golang.org/x/tools/gop/analysis/passes/stringintconv"
+	"golang.org/x/tools/gop/analysis/passes/structtag"
+	"golang.org/x/tools/gop/analysis/passes/testinggoroutine"
+	"golang.org/x/tools/gop/analysis/passes/tests"
+	"golang.org/x/tools/gop/analysis/passes/timeformat"
+	"golang.org/x/tools/gop/analysis/passes/unmarshal"
+	"golang.org/x/tools/gop/analysis/passes/unreachable"
+	"golang.org/x/tools/gop/analysis/passes/unsafeptr"
+	"golang.org/x/tools/gop/analysis/passes/unusedresult"
+	"golang.org/x/tools/gop/analysis/passes/unusedwrite"
+)
+
+func main() {
+	disabled := []analysis.IAnalyzer{
+		fieldalignment.Analyzer,
+		lambdaparams.Analyzer,
+		nilness.Analyzer,
+		shadow.Analyzer,
+		unusedwrite.Analyzer,
+	}
+	multichecker.MainWithDisabled(disabled,
+		appends.Analyzer,
+		asmdecl.Analyzer,
+		assign.Analyzer,
+		atomic.Analyzer,
+		atomicalign.Analyzer,
+		bools.Analyzer,
+		buildtag.Analyzer,
+		classshadow.Analyzer,
+		cgocall.Analyzer,
+		composite.Analyzer,
+		copylock.Analyzer,
+		deepequalerrors.Analyzer,
+		defers.Analyzer,
+		directive.Analyzer,
+		errorsas.Analyzer,
+		errwrap.Analyzer,
+		framepointer.Analyzer,
+		httpresponse.Analyzer,
+		ifaceassert.Analyzer,
+		loopclosure.Analyzer,
+		lostcancel.Analyzer,
+		nilfunc.Analyzer,
+		overload.Analyzer,
+		printf.Analyzer,
+		reflectvaluecompare.Analyzer,
+		shift.Analyzer,
+		sigchanyzer.Analyzer,
+		slog.Analyzer,
+		sortslice.Analyzer,
+		stdmethods.Analyzer,
+		stringintconv.Analyzer,
+		structtag.Analyzer,
+		tests.Analyzer,
+		testinggoroutine.Analyzer,
+		timeformat.Analyzer,
+		unmarshal.Analyzer,
+		unreachable.Analyzer,
+		unsafeptr.Analyzer,
+		unusedresult.Analyzer,
+	)
+}
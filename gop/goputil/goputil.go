@@ -4,6 +4,13 @@
 
 package goputil
 
+import (
+	"path"
+	"strings"
+
+	"github.com/goplus/mod/gopmod"
+)
+
 type Kind int
 
 const (
@@ -12,6 +19,8 @@ const (
 	FileGopClass
 )
 
+// FileKind returns the kind of a file with the extension fext. Only the
+// builtin extensions are recognized, see FileKindOf.
 func FileKind(fext string) Kind {
 	switch fext {
 	case ".gop":
@@ -22,6 +31,73 @@ func FileKind(fext string) Kind {
 	return FileUnknown
 }
 
+// FileKindOf returns the kind of the file fname in the Go+ module mod.
+// Besides the builtin extensions, it recognizes the classfiles registered by
+// mod (the project and class directives of gop.mod and the classfile modules
+// it registers). mod may be nil.
+func FileKindOf(mod *gopmod.Module, fname string) Kind {
+	fext := path.Ext(fname)
+	if fext == ".gop" {
+		return FileGopNormal
+	}
+	if mod != nil {
+		if _, ok := mod.ClassKind(fname); ok {
+			return FileGopClass
+		}
+	}
+	return FileKind(fext)
+}
+
+// Exts returns the builtin Go+ file extensions, without leading dots, as a
+// comma-separated list.
 func Exts() string {
 	return "gop,spx,rdx,gox,gmx"
 }
+
+// ExtsOf returns the Go+ file extensions, without leading dots, as a
+// comma-separated list: the builtin ones followed by those of the classfile
+// extensions classExts, eg. "gox" for "_yap.gox".
+func ExtsOf(classExts []string) string {
+	exts := Exts()
+	seen := make(map[string]bool)
+	for _, ext := range strings.Split(exts, ",") {
+		seen[ext] = true
+	}
+	for _, classExt := range classExts {
+		ext := strings.TrimPrefix(path.Ext(classExt), ".")
+		if ext != "" && !seen[ext] {
+			seen[ext] = true
+			exts += "," + ext
+		}
+	}
+	return exts
+}
+
+// ClassExts appends the classfile extensions of the project c to exts and
+// returns the extended list.
+func ClassExts(exts []string, c *gopmod.Project) []string {
+	exts = append(exts, c.Ext)
+	for _, w := range c.Works {
+		if w.Ext != c.Ext {
+			exts = append(exts, w.Ext)
+		}
+	}
+	return exts
+}
+
+// LoadMod loads the Go+ module of the directory dir and imports its
+// classfiles. It also returns their extensions, see ClassExts. If dir is not
+// in a module, gopmod.Default is returned.
+func LoadMod(dir string) (mod *gopmod.Module, classExts []string, err error) {
+	mod, err = gopmod.Load(dir)
+	if err != nil {
+		if !gopmod.IsNotFound(err) {
+			return
+		}
+		mod = gopmod.Default
+	}
+	err = mod.ImportClasses(func(c *gopmod.Project) {
+		classExts = ClassExts(classExts, c)
+	})
+	return
+}
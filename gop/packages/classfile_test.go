@@ -0,0 +1,72 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gop/packages"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestGopClassfiles(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	setGopRoot(t)
+
+	files := map[string]string{
+		"go.mod":             "module example.com/m\n\ngo 1.18\n",
+		"b/Rect.foo":         "func Area() int {\n\treturn 0\n}\n",
+		"b/main.gsh":         "echo \"hi\"\n",
+		"b/README.md":        "# b\n",
+		"b/gop_autogen.go":   "package main\n",
+		"b/data_test.txt":    "data\n",
+		"b/c/a.gop":          "package c\n",
+		"b/c/gop_autogen.go": "package c\n",
+	}
+	for _, test := range []struct {
+		name   string
+		gopMod string // "" for none
+		want   []string
+		err    string // a substring of the package error, if any
+	}{
+		{"registered", "gop 1.1\n\nproject .foo Foo fmt\n", []string{"Rect.foo", "main.gsh"}, ""},
+		{"unregistered", "", []string{"main.gsh"}, ""}, // .gsh is a builtin classfile
+		{"broken gop.mod", "gop 1.1\n\nproject\n", nil, "loading the Go+ module"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if test.gopMod != "" {
+				files["gop.mod"] = test.gopMod
+			} else {
+				delete(files, "gop.mod")
+			}
+			dir := writeFiles(t, files)
+			cfg := &packages.Config{Dir: dir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles}
+			pkgs, err := packages.Load(cfg, "./b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pkgs) != 1 {
+				t.Fatalf("got %d packages, want 1", len(pkgs))
+			}
+			pkg := pkgs[0]
+			var got []string
+			for _, file := range pkg.GopFiles {
+				got = append(got, filepath.Base(file))
+			}
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("GopFiles = %v, want %v", got, test.want)
+			}
+			var errs []string
+			for _, err := range pkg.Errors {
+				errs = append(errs, err.Msg)
+			}
+			if msg := strings.Join(errs, "\n"); test.err == "" && msg != "" || !strings.Contains(msg, test.err) {
+				t.Errorf("package errors %q, want an error containing %q", msg, test.err)
+			}
+		})
+	}
+}
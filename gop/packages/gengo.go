@@ -6,11 +6,23 @@ package packages
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
+	"sync"
 
+	"github.com/goplus/gogen"
+	"github.com/goplus/gop"
 	"github.com/goplus/gop/env"
+	"github.com/goplus/gop/scanner"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/gopprojs"
+	modenv "github.com/goplus/mod/env"
+	qerrors "github.com/qiniu/x/errors"
 	"golang.org/x/tools/gop/langserver"
 )
 
@@ -73,3 +85,212 @@ func buildPattern(pattern []string) (gopPattern []string, allPattern []string) {
 	}
 	return
 }
+
+// GenGoMode specifies how the gop_autogen.go files of the Go+ packages are
+// generated before they are loaded.
+type GenGoMode int
+
+const (
+	// GenGoDaemon asks the `gop serve` daemon to generate the files. It is
+	// skipped if gop isn't installed.
+	GenGoDaemon GenGoMode = iota
+
+	// GenGoInProcess generates the files in the current process with the gop
+	// compiler packages, which doesn't need an installed gop binary. The
+	// errors of the generation are reported in the Errors of the packages.
+	GenGoInProcess
+)
+
+// genGoInProcess generates gop_autogen.go files for the Go+ packages matching
+// patternIn in the current process. Relative directories are resolved from
+// dir. It returns the patterns to load and the errors of the generation,
+// keyed by package directory. The errors of the patterns themselves, which
+// belong to no package directory, are keyed by "".
+func genGoInProcess(dir string, patternIn []string) (patternOut []string, errs map[string][]Error) {
+	pattern, patternOut := buildPattern(patternIn)
+	for _, v := range patternIn {
+		if v == "." || v == ".." { // buildPattern only selects patterns with a slash
+			pattern = append(pattern, v)
+		}
+	}
+	if debugVerbose {
+		log.Println("genGoInProcess:", pattern, "in:", patternIn, "out:", patternOut)
+	}
+	if len(pattern) == 0 {
+		return
+	}
+	errs = make(map[string][]Error)
+	projs, err := gopprojs.ParseAll(pattern...)
+	if err != nil {
+		errs[""] = append(errs[""], Error{
+			Pos:  "-",
+			Msg:  fmt.Sprintf("generating Go+ packages %s: %v", strings.Join(pattern, " "), err),
+			Kind: ListError,
+		})
+		return
+	}
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	for _, proj := range projs {
+		switch v := proj.(type) {
+		case *gopprojs.DirProj:
+			pkgDir := v.Dir
+			if !filepath.IsAbs(pkgDir) {
+				pkgDir = filepath.Join(dir, pkgDir)
+			}
+			root := strings.TrimSuffix(pkgDir, "/...")
+			conf, err := newGenGoConf(root)
+			if err != nil {
+				addGenGoErrors(errs, root, "", err)
+				continue
+			}
+			_, _, err = gop.GenGoEx(pkgDir, conf, true, 0)
+			conf.UpdateCache()
+			addGenGoErrors(errs, root, conf.Mod.Root(), err)
+		case *gopprojs.PkgPathProj:
+			if v.Path == "builtin" {
+				continue
+			}
+			conf, err := newGenGoConf(dir)
+			if err != nil {
+				addGenGoErrors(errs, dir, "", err)
+				continue
+			}
+			pkgDir, _, err := gop.GenGoPkgPathEx(dir, v.Path, conf, true, 0)
+			conf.UpdateCache()
+			if pkgDir == "" {
+				pkgDir = dir
+			}
+			addGenGoErrors(errs, pkgDir, conf.Mod.Root(), err)
+		}
+	}
+	return
+}
+
+// newGenGoConf returns the configuration of the gop compiler for the module
+// of dir. Unlike gop.NewDefaultConf, it doesn't panic if GOPROOT isn't found.
+func newGenGoConf(dir string) (*gop.Config, error) {
+	mod, err := gop.LoadMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := gopRoot()
+	if err != nil {
+		return nil, err
+	}
+	gopEnv := &modenv.Gop{Version: env.Version(), BuildDate: env.BuildDate(), Root: root}
+	fset := token.NewFileSet()
+	imp := gop.NewImporter(mod, gopEnv, fset)
+	imp.Flags = 0 // don't print to stderr
+	conf := &gop.Config{Gop: gopEnv, Fset: fset, Mod: mod, Importer: imp}
+	conf.CacheFile = imp.CacheFile()
+	imp.Cache().Load(conf.CacheFile)
+	return conf, nil
+}
+
+var (
+	gopRootOnce sync.Once
+	gopRootDir  string
+	gopRootErr  error
+)
+
+// gopRoot returns the root of the Go+ tree, which provides the builtin
+// packages of Go+. If GOPROOT isn't set and gop isn't installed, it is the
+// directory of the gop module this program is built with.
+func gopRoot() (string, error) {
+	gopRootOnce.Do(func() {
+		func() {
+			defer func() {
+				if e := recover(); e != nil { // env.GOPROOT panics if it isn't found
+					gopRootErr = fmt.Errorf("%s", strings.TrimSpace(fmt.Sprint(e)))
+				}
+			}()
+			gopRootDir = env.GOPROOT()
+		}()
+		if gopRootErr != nil {
+			if dir := gopModDir(); dir != "" {
+				gopRootDir, gopRootErr = dir, nil
+			}
+		}
+	})
+	return gopRootDir, gopRootErr
+}
+
+// gopModDir returns the directory of the gop module in the module cache, if
+// this program is built with it.
+func gopModDir() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "github.com/goplus/gop" {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if filepath.IsAbs(dep.Path) { // replaced by a local directory
+			return dep.Path
+		}
+		out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+		if err != nil {
+			return ""
+		}
+		dir := filepath.Join(strings.TrimSpace(string(out)), dep.Path+"@"+dep.Version)
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+			return ""
+		}
+		return dir
+	}
+	return ""
+}
+
+// addGenGoErrors adds the errors of err, an error of the generation of the
+// Go+ packages of dir, to errs. An error with a position is added to the
+// directory of its file. The relative file names of positions are relative
+// to base.
+func addGenGoErrors(errs map[string][]Error, dir, base string, err error) {
+	add := func(pos token.Position, msg string, kind ErrorKind) {
+		posDir, posStr := dir, "-"
+		if pos.IsValid() {
+			if !filepath.IsAbs(pos.Filename) {
+				pos.Filename = filepath.Join(base, pos.Filename)
+			}
+			posDir, posStr = filepath.Dir(pos.Filename), pos.String()
+		}
+		errs[posDir] = append(errs[posDir], Error{Pos: posStr, Msg: msg, Kind: kind})
+	}
+	switch e := err.(type) {
+	case nil:
+	case qerrors.List:
+		for _, err := range e {
+			addGenGoErrors(errs, dir, base, err)
+		}
+	case scanner.ErrorList:
+		for _, err := range e {
+			add(err.Pos, err.Msg, ParseError)
+		}
+	case *scanner.Error:
+		add(e.Pos, e.Msg, ParseError)
+	case *gogen.CodeError:
+		add(e.Fset.Position(e.Pos), e.Msg, TypeError)
+	case *gogen.MatchError:
+		add(e.Fset.Position(e.Pos()), e.Message(""), TypeError)
+	case *gogen.ImportError:
+		var pos token.Position
+		if e.Pos.IsValid() {
+			pos = e.Fset.Position(e.Pos)
+		}
+		add(pos, e.Err.Error(), TypeError)
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			addGenGoErrors(errs, dir, base, inner)
+		} else {
+			add(token.Position{}, qerrors.Summary(err), UnknownError)
+		}
+	default:
+		add(token.Position{}, err.Error(), UnknownError)
+	}
+}
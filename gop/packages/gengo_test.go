@@ -0,0 +1,121 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gop/packages"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestGenGoInProcess(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	setGopRoot(t)
+
+	dir := writeFiles(t, map[string]string{
+		"go.mod":    "module example.com/m\n\ngo 1.18\n",
+		"ok/a.gop":  "package ok\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"bad/b.gop": "package bad\n\nfunc F() int {\n\treturn undefinedName\n}\n",
+		"bad/b_.go": "package bad\n",
+	})
+
+	gop := &packages.GopConfig{GenGo: packages.GenGoInProcess}
+	cfg := &packages.Config{Dir: dir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles}
+	pkgs, err := packages.LoadEx(gop, cfg, "./ok", "./bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 2 {
+		t.Fatalf("got %d packages, want 2", len(pkgs))
+	}
+
+	ok, bad := pkgs[0], pkgs[1]
+	if ok.PkgPath != "example.com/m/ok" {
+		ok, bad = bad, ok
+	}
+	if len(ok.Errors) > 0 {
+		t.Errorf("unexpected errors for package ok: %v", ok.Errors)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ok", "gop_autogen.go")); err != nil {
+		t.Errorf("gop_autogen.go of package ok was not generated: %v", err)
+	}
+	if len(ok.GopFiles) != 1 {
+		t.Errorf("got GopFiles %v for package ok, want a.gop", ok.GopFiles)
+	}
+
+	var found bool
+	for _, err := range bad.Errors {
+		if strings.Contains(err.Pos, "b.gop:4") && strings.Contains(err.Msg, "undefinedName") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("got errors %v for package bad, want an error about undefinedName in b.gop:4", bad.Errors)
+	}
+}
+
+// setGopRoot sets GOPROOT, if it isn't set, to the directory of the gop
+// module: a test binary has no build info to find it.
+func setGopRoot(t *testing.T) {
+	if os.Getenv("GOPROOT") != "" {
+		return
+	}
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/goplus/gop").Output()
+	if err != nil {
+		t.Skipf("can't find the gop module: %v", err)
+	}
+	t.Setenv("GOPROOT", strings.TrimSpace(string(out)))
+}
+
+// writeFiles writes files to a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenGoInProcessPatternError(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	setGopRoot(t)
+
+	dir := writeFiles(t, map[string]string{
+		"go.mod":   "module example.com/m\n\ngo 1.18\n",
+		"ok/a.gop": "package ok\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"ok/a_.go": "package ok\n",
+	})
+
+	// gop takes a pattern with an extension for a file, which can't be mixed
+	// with packages.
+	gop := &packages.GopConfig{GenGo: packages.GenGoInProcess}
+	cfg := &packages.Config{Dir: dir, Mode: packages.NeedName | packages.NeedFiles}
+	pkgs, err := packages.LoadEx(gop, cfg, "./ok", "example.com/m/x.v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range pkgs {
+		var found bool
+		for _, err := range pkg.Errors {
+			if err.Kind == packages.ListError && strings.Contains(err.Msg, "mixed files project") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("got errors %v for package %s, want the error of the patterns", pkg.Errors, pkg.ID)
+		}
+	}
+}
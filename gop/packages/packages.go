@@ -6,6 +6,7 @@ package packages
 
 import (
 	"context"
+	"fmt"
 	goast "go/ast"
 	"go/types"
 	"log"
@@ -15,6 +16,7 @@ import (
 	"strings"
 	"sync"
 
+	gogenpkgs "github.com/goplus/gogen/packages"
 	"github.com/goplus/gop"
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/parser"
@@ -214,6 +216,11 @@ type GopConfig struct {
 	// Context is an opaque packages.Load context.
 	// Contexts are safe for concurrent use.
 	Context *Context
+
+	// GenGo specifies how the gop_autogen.go files of the Go+ packages
+	// matching the patterns are generated before loading.
+	// The zero value is GenGoDaemon.
+	GenGo GenGoMode
 }
 
 // Load loads and returns the Go/Go+ packages named by the given patterns.
@@ -249,12 +256,17 @@ func Load(cfg *Config, patterns ...string) ([]*Package, error) {
 // proceeding with further analysis. The PrintErrors function is
 // provided for convenient display of all errors.
 func LoadEx(gop *GopConfig, cfg *Config, patterns ...string) ([]*Package, error) {
-	patterns, _ = GenGo(patterns...)
-
 	var conf Config
 	if cfg != nil {
 		conf = *cfg
 	}
+
+	var genErrs map[string][]Error
+	if gop != nil && gop.GenGo == GenGoInProcess {
+		patterns, genErrs = genGoInProcess(conf.Dir, patterns)
+	} else {
+		patterns, _ = GenGo(patterns...)
+	}
 	if conf.Fset == nil {
 		conf.Fset = token.NewFileSet()
 	}
@@ -291,9 +303,34 @@ func LoadEx(gop *GopConfig, cfg *Config, patterns ...string) ([]*Package, error)
 	for i, pkg := range pkgs {
 		ret[i] = pkgOf(pkgMap, pkg, ld, conf.Mode)
 	}
+	if len(genErrs) > 0 {
+		for _, pkg := range pkgMap {
+			if dir := pkgDir(pkg); dir != "" {
+				pkg.Errors = append(pkg.Errors, genErrs[dir]...)
+			}
+		}
+		for _, pkg := range ret { // errors of the patterns
+			pkg.Errors = append(pkg.Errors, genErrs[""]...)
+		}
+	}
 	return ret, nil
 }
 
+// pkgDir returns the directory of pkg, or "" if it is unknown.
+func pkgDir(pkg *Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles, pkg.IgnoredFiles} {
+		if len(files) > 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+	if mod := pkg.Module; mod != nil && mod.Dir != "" {
+		if rel := strings.TrimPrefix(pkg.PkgPath, mod.Path); rel != pkg.PkgPath && (rel == "" || rel[0] == '/') {
+			return filepath.Join(mod.Dir, filepath.FromSlash(rel))
+		}
+	}
+	return ""
+}
+
 func importPkgs(pkgMap map[*packages.Package]*Package, pkgs map[string]*packages.Package, ld *loader, mode LoadMode) map[string]*Package {
 	if len(pkgs) == 0 {
 		return nil
@@ -332,23 +369,73 @@ func pkgOf(pkgMap map[*packages.Package]*Package, pkg *packages.Package, ld *loa
 		return ret
 	}
 	ret := &Package{Package: *pkg, Imports: importPkgs(pkgMap, pkg.Imports, ld, mode)}
+	initGop(ret, ld, mode, (*importsOf)(ret))
+	pkgMap[pkg] = ret
+	return ret
+}
+
+// AddGopFiles adds the Go+ files to pkg, a Go+ package whose Go files,
+// including gop_autogen.go, were loaded by other means than Load, such as a
+// compilation unit of go vet. Its Name, Fset, CompiledGoFiles, Syntax, Types
+// and TypesInfo must be set. The Go+ files of its directory are parsed into
+// GopSyntax and type-checked into GopTypesInfo, resolving their imports with
+// imp, the importer of its Go files. It also sets CompiledNongenGoFiles and
+// NongenSyntax.
+func AddGopFiles(pkg *Package, imp types.Importer) {
+	ld := &loader{pkg.Fset, Default, parser.ParseEntry, nil, context.Background()}
+	initGop(pkg, ld, NeedSyntax|NeedTypes|NeedTypesInfo|NeedNongen, imp)
+}
+
+// initGop adds the Go+ files of ret, if any. deps resolves the imports of its
+// Go files.
+func initGop(ret *Package, ld *loader, mode LoadMode, deps types.Importer) {
 	needNongen := (mode & NeedNongen) != 0
 	if needNongen {
-		ret.CompiledNongenGoFiles = pkg.CompiledGoFiles
-		ret.NongenSyntax = pkg.Syntax
+		ret.CompiledNongenGoFiles = ret.CompiledGoFiles
+		ret.NongenSyntax = ret.Syntax
 	}
-	for i, file := range pkg.CompiledGoFiles {
+	for i, file := range ret.CompiledGoFiles {
 		dir, fname := filepath.Split(file)
 		if isAutogen(fname) { // has Go+ files
-			addGopFiles(ret, ld, dir, mode, isGoTestFile(fname) || hasGoTestFile(pkg.CompiledGoFiles[i+1:]))
+			test := isGoTestFile(fname) || hasGoTestFile(ret.CompiledGoFiles[i+1:])
+			addGopFiles(ret, ld, dir, mode, test, deps)
 			if needNongen {
 				initNongen(ret, i)
 			}
-			break
+			return
 		}
 	}
-	pkgMap[pkg] = ret
-	return ret
+	// A Go+ package whose gop_autogen.go isn't generated, e.g. because gop
+	// can't compile it, has no Go files: its Go+ files are its only files.
+	if dir, i := noGoFilesDir(ret); dir != "" {
+		goErrs := len(ret.Errors) > 1
+		addGopFiles(ret, ld, dir+string(filepath.Separator), mode, false, deps)
+		if len(ret.GopFiles) > 0 {
+			// As for the other Go+ packages, only the errors of the Go files
+			// make it ill-typed.
+			ret.Errors = append(ret.Errors[:i], ret.Errors[i+1:]...)
+			if mode&NeedTypes != 0 {
+				ret.IllTyped = goErrs
+			}
+		}
+	}
+}
+
+// noGoFilesDir returns the directory of ret and the index of its error if go
+// list reported that the directory has no Go files.
+func noGoFilesDir(ret *Package) (dir string, i int) {
+	if len(ret.CompiledGoFiles) > 0 {
+		return "", -1
+	}
+	for i, err := range ret.Errors {
+		if err.Kind != ListError {
+			continue
+		}
+		if _, dir, ok := strings.Cut(err.Msg, "no Go files in "); ok {
+			return dir, i
+		}
+	}
+	return "", -1
 }
 
 func hasGoTestFile(goFiles []string) bool {
@@ -384,7 +471,33 @@ func autogenFiles(ret *Package, test bool) []*goast.File {
 	return files
 }
 
-func addGopFiles(ret *Package, ld *loader, dir string, mode LoadMode, test bool) {
+// depsImporter resolves the imports of the Go+ files of a package from the
+// dependencies of its Go files, so that Go and Go+ files share the same
+// imported types. The imports of Go+ files that gop_autogen.go doesn't have
+// are resolved by gop.
+type depsImporter struct {
+	deps types.Importer
+	gop  types.Importer
+}
+
+func (p *depsImporter) Import(path string) (*types.Package, error) {
+	if pkg, err := p.deps.Import(path); err == nil {
+		return pkg, nil
+	}
+	return p.gop.Import(path)
+}
+
+// importsOf resolves imports from the dependencies of a loaded package.
+type importsOf Package
+
+func (p *importsOf) Import(path string) (*types.Package, error) {
+	if dep, ok := p.Imports[path]; ok && dep.Types != nil {
+		return dep.Types, nil
+	}
+	return nil, fmt.Errorf("package %s is not a dependency of %s", path, p.PkgPath)
+}
+
+func addGopFiles(ret *Package, ld *loader, dir string, mode LoadMode, test bool, deps types.Importer) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return
@@ -393,25 +506,52 @@ func addGopFiles(ret *Package, ld *loader, dir string, mode LoadMode, test bool)
 	pkgName := ret.Name
 	var mod *gopmod.Module
 	var once sync.Once
+	loadMod := func() *gopmod.Module {
+		once.Do(func() {
+			var err error
+			if mod, err = gop.LoadMod(dir); err != nil {
+				appendModError(ret, dir, err)
+			}
+		})
+		return mod
+	}
+	var hasClassMods bool
+	var classOnce sync.Once
 	for _, e := range entries {
 		fname := e.Name()
 		if strings.HasPrefix(fname, "_") {
 			continue
 		}
 		fext := path.Ext(fname)
-		if goputil.FileKind(fext) == goputil.FileUnknown {
+		if fext == "" || fext == ".go" {
 			continue
 		}
+		if goputil.FileKind(fext) == goputil.FileUnknown {
+			// The classfiles registered by gop.mod may have custom extensions,
+			// but importing the classfile modules is expensive: only do it if
+			// the extension is a builtin classfile one or gop.mod registers any.
+			if !isBuiltinClassExt(fext) {
+				classOnce.Do(func() {
+					var err error
+					if hasClassMods, err = gopHasClassMods(dir); err != nil {
+						appendModError(ret, dir, err)
+					}
+				})
+				if !hasClassMods {
+					continue
+				}
+			}
+			if goputil.FileKindOf(loadMod(), fname) == goputil.FileUnknown {
+				continue
+			}
+		}
 		if !test {
 			if strings.HasSuffix(fname[:len(fname)-len(fext)], "_test") {
 				continue
 			}
 			// check gox class test
 			if strings.HasSuffix(fname, "test.gox") {
-				once.Do(func() {
-					mod, _ = gop.LoadMod(dir)
-				})
-				if mod != nil {
+				if mod := loadMod(); mod != nil {
 					if _, ok := mod.ClassKind(fname); ok {
 						continue
 					}
@@ -420,15 +560,24 @@ func addGopFiles(ret *Package, ld *loader, dir string, mode LoadMode, test bool)
 		}
 		file := dir + fname
 		f, err := parser.ParseFile(fsetTemp, file, nil, parser.PackageClauseOnly)
-		if err == nil && pkgName == f.Name.Name {
+		if err == nil && (pkgName == "" || pkgName == f.Name.Name) {
+			pkgName = f.Name.Name
 			ret.GopFiles = append(ret.GopFiles, file)
 			ret.CompiledGopFiles = append(ret.CompiledGopFiles, file)
 			// goxls: todo - condition
 		}
 	}
+	if ret.Name == "" && len(ret.GopFiles) > 0 { // a package without Go files
+		ret.Name = pkgName
+		if ret.Types != nil {
+			ret.Types.SetName(pkgName)
+		}
+	}
 	if ld != nil && len(ret.CompiledGopFiles) > 0 {
 		ctx := ld.Context
-		mod := ctx.LoadMod(ret.Module)
+		if ret.Module != nil || mod == nil {
+			mod = ctx.LoadMod(ret.Module)
+		} // else pkg was not loaded by Load: use the module of dir
 		ret.GopSyntax = ld.parseFiles(ret, mod, ret.CompiledGopFiles)
 		if mode&(NeedTypes|NeedTypesInfo) != 0 {
 			ret.GopTypesInfo = &typesutil.Info{
@@ -442,7 +591,8 @@ func addGopFiles(ret *Package, ld *loader, dir string, mode LoadMode, test bool)
 				Overloads:  make(map[*ast.Ident]types.Object),
 			}
 			cfg := &types.Config{
-				Context: ctx.Types,
+				Context:  ctx.Types,
+				Importer: &depsImporter{deps, gogenpkgs.NewImporter(ld.Fset, dir)},
 				Error: func(err error) {
 					appendError(ret, err)
 				},
@@ -574,6 +724,47 @@ func sameFile(x, y string) bool {
 	return false
 }
 
+// isBuiltinClassExt reports whether ext is the extension of a classfile that
+// gop registers in every module, such as .gsh.
+func isBuiltinClassExt(ext string) bool {
+	for _, proj := range []*gopmod.Project{gopmod.SpxProject, gopmod.GshProject, gopmod.TestProject} {
+		if proj.Ext == ext {
+			return true
+		}
+		for _, work := range proj.Works {
+			if work.Ext == ext {
+				return true
+			}
+		}
+	}
+	return ext == ".gmx" // the old extension of spx projects
+}
+
+// gopHasClassMods reports whether the Go+ module of dir registers classfiles
+// of its own, which may have custom extensions. Unlike gop.LoadMod, it only
+// reads the go.mod and gop.mod files, without importing the classfile
+// modules.
+func gopHasClassMods(dir string) (bool, error) {
+	mod, err := gopmod.Load(dir)
+	if err != nil {
+		if gopmod.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return mod.Opt != nil && (len(mod.Opt.Projects) > 0 || len(mod.Opt.ClassMods) > 0), nil
+}
+
+// appendModError reports err, an error loading the Go+ module of dir, as an
+// error of ret.
+func appendModError(ret *Package, dir string, err error) {
+	ret.Errors = append(ret.Errors, Error{
+		Pos:  "-",
+		Msg:  fmt.Sprintf("loading the Go+ module of %s: %v", dir, err),
+		Kind: ListError,
+	})
+}
+
 func appendError(ret *Package, err error) {
 	switch err := err.(type) {
 	case Error:
@@ -0,0 +1,76 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/gop/token"
+)
+
+// GopPosition returns the position in the Go+ source files of the package of
+// pos, a position in one of its CompiledGoFiles, such as gop_autogen.go.
+// See GopPositionOf.
+func (p *Package) GopPosition(pos token.Pos) token.Position {
+	return GopPositionOf(p.Fset, pos, p.CompiledGopFiles)
+}
+
+// GopPositionOf returns the position in one of gopFiles of pos, a position in
+// fset of a Go file generated from them, such as gop_autogen.go.
+//
+// The mapping uses the //line directives the Go+ compiler writes before the
+// code of each declaration and statement of the Go+ files. They name the Go+
+// files relative to the module root, or to the working directory of the gop
+// daemon, while go/scanner resolves them relative to the directory of the
+// generated file, so the file of a directive is matched with gopFiles by its
+// relative path.
+//
+// If pos is not covered by a //line directive of gopFiles, such as in the
+// imports of a generated file, or in a Go file written by hand, GopPositionOf
+// returns the position of pos as fset.Position does.
+func GopPositionOf(fset *token.FileSet, pos token.Pos, gopFiles []string) token.Position {
+	tf := fset.File(pos)
+	if tf == nil {
+		return token.Position{}
+	}
+	adjusted := tf.PositionFor(pos, true)
+	unadjusted := tf.PositionFor(pos, false)
+	if adjusted.Filename == unadjusted.Filename {
+		return adjusted
+	}
+	if file := gopFileOf(adjusted.Filename, filepath.Dir(unadjusted.Filename), gopFiles); file != "" {
+		adjusted.Filename = file
+	}
+	return adjusted
+}
+
+// gopFileOf returns the file of gopFiles named filename by a //line
+// directive of a Go file of dir, or "" if there is none.
+func gopFileOf(filename, dir string, gopFiles []string) string {
+	for _, file := range gopFiles {
+		if file == filename {
+			return file
+		}
+	}
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil {
+		return ""
+	}
+	// A path relative to another directory ends with the absolute path of
+	// its file.
+	for strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = rel[3:]
+	}
+	if rel == ".." || rel == "." {
+		return ""
+	}
+	for _, file := range gopFiles {
+		if strings.HasSuffix(file, string(filepath.Separator)+rel) {
+			return file
+		}
+	}
+	return ""
+}
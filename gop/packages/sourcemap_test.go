@@ -0,0 +1,83 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages_test
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/token"
+
+	"golang.org/x/tools/gop/packages"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestGopPosition(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	setGopRoot(t)
+
+	dir := writeFiles(t, map[string]string{
+		"go.mod":     "module example.com/m\n\ngo 1.18\n",
+		"ok/a.gop":   "package ok\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"ok/b_go.go": "package ok\n\nfunc Sub(a, b int) int {\n\treturn a - b\n}\n",
+	})
+	gop := &packages.GopConfig{GenGo: packages.GenGoInProcess}
+	cfg := &packages.Config{Dir: dir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo}
+	pkgs, err := packages.LoadEx(gop, cfg, "./ok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 1 {
+		t.Fatalf("got %d packages, want 1", len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", pkg.Errors)
+	}
+
+	got := make(map[string]string) // Go file -> Go+ position of its return statement
+	for _, f := range pkg.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ret, ok := n.(*ast.ReturnStmt); ok {
+				file := filepath.Base(pkg.Fset.File(ret.Pos()).Name())
+				pos := pkg.GopPosition(ret.Pos())
+				got[file] = strings.TrimPrefix(filepath.ToSlash(pos.Filename), filepath.ToSlash(dir)+"/") + fmt.Sprintf(":%d", pos.Line)
+			}
+			return true
+		})
+	}
+	want := map[string]string{
+		"gop_autogen.go": "ok/a.gop:4",
+		"b_go.go":        "ok/b_go.go:4",
+	}
+	for file, pos := range want {
+		if got[file] != pos {
+			t.Errorf("GopPosition of the return statement of %s = %q, want %q", file, got[file], pos)
+		}
+	}
+}
+
+func TestGopPositionOf(t *testing.T) {
+	dir := filepath.FromSlash("/w/m/ok")
+	gopFiles := []string{filepath.Join(dir, "a.gop")}
+	for _, directive := range []string{
+		"ok/a.gop",           // relative to the module root, as gop go writes it
+		"../../w/m/ok/a.gop", // relative to the working directory of the gop daemon, /x/y
+	} {
+		fset := token.NewFileSet()
+		tf := fset.AddFile(filepath.Join(dir, "gop_autogen.go"), -1, 100)
+		tf.SetLines([]int{0, 10, 20, 30})
+		// go/scanner resolves the file of a //line directive like this.
+		tf.AddLineColumnInfo(20, filepath.Join(dir, filepath.FromSlash(directive)), 4, 1)
+
+		pos := packages.GopPositionOf(fset, tf.Pos(25), gopFiles)
+		if pos.Filename != gopFiles[0] || pos.Line != 4 || pos.Column != 6 {
+			t.Errorf("GopPositionOf with //line %s:4:1 = %v, want %s:4:6", directive, pos, gopFiles[0])
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// No testdata on Android.
+
+//go:build !android
+// +build !android
+
+package eg_test
+
+import (
+	"bytes"
+	"flag"
+	"go/constant"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gogen/packages"
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gop/refactor/eg"
+	"golang.org/x/tools/internal/testenv"
+)
+
+var (
+	updateFlag  = flag.Bool("update", false, "update the golden files")
+	verboseFlag = flag.Bool("verbose", false, "show matcher information")
+)
+
+func Test(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	switch runtime.GOOS {
+	case "windows":
+		t.Skipf("skipping test on %q (no /usr/bin/diff)", runtime.GOOS)
+	}
+
+	fset := token.NewFileSet()
+	conf := typesutil.CheckConfig{
+		Importer: packages.NewImporter(fset),
+	}
+
+	// Each entry is a single-file package.
+	// Order matters: each non-template package is processed using
+	// the preceding template package.
+	var xform *eg.Transformer
+	for _, filename := range []string{
+		"testdata/A.template",
+		"testdata/A1.gop",
+
+		"testdata/B.template",
+		"testdata/B1.gop",
+
+		"testdata/C.template",
+		"testdata/C1.gop",
+
+		"testdata/no_before.template",
+		"testdata/type_mismatch.template",
+		"testdata/lambda.template",
+	} {
+		file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := &typesutil.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Overloads:  make(map[*ast.Ident]types.Object),
+		}
+		pkgname := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+		pkg, err := conf.Check(pkgname, fset, []*ast.File{file}, info)
+		if err != nil {
+			t.Fatalf("%s: %v", filename, err)
+		}
+
+		if strings.HasSuffix(filename, "template") {
+			// a new template
+			shouldFail, _ := pkg.Scope().Lookup("shouldFail").(*types.Const)
+			xform, err = eg.NewTransformer(fset, pkg, file, info, *verboseFlag)
+			if err != nil {
+				if shouldFail == nil {
+					t.Errorf("NewTransformer(%s): %s", filename, err)
+				} else if want := constant.StringVal(shouldFail.Val()); !strings.Contains(err.Error(), want) {
+					t.Errorf("NewTransformer(%s): got error %q, want error %q", filename, err, want)
+				}
+			} else if shouldFail != nil {
+				t.Errorf("NewTransformer(%s) succeeded unexpectedly; want error %q",
+					filename, shouldFail.Val())
+			}
+			continue
+		}
+
+		if xform == nil {
+			t.Errorf("%s: no previous template", filename)
+			continue
+		}
+
+		// apply previous template to this package
+		n := xform.Transform(info, pkg, file)
+		if n == 0 {
+			t.Errorf("%s: no matches", filename)
+			continue
+		}
+
+		gotf, err := os.CreateTemp("", filepath.Base(filename)+"t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotf.Close()
+		got := gotf.Name()             // foo.gopt
+		golden := filename + ".golden" // foo.gop.golden
+
+		// Write actual output to foo.gopt.
+		if err := eg.WriteAST(fset, got, file); err != nil {
+			t.Error(err)
+		}
+		defer os.Remove(got)
+
+		// Compare foo.gopt with foo.gop.golden.
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "plan9":
+			cmd = exec.Command("/bin/diff", "-c", golden, got)
+		default:
+			cmd = exec.Command("/usr/bin/diff", "-u", golden, got)
+		}
+		buf := new(bytes.Buffer)
+		cmd.Stdout = buf
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			t.Errorf("eg tests for %s failed: %s.\n%s\n", filename, err, buf)
+
+			if *updateFlag {
+				t.Logf("Updating %s...", golden)
+				if err := exec.Command("/bin/cp", got, golden).Run(); err != nil {
+					t.Errorf("Update failed: %s", err)
+				}
+			}
+		}
+	}
+}
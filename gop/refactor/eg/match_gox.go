@@ -0,0 +1,82 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eg
+
+import (
+	"github.com/goplus/gop/ast"
+)
+
+// gopMatchExpr reports whether pattern x matches y, two Go+ extended
+// expressions of the same type. ok is false if x is not a Go+ extended
+// expression.
+func (tr *Transformer) gopMatchExpr(x, y ast.Expr) (match, ok bool) {
+	switch x := x.(type) {
+	case *ast.LambdaExpr, *ast.LambdaExpr2, *ast.ComprehensionExpr, *ast.ForPhrase:
+		// like func literals, lambdas and comprehensions (which introduce
+		// variables) never match.
+		return false, true
+
+	case *ast.SliceLit:
+		y := y.(*ast.SliceLit)
+		return tr.matchExprs(x.Elts, y.Elts), true
+
+	case *ast.MatrixLit:
+		y := y.(*ast.MatrixLit)
+		if len(x.Elts) != len(y.Elts) {
+			return false, true
+		}
+		for i := range x.Elts {
+			if !tr.matchExprs(x.Elts[i], y.Elts[i]) {
+				return false, true
+			}
+		}
+		return true, true
+
+	case *ast.ElemEllipsis:
+		y := y.(*ast.ElemEllipsis)
+		return tr.matchExpr(x.Elt, y.Elt), true
+
+	case *ast.ErrWrapExpr:
+		y := y.(*ast.ErrWrapExpr)
+		return x.Tok == y.Tok &&
+			tr.matchExpr(x.X, y.X) &&
+			tr.matchExpr(x.Default, y.Default), true
+
+	case *ast.RangeExpr:
+		y := y.(*ast.RangeExpr)
+		return x.Colon2.IsValid() == y.Colon2.IsValid() &&
+			tr.matchExpr(x.First, y.First) &&
+			tr.matchExpr(x.Last, y.Last) &&
+			tr.matchExpr(x.Expr3, y.Expr3), true
+
+	case *ast.EnvExpr:
+		y := y.(*ast.EnvExpr)
+		// ${name} denotes an environment variable, not an object.
+		return x.Name.Name == y.Name.Name, true
+	}
+	return false, false
+}
+
+// gopMatchStringLit reports whether the Go+ string literal x, which embeds
+// expressions such as "${a}, ${b}", matches y: their string parts must be
+// equal and their expressions must match.
+func (tr *Transformer) gopMatchStringLit(x, y *ast.BasicLit) bool {
+	if x.Extra == nil || y.Extra == nil || len(x.Extra.Parts) != len(y.Extra.Parts) {
+		return false
+	}
+	for i, xpart := range x.Extra.Parts {
+		switch xpart := xpart.(type) {
+		case string:
+			if ypart, ok := y.Extra.Parts[i].(string); !ok || xpart != ypart {
+				return false
+			}
+		case ast.Expr:
+			if ypart, ok := y.Extra.Parts[i].(ast.Expr); !ok || !tr.matchExpr(xpart, ypart) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -72,3 +72,14 @@ func GetClassType(file *ast.File, filename string) (classType string, ok bool) {
 	}
 	return
 }
+
+// GetTestName returns the name of the test function that gop generates for
+// a test classfile, eg. Test_foo for foo_test.gox. The project file of a test
+// classfile, eg. main_test.gox, runs the tests but is no test itself.
+func GetTestName(file *ast.File, filename string) (testName string, ok bool) {
+	if file.IsClass && !file.IsProj && strings.HasSuffix(filename, "test.gox") {
+		classType, _, _ := cl.ClassNameAndExt(filename)
+		return "Test" + testNameSuffix(classType), true
+	}
+	return
+}
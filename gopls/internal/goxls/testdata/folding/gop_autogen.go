@@ -0,0 +1,69 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const _ = true
+const Gopo_add = "addInt,addStr"
+//line a.gop:13:1
+func addInt(a int, b int) int {
+//line a.gop:14:1
+	return a + b
+}
+//line a.gop:17:1
+func addStr(a string, b string) string {
+//line a.gop:18:1
+	return a + b
+}
+//line a.gop:21:1
+func apply(fn func(int) int, v int) int {
+//line a.gop:22:1
+	return fn(v)
+}
+//line a.gop:25
+func main() {
+//line a.gop:25:1
+	squares := func() (_gop_ret []int) {
+		for
+//line a.gop:25:1
+		_, x := range []int{1, 2, 3} {
+//line a.gop:25:1
+			if x > 1 {
+//line a.gop:25:1
+				_gop_ret = append(_gop_ret, x*x)
+			}
+		}
+//line a.gop:25:1
+		return
+	}()
+//line a.gop:26:1
+	evens := func() (_gop_ret []int) {
+		for
+//line a.gop:26:1
+		_, x := range squares {
+//line a.gop:26:1
+			if x%2 == 0 {
+//line a.gop:26:1
+				_gop_ret = append(_gop_ret, x)
+			}
+		}
+//line a.gop:26:1
+		return
+	}()
+//line a.gop:27:1
+	fmt.Println(apply(func(x int) int {
+//line a.gop:28:1
+		return x * 2
+	}, 3), apply(func(x int) int {
+//line a.gop:27:1
+		return x + 1
+	}, 4))
+//line a.gop:31:1
+	fmt.Println("hello", strings.ToUpper("world"), addInt(1, 2))
+//line a.gop:34:1
+	fmt.Println(squares, evens)
+}
@@ -0,0 +1,24 @@
+package generics
+
+const GopPackage = true
+
+func Map[T, U any](s []T, f func(T) U) []U { //@Map,mark(MapLambda, "Map")
+	return nil
+}
+
+func Ident[T any](x T) T { //@Ident
+	return x
+}
+
+func First__0[T any](s []T) T { //@First__0
+	var x T
+	return x
+}
+
+func First__1[T any](a, b T) T { //@First__1
+	return a
+}
+
+func Max[T int | float64](a, b T) T {
+	return a
+}
@@ -0,0 +1,46 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package inlayhint
+
+import (
+	"fmt"
+	"strings"
+)
+
+const GopPackage = true
+const _ = true
+//line a.gop:7:1
+func sum(a int, b int) int {
+//line a.gop:8:1
+	return a + b
+}
+//line a.gop:11:1
+func main() {
+//line a.gop:12:1
+	p := Point{1, 2}
+//line a.gop:13:1
+	n := sum(p.X, p.Y)
+//line a.gop:14:1
+	fmt.Println(strings.Repeat("x", n))
+	for
+//line a.gop:15:1
+	i, v := range []int{1, 2} {
+//line a.gop:16:1
+		fmt.Println(i, v)
+	}
+//line a.gop:18:1
+	squares := func() (_gop_ret []int) {
+		for
+//line a.gop:18:1
+		_, x := range []int{1, 2, 3} {
+//line a.gop:18:1
+			_gop_ret = append(_gop_ret, x*x)
+		}
+//line a.gop:18:1
+		return
+	}()
+//line a.gop:19:1
+	fmt.Println(squares, Add__0(1, 2), Add__1("a", "b"))
+//line a.gop:20:1
+	Echo__1(3, 4)
+}
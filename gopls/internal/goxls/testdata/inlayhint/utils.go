@@ -0,0 +1,23 @@
+package inlayhint
+
+import "fmt"
+
+type Point struct {
+	X, Y int
+}
+
+func Add__0(a, b int) int {
+	return a + b
+}
+
+func Add__1(a, b string) string {
+	return a + b
+}
+
+func Echo__0(a int) {
+	fmt.Println(a)
+}
+
+func Echo__1(a, b int) {
+	fmt.Println(a, b)
+}
@@ -0,0 +1,36 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package main
+
+import "fmt"
+
+const _ = true
+//line a.gop:1:1
+func apply(fn func(int) int, v int) int {
+//line a.gop:2:1
+	return fn(v)
+}
+//line a.gop:5
+func main() {
+//line a.gop:5:1
+	squares := func() (_gop_ret []int) {
+		for
+//line a.gop:5:1
+		_, x := range []int{1, 2, 3} {
+//line a.gop:5:1
+			if x > 1 {
+//line a.gop:5:1
+				_gop_ret = append(_gop_ret, x*x)
+			}
+		}
+//line a.gop:5:1
+		return
+	}()
+//line a.gop:6:1
+	fmt.Println(apply(func(x int) int {
+//line a.gop:6:1
+		return x * 2
+	}, 3))
+//line a.gop:7:1
+	fmt.Println("hello", squares)
+}
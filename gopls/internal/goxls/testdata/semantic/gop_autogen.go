@@ -0,0 +1,66 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package semantic
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const GopPackage = true
+const _ = true
+const Gopo_add = "addInt,addStr"
+//line semantic.gop:10:1
+func addInt(a int, b int) int {
+//line semantic.gop:11:1
+	return a + b
+}
+//line semantic.gop:14:1
+func addStr(a string, b string) string {
+//line semantic.gop:15:1
+	return a + b
+}
+//line semantic.gop:18:1
+func twice(fn func(int) int, v int) int {
+//line semantic.gop:19:1
+	return fn(fn(v))
+}
+//line semantic.gop:22:1
+func demo() {
+//line semantic.gop:23:1
+	name := "world"
+//line semantic.gop:24:1
+	fmt.Println("hello", name, addInt(1, 2), addStr("a", "b"))
+//line semantic.gop:25:1
+	fmt.Println(twice(func(x int) int {
+//line semantic.gop:25:1
+		return x * 2
+	}, 3))
+//line semantic.gop:26:1
+	squares := func() (_gop_ret []int) {
+		for
+//line semantic.gop:26:1
+		_, x := range []int{1, 2, 3, 4} {
+//line semantic.gop:26:1
+			if x > 1 {
+//line semantic.gop:26:1
+				_gop_ret = append(_gop_ret, x*x)
+			}
+		}
+//line semantic.gop:26:1
+		return
+	}()
+//line semantic.gop:27:1
+	for
+//line semantic.gop:27:1
+	i := 1; i < 5;
+//line semantic.gop:27:1
+	i += 1 {
+//line semantic.gop:28:1
+		fmt.Println(i, squares)
+	}
+//line semantic.gop:30:1
+	n, _ := strconv.Atoi("12")
+//line semantic.gop:31:1
+	fmt.Println(n)
+}
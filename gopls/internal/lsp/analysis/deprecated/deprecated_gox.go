@@ -0,0 +1,252 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deprecated
+
+import (
+	"bytes"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/token"
+	goanalysis "golang.org/x/tools/go/analysis"
+	goinspector "golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/internal/typeparams"
+)
+
+var GopAnalyzer = &analysis.Analyzer{
+	Name:             "gopDeprecated",
+	Doc:              doc,
+	Run:              gopCheckDeprecated,
+	FactTypes:        []analysis.Fact{(*gopDeprecationFact)(nil)},
+	RunDespiteErrors: true,
+}
+
+// gopDeprecationFact is the Go+ counterpart of deprecationFact: a fact type
+// can't be shared by two analyzers. GopAnalyzer doesn't require Analyzer for
+// the same reason: the facts of an analyzer are shared with the ones it
+// requires.
+type gopDeprecationFact struct{ Msg string }
+
+func (*gopDeprecationFact) AFact()           {}
+func (d *gopDeprecationFact) String() string { return "Deprecated: " + d.Msg }
+
+// gopCheckDeprecated is the Go+ version of checkDeprecated.
+func gopCheckDeprecated(pass *analysis.Pass) (interface{}, error) {
+	deprs, err := gopCollectDeprecatedNames(pass)
+	if err != nil || len(pass.GopFiles) == 0 || (len(deprs.packages) == 0 && len(deprs.objects) == 0) {
+		return nil, err
+	}
+	info := pass.GopTypesInfo
+
+	reportDeprecation := func(depr *deprecationFact, node ast.Node) {
+		buf := new(bytes.Buffer)
+		if err := format.Node(buf, pass.Fset, node); err != nil {
+			// This shouldn't happen but let's be conservative.
+			buf.Reset()
+			buf.WriteString("declaration")
+		}
+		pass.ReportRangef(node, "%s is deprecated: %s", buf, depr.Msg)
+	}
+
+	for _, f := range pass.GopFiles {
+		ast.Inspect(f, func(node ast.Node) bool {
+			// Caveat: this misses dot-imported objects
+			sel, ok := node.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			obj := info.ObjectOf(sel.Sel)
+			if obj_, ok := obj.(*types.Func); ok {
+				obj = typeparams.OriginMethod(obj_)
+			}
+			if obj == nil || obj.Pkg() == nil {
+				// skip invalid sel.Sel.
+				return true
+			}
+
+			if obj.Pkg() == pass.Pkg {
+				// A package is allowed to use its own deprecated objects
+				return true
+			}
+
+			// See checkDeprecated for the relations between "foo",
+			// "foo_test" and "foo.test".
+			if strings.TrimSuffix(pass.Pkg.Path(), "_test") == obj.Pkg().Path() {
+				return true
+			}
+			if strings.TrimSuffix(pass.Pkg.Path(), ".test") == obj.Pkg().Path() {
+				return true
+			}
+			if strings.TrimSuffix(pass.Pkg.Path(), ".test") == strings.TrimSuffix(obj.Pkg().Path(), "_test") {
+				return true
+			}
+
+			if depr, ok := deprs.objects[obj]; ok {
+				reportDeprecation(depr, sel)
+			}
+			return true
+		})
+
+		for _, spec := range f.Imports {
+			var imp *types.Package
+			var obj types.Object
+			if spec.Name != nil {
+				obj = info.ObjectOf(spec.Name)
+			} else {
+				obj = info.Implicits[spec]
+			}
+			pkgName, ok := obj.(*types.PkgName)
+			if !ok {
+				continue
+			}
+			imp = pkgName.Imported()
+
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			pkgPath := pass.Pkg.Path()
+			if strings.TrimSuffix(pkgPath, "_test") == path {
+				// foo_test can import foo
+				continue
+			}
+			if strings.TrimSuffix(pkgPath, ".test") == path {
+				// foo.test can import foo
+				continue
+			}
+			if strings.TrimSuffix(pkgPath, ".test") == strings.TrimSuffix(path, "_test") {
+				// foo.test can import foo_test
+				continue
+			}
+			if depr, ok := deprs.packages[imp]; ok {
+				reportDeprecation(depr, spec.Path)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// gopCollectDeprecatedNames collects the deprecated identifiers declared in
+// both the Go and Go+ files of the package, and publishes them both as Facts
+// and the return value.
+func gopCollectDeprecatedNames(pass *analysis.Pass) (deprecatedNames, error) {
+	info := pass.GopTypesInfo
+
+	doDocs := func(names []*ast.Ident, docs *ast.CommentGroup) {
+		alt := gopExtractDeprecatedMessage([]*ast.CommentGroup{docs})
+		if alt == "" || info == nil {
+			return
+		}
+
+		for _, name := range names {
+			if obj := info.ObjectOf(name); obj != nil {
+				pass.ExportObjectFact(obj, &gopDeprecationFact{alt})
+			}
+		}
+	}
+
+	var docs []*ast.CommentGroup
+	for _, f := range pass.GopFiles {
+		docs = append(docs, f.Doc)
+	}
+	if alt := gopExtractDeprecatedMessage(docs); alt != "" {
+		pass.ExportPackageFact(&gopDeprecationFact{alt})
+	}
+	for _, f := range pass.GopFiles {
+		ast.Inspect(f, func(node ast.Node) bool {
+			var names []*ast.Ident
+			var docs *ast.CommentGroup
+			switch node := node.(type) {
+			case *ast.GenDecl:
+				switch node.Tok {
+				case token.TYPE, token.CONST, token.VAR:
+					docs = node.Doc
+					for i := range node.Specs {
+						switch n := node.Specs[i].(type) {
+						case *ast.ValueSpec:
+							names = append(names, n.Names...)
+						case *ast.TypeSpec:
+							names = append(names, n.Name)
+						}
+					}
+				default:
+					return true
+				}
+			case *ast.FuncDecl:
+				docs = node.Doc
+				names = []*ast.Ident{node.Name}
+			case *ast.TypeSpec:
+				docs = node.Doc
+				names = []*ast.Ident{node.Name}
+			case *ast.ValueSpec:
+				docs = node.Doc
+				names = node.Names
+			case *ast.StructType:
+				for _, field := range node.Fields.List {
+					doDocs(field.Names, field.Doc)
+				}
+			case *ast.InterfaceType:
+				for _, field := range node.Methods.List {
+					doDocs(field.Names, field.Doc)
+				}
+			}
+			if docs != nil && len(names) > 0 {
+				doDocs(names, docs)
+			}
+			return true
+		})
+	}
+
+	// The Go files of the package (all of them, for a Go dependency) are
+	// handled by collectDeprecatedNames, through a Go pass whose facts are
+	// stored as gopDeprecationFacts.
+	goPass := pass.GoPass
+	goPass.ExportObjectFact = func(obj types.Object, fact goanalysis.Fact) {
+		pass.ExportObjectFact(obj, (*gopDeprecationFact)(fact.(*deprecationFact)))
+	}
+	goPass.ExportPackageFact = func(fact goanalysis.Fact) {
+		pass.ExportPackageFact((*gopDeprecationFact)(fact.(*deprecationFact)))
+	}
+	goPass.AllObjectFacts = func() []goanalysis.ObjectFact {
+		facts := pass.AllObjectFacts()
+		for i, fact := range facts {
+			facts[i].Fact = (*deprecationFact)(fact.Fact.(*gopDeprecationFact))
+		}
+		return facts
+	}
+	goPass.AllPackageFacts = func() []goanalysis.PackageFact {
+		facts := pass.AllPackageFacts()
+		for i, fact := range facts {
+			facts[i].Fact = (*deprecationFact)(fact.Fact.(*gopDeprecationFact))
+		}
+		return facts
+	}
+	return collectDeprecatedNames(&goPass, goinspector.New(pass.Files))
+}
+
+// gopExtractDeprecatedMessage returns the message of the first "Deprecated: "
+// paragraph of docs.
+func gopExtractDeprecatedMessage(docs []*ast.CommentGroup) string {
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		parts := strings.Split(doc.Text(), "\n\n")
+		for _, part := range parts {
+			if !strings.HasPrefix(part, "Deprecated: ") {
+				continue
+			}
+			alt := part[len("Deprecated: "):]
+			alt = strings.Replace(alt, "\n", " ", -1)
+			return strings.TrimSpace(alt)
+		}
+	}
+	return ""
+}
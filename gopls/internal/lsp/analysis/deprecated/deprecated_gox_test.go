@@ -0,0 +1,18 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deprecated
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gop/analysis/analysistest"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestGop(t *testing.T) {
+	testenv.NeedsGo1Point(t, 19)
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, GopAnalyzer, "goplus")
+}
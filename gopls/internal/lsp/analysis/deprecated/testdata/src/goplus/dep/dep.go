@@ -0,0 +1,12 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dep
+
+// Old is an old function.
+//
+// Deprecated: use New instead.
+func Old() {}
+
+func New() {}
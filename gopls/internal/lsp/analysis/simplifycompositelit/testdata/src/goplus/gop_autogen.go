@@ -0,0 +1,16 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package goplus
+
+const _ = true
+
+type T struct {
+	x int
+	y int
+}
+
+var _ = []T{T{}, T{1, 2}}
+var _ = map[string]T{"foo": T{}, "bar": T{3, 4}}
+var _ = map[T]string{T{1, 2}: "foo"}
+var _ = []*T{&T{1, 2}, &T{3, 4}}
+var _ = []T{T{1, 2}, T{3, 4}}
@@ -0,0 +1,131 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplifyrange
+
+import (
+	"bytes"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/ast/inspector"
+)
+
+const gopDoc = Doc + `
+
+In Go+ files, a for phrase of the form:
+	for _, v <- x {...}
+will be simplified to:
+	for v <- x {...}`
+
+var GopAnalyzer = &analysis.Analyzer{
+	Name:     "gopSimplifyrange",
+	Doc:      gopDoc,
+	Requires: []analysis.IAnalyzer{Analyzer, inspect.Analyzer},
+	Run:      gopRun,
+}
+
+func gopRun(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.GopFiles) == 0 {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		gopSimplifyRange(pass, n.(*ast.RangeStmt))
+	})
+
+	// For phrases of the form: for _, v <- x {}
+	// (The inspector doesn't filter Go+ specific nodes.)
+	for _, f := range pass.GopFiles {
+		ast.Inspect(f, func(n ast.Node) bool {
+			stmt, ok := n.(*ast.ForPhraseStmt)
+			if !ok || stmt.Key == nil || !gopIsBlank(stmt.Key) {
+				return true
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     stmt.Key.Pos(),
+				End:     stmt.Key.End(),
+				Message: "simplify range expression",
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: "Remove empty key",
+					TextEdits: []analysis.TextEdit{{
+						Pos: stmt.Key.Pos(),
+						End: stmt.Value.Pos(),
+					}},
+				}},
+			})
+			return true
+		})
+	}
+	return nil, nil
+}
+
+func gopSimplifyRange(pass *analysis.Pass, stmt *ast.RangeStmt) {
+	x := *stmt
+	copy := &x
+	end := gopNewlineIndex(pass.Fset, copy)
+
+	// Range statements of the form: for i, _ := range x {}
+	var old ast.Expr
+	if gopIsBlank(copy.Value) {
+		old = copy.Value
+		copy.Value = nil
+	}
+	// Range statements of the form: for _ := range x {}
+	if gopIsBlank(copy.Key) && copy.Value == nil {
+		old = copy.Key
+		copy.Key = nil
+	}
+	// Return early if neither if condition is met.
+	if old == nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            old.Pos(),
+		End:            old.End(),
+		Message:        "simplify range expression",
+		SuggestedFixes: gopSuggestedFixes(pass.Fset, copy, end),
+	})
+}
+
+func gopSuggestedFixes(fset *token.FileSet, rng *ast.RangeStmt, end token.Pos) []analysis.SuggestedFix {
+	var b bytes.Buffer
+	format.Node(&b, fset, rng)
+	stmt := b.Bytes()
+	index := bytes.Index(stmt, []byte("\n"))
+	// If there is a new line character, then don't replace the body.
+	if index != -1 {
+		stmt = stmt[:index]
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Remove empty value",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     rng.Pos(),
+			End:     end,
+			NewText: stmt,
+		}},
+	}}
+}
+
+func gopNewlineIndex(fset *token.FileSet, rng *ast.RangeStmt) token.Pos {
+	var b bytes.Buffer
+	format.Node(&b, fset, rng)
+	contents := b.Bytes()
+	index := bytes.Index(contents, []byte("\n"))
+	if index == -1 {
+		return rng.End()
+	}
+	return rng.Pos() + token.Pos(index)
+}
+
+func gopIsBlank(x ast.Expr) bool {
+	ident, ok := x.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
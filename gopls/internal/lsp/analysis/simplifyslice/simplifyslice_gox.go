@@ -0,0 +1,81 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simplifyslice
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/format"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/ast/inspector"
+)
+
+var GopAnalyzer = &analysis.Analyzer{
+	Name:     "gopSimplifyslice",
+	Doc:      Doc,
+	Requires: []analysis.IAnalyzer{Analyzer, inspect.Analyzer},
+	Run:      gopRun,
+}
+
+func gopRun(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.GopFiles) == 0 {
+		return nil, nil
+	}
+	info := pass.GopTypesInfo
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.SliceExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr := n.(*ast.SliceExpr)
+		// - 3-index slices always require the 2nd and 3rd index
+		if expr.Max != nil {
+			return
+		}
+		s, ok := expr.X.(*ast.Ident)
+		// the array/slice object is a single, resolved identifier
+		if !ok || info.ObjectOf(s) == nil {
+			return
+		}
+		call, ok := expr.High.(*ast.CallExpr)
+		// the high expression is a function call with a single argument
+		if !ok || len(call.Args) != 1 || call.Ellipsis.IsValid() {
+			return
+		}
+		fun, ok := call.Fun.(*ast.Ident)
+		// the function called is the predefined len()
+		if !ok || fun.Name != "len" {
+			return
+		}
+		if _, ok := info.Uses[fun].(*types.Builtin); !ok {
+			return
+		}
+		arg, ok := call.Args[0].(*ast.Ident)
+		// the len argument is the array/slice object
+		if !ok || info.ObjectOf(arg) != info.ObjectOf(s) {
+			return
+		}
+		var b bytes.Buffer
+		format.Node(&b, pass.Fset, expr.High)
+		pass.Report(analysis.Diagnostic{
+			Pos:     expr.High.Pos(),
+			End:     expr.High.End(),
+			Message: fmt.Sprintf("unneeded: %s", b.String()),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: fmt.Sprintf("Remove '%s'", b.String()),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     expr.High.Pos(),
+					End:     expr.High.End(),
+					NewText: []byte{},
+				}},
+			}},
+		})
+	})
+	return nil, nil
+}
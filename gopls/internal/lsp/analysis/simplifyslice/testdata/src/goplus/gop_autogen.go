@@ -0,0 +1,34 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package goplus
+
+const _ = true
+
+var a [10]byte
+var b [20]float32
+var s []int
+var _ = a[0:]
+var _ = a[2:len(a)]
+var _ = a[3:len(a)]
+var _ = a[2:len(a):len(a)]
+var _ = a[:len(a)]
+var _ = s[2:len(s)]
+var _ = s[0:len(b)]
+var _ = s[:len(s)-1]
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:19:1
+func _() {
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:20:1
+	s := s[0:len(s)]
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:21:1
+	_ = s
+}
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:24:1
+func _() {
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:25:1
+	len := func(v []int) int {
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:25:1
+		return 0
+	}
+//line internal/lsp/analysis/simplifyslice/testdata/src/goplus/a.gop:26:1
+	_ = s[:len(s)]
+}
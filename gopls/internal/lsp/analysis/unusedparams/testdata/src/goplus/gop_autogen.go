@@ -0,0 +1,84 @@
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package goplus
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+const _ = true
+
+type parent interface {
+	n(f bool)
+}
+type yuh struct {
+	a int
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:17:1
+func (y *yuh) n(f bool) {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:18:1
+	for
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:18:1
+	i := 0; i < 10;
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:18:1
+	i++ {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:19:1
+		fmt.Println(i)
+	}
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:23:1
+func a(i1 int, i2 int, i3 int) int {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:24:1
+	i3 += i1
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:25:1
+	_ = func(z int) int {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:26:1
+		_ = 1
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:27:1
+		return 1
+	}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:29:1
+	return i3
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:32:1
+func b(c bytes.Buffer) {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:33:1
+	_ = 1
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:36:1
+func z(h http.ResponseWriter, _ *http.Request) {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:37:1
+	fmt.Println("Before")
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:40:1
+func l(h http.Handler) http.Handler {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:41:1
+	return http.HandlerFunc(z)
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:44:1
+func mult(a int, b int) int {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:45:1
+	a += 1
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:46:1
+	return a
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:49:1
+func y(a int) {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:50:1
+	panic("yo")
+}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:53:1
+func sum(nums []int, scale int) int {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:54:1
+	s := 0
+	for
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:55:1
+	_, n := range nums {
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:56:1
+		s += n
+	}
+//line internal/lsp/analysis/unusedparams/testdata/src/goplus/a.gop:58:1
+	return s
+}
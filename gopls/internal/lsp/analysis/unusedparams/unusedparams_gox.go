@@ -0,0 +1,144 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unusedparams
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/inspect"
+	"golang.org/x/tools/gop/ast/inspector"
+)
+
+var GopAnalyzer = &analysis.Analyzer{
+	Name:     "gopUnusedparams",
+	Doc:      Doc,
+	Requires: []analysis.IAnalyzer{Analyzer, inspect.Analyzer},
+	Run:      gopRun,
+}
+
+type gopParamData struct {
+	field  *ast.Field
+	ident  *ast.Ident
+	typObj types.Object
+}
+
+// gopIsTestFile reports whether filename is a Go+ test file.
+func gopIsTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.gop") || strings.HasSuffix(filename, "_test.gox")
+}
+
+func gopRun(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.GopFiles) == 0 {
+		return nil, nil
+	}
+	info := pass.GopTypesInfo
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var fieldList *ast.FieldList
+		var body *ast.BlockStmt
+
+		// Get the fieldList and body from the function node.
+		switch f := n.(type) {
+		case *ast.FuncDecl:
+			fieldList, body = f.Type.Params, f.Body
+			// Methods, including the ones of Go+ classes, may implement an
+			// interface: ignore them to avoid false positives.
+			if f.Recv != nil || f.IsClass {
+				return
+			}
+			// Ignore functions in Go+ test files to reduce false positives.
+			if file := pass.Fset.File(n.Pos()); file != nil && gopIsTestFile(file.Name()) {
+				return
+			}
+		case *ast.FuncLit:
+			fieldList, body = f.Type.Params, f.Body
+		}
+		// If there are no arguments or the function is empty, then return.
+		if fieldList.NumFields() == 0 || body == nil || len(body.List) == 0 {
+			return
+		}
+
+		switch expr := body.List[0].(type) {
+		case *ast.ReturnStmt:
+			// Ignore functions that only contain a return statement to reduce false positives.
+			return
+		case *ast.ExprStmt:
+			callExpr, ok := expr.X.(*ast.CallExpr)
+			if !ok || len(body.List) > 1 {
+				break
+			}
+			// Ignore functions that only contain a panic statement to reduce false positives.
+			if fun, ok := callExpr.Fun.(*ast.Ident); ok && fun.Name == "panic" {
+				return
+			}
+		}
+
+		// Get the useful data from each field.
+		params := make(map[string]*gopParamData)
+		unused := make(map[*gopParamData]bool)
+		for _, f := range fieldList.List {
+			for _, i := range f.Names {
+				if i.Name == "_" {
+					continue
+				}
+				params[i.Name] = &gopParamData{
+					field:  f,
+					ident:  i,
+					typObj: info.ObjectOf(i),
+				}
+				unused[params[i.Name]] = true
+			}
+		}
+
+		// Traverse through the body of the function and
+		// check to see which parameters are unused.
+		ast.Inspect(body, func(node ast.Node) bool {
+			n, ok := node.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			param, ok := params[n.Name]
+			if !ok {
+				return false
+			}
+			if nObj := info.ObjectOf(n); nObj != param.typObj {
+				return false
+			}
+			delete(unused, param)
+			return false
+		})
+
+		// Create the reports for the unused parameters.
+		for u := range unused {
+			start, end := u.field.Pos(), u.field.End()
+			if len(u.field.Names) > 1 {
+				start, end = u.ident.Pos(), u.ident.End()
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     start,
+				End:     end,
+				Message: fmt.Sprintf("potentially unused parameter: '%s'", u.ident.Name),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: `Replace with "_"`,
+					TextEdits: []analysis.TextEdit{{
+						Pos:     u.ident.Pos(),
+						End:     u.ident.End(),
+						NewText: []byte("_"),
+					}},
+				}},
+			})
+		}
+	})
+	return nil, nil
+}
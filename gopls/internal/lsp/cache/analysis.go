@@ -213,12 +213,9 @@ func (snapshot *snapshot) Analyze(ctx context.Context, pkgs map[PackageID]unit,
 	enabled = requiredAnalyzers(enabled)
 	var facty []gopanalysis.IAnalyzer // facty subset of enabled + transitive requirements
 	for _, a := range enabled {
-		factTypes := gopanalysis.FactTypes(a)
-		if len(factTypes) > 0 {
+		if len(gopanalysis.FactTypes(a)) > 0 {
 			facty = append(facty, a)
-			for _, f := range factTypes {
-				gob.Register(f) // <2us
-			}
+			gopanalysis.RegisterFacts(a) // <2us - goxls: Go+ facts
 		}
 	}
 	facty = requiredAnalyzers(facty)
@@ -708,7 +705,7 @@ var cacheLimit = make(chan unit, 32)
 // analysisCacheKey returns a cache key that is a cryptographic digest
 // of the all the values that might affect type checking and analysis:
 // the analyzer names, package metadata, names and contents of
-// compiled Go files, and vdeps (successor) information
+// compiled Go and Go+ files, and vdeps (successor) information
 // (export data and facts).
 func (an *analysisNode) cacheKey() [sha256.Size]byte {
 	hasher := sha256.New()
@@ -749,6 +746,12 @@ func (an *analysisNode) cacheKey() [sha256.Size]byte {
 		fmt.Fprintln(hasher, fh.FileIdentity())
 	}
 
+	// goxls: Go+ file names and contents
+	fmt.Fprintf(hasher, "gop files: %d\n", len(an.gopFiles))
+	for _, fh := range an.gopFiles {
+		fmt.Fprintln(hasher, fh.FileIdentity())
+	}
+
 	// vdeps, in PackageID order
 	depIDs := make([]string, 0, len(an.succs))
 	for depID := range an.succs {
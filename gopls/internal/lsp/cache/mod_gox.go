@@ -0,0 +1,127 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/event/tag"
+	"golang.org/x/tools/internal/gocommand"
+	"golang.org/x/tools/internal/memoize"
+	"golang.org/x/tools/internal/persistent"
+)
+
+// GopListModules returns the modules of the given paths, as listed by go list
+// -m in the directory of the gop.mod file uri. Concurrent requests are
+// combined into a single command.
+func (s *snapshot) GopListModules(ctx context.Context, uri span.URI, paths []string) ([]*source.GopModule, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	dir := filepath.Dir(uri.Filename())
+	goMod, err := s.ReadFile(ctx, span.URIFromPath(filepath.Join(dir, "go.mod")))
+	if err != nil {
+		return nil, err
+	}
+	gopMod, err := s.ReadFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	// go list -m depends on the requirements of go.mod, and the gop.mod
+	// features query the modules it registers.
+	key := source.Hashf("%s %s %s %s", dir, goMod.FileIdentity().Hash, gopMod.FileIdentity().Hash, strings.Join(paths, " "))
+
+	s.mu.Lock()
+	entry, hit := s.gopListModHandles.Get(key)
+	s.mu.Unlock()
+
+	type gopListModResult struct {
+		mods []*source.GopModule
+		err  error
+	}
+
+	// Cache miss?
+	if !hit {
+		handle := memoize.NewPromise("gopListModules", func(ctx context.Context, arg interface{}) interface{} {
+			mods, err := gopListModulesImpl(ctx, arg.(*snapshot), dir, paths)
+			return gopListModResult{mods, err}
+		})
+
+		entry = handle
+		s.mu.Lock()
+		s.gopListModHandles.Set(key, entry, nil)
+		s.mu.Unlock()
+	}
+
+	// Await result.
+	v, err := s.awaitPromise(ctx, entry.(*memoize.Promise))
+	if err != nil {
+		return nil, err
+	}
+	res := v.(gopListModResult)
+	return res.mods, res.err
+}
+
+// gopListModulesImpl runs go list -m -e -json on paths in dir.
+func gopListModulesImpl(ctx context.Context, snapshot *snapshot, dir string, paths []string) ([]*source.GopModule, error) {
+	ctx, done := event.Start(ctx, "cache.GopListModules", tag.Directory.Of(dir))
+	defer done()
+
+	inv := &gocommand.Invocation{
+		Verb:       "list",
+		Args:       append([]string{"-m", "-e", "-json"}, paths...),
+		WorkingDir: dir,
+	}
+	stdout, err := snapshot.RunGoCommandDirect(ctx, source.Normal, inv)
+	if err != nil {
+		return nil, err
+	}
+	var mods []*source.GopModule
+	for dec := json.NewDecoder(stdout); ; {
+		var m struct {
+			Path    string
+			Version string
+			Dir     string
+			Error   *struct{ Err string }
+		}
+		if err := dec.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		mod := &source.GopModule{Path: m.Path, Version: m.Version, Dir: m.Dir}
+		if m.Error != nil {
+			mod.Err = m.Error.Err
+		}
+		mods = append(mods, mod)
+	}
+	return mods, nil
+}
+
+// invalidateGopListMods drops the go list -m results of handles if uri is a
+// go.mod or gop.mod file. The results are keyed on the content of these
+// files, so this only keeps stale results from accumulating.
+func invalidateGopListMods(handles *persistent.Map, uri span.URI) {
+	switch filepath.Base(uri.Filename()) {
+	case "go.mod", "gop.mod":
+		handles.Clear()
+	}
+}
+
+// hashLessInterface is the < relation for "any" values containing
+// source.Hashes.
+func hashLessInterface(a, b interface{}) bool {
+	x, y := a.(source.Hash), b.(source.Hash)
+	return bytes.Compare(x[:], y[:]) < 0
+}
@@ -200,6 +200,7 @@ func (s *Session) createView(ctx context.Context, name string, folder span.URI,
 		modTidyHandles:       persistent.NewMap(uriLessInterface),
 		modVulnHandles:       persistent.NewMap(uriLessInterface),
 		modWhyHandles:        persistent.NewMap(uriLessInterface),
+		gopListModHandles:    persistent.NewMap(hashLessInterface), // goxls: gop.mod
 		knownSubdirs:         newKnownDirsSet(),
 		workspaceModFiles:    wsModFiles,
 		workspaceModFilesErr: wsModFilesErr,
@@ -17,7 +17,6 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -31,7 +30,6 @@ import (
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/types/objectpath"
-	"golang.org/x/tools/gop/goputil"
 	"golang.org/x/tools/gopls/internal/bug"
 	"golang.org/x/tools/gopls/internal/goxls"
 	"golang.org/x/tools/gopls/internal/lsp/command"
@@ -154,6 +152,10 @@ type snapshot struct {
 	modWhyHandles  *persistent.Map // from span.URI to *memoize.Promise[modWhyResult]
 	modVulnHandles *persistent.Map // from span.URI to *memoize.Promise[modVulnResult]
 
+	// goxls: gopListModHandles caches the go list -m queries of the gop.mod
+	// features.
+	gopListModHandles *persistent.Map // from source.Hash to *memoize.Promise[gopListModResult]
+
 	// knownSubdirs is the set of subdirectory URIs in the workspace,
 	// used to create glob patterns for file watching.
 	knownSubdirs      knownDirsSet
@@ -261,6 +263,7 @@ func (s *snapshot) destroy(destroyedBy string) {
 	s.modTidyHandles.Destroy()
 	s.modVulnHandles.Destroy()
 	s.modWhyHandles.Destroy()
+	s.gopListModHandles.Destroy() // goxls: gop.mod
 }
 
 func (s *snapshot) SequenceID() uint64 {
@@ -890,7 +893,7 @@ func (s *snapshot) resetActivePackagesLocked() {
 const fileExtensions = "go,mod,sum,work"
 
 func (s *snapshot) fileWatchingGlobPatterns(ctx context.Context) map[string]struct{} {
-	extensions := fileExtensions + "," + gopExtensions() // goxls: Go+
+	extensions := fileExtensions + "," + s.view.gopExtensions() // goxls: Go+
 	for _, ext := range s.View().Options().TemplateExtensions {
 		extensions += "," + ext
 	}
@@ -1152,6 +1155,10 @@ func (s *snapshot) Symbols(ctx context.Context, workspaceOnly bool) (map[span.UR
 	group.SetLimit(nprocs)
 	for uri := range uris {
 		uri := uri
+		// goxls: the symbols of Go+ files come from the Go+ files themselves
+		if fname := filepath.Base(uri.Filename()); strings.HasPrefix(fname, "gop_autogen") {
+			continue
+		}
 		group.Go(func() error {
 			symbols, err := s.symbolize(ctx, uri)
 			if err != nil {
@@ -1908,6 +1915,10 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		if _, ok := wsModFiles[uri]; ok && change.fileHandle.Saved() && !change.isUnchanged {
 			reinit = true
 		}
+		// goxls: Go+ - as does a gop.mod file, which may register classfiles
+		if gopModChanged(uri, change, wsModFiles) {
+			reinit = true
+		}
 	}
 
 	// Finally, process sumfile changes that may affect loading.
@@ -1967,6 +1978,7 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		modTidyHandles:       s.modTidyHandles.Clone(),
 		modWhyHandles:        s.modWhyHandles.Clone(),
 		modVulnHandles:       s.modVulnHandles.Clone(),
+		gopListModHandles:    s.gopListModHandles.Clone(), // goxls: gop.mod
 		knownSubdirs:         s.knownSubdirs.Clone(),
 		workspaceModFiles:    wsModFiles,
 		workspaceModFilesErr: wsModFilesErr,
@@ -2026,6 +2038,7 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		result.modTidyHandles.Delete(uri)
 		result.modWhyHandles.Delete(uri)
 		result.modVulnHandles.Delete(uri)
+		invalidateGopListMods(result.gopListModHandles, uri) // goxls: gop.mod
 
 		// Invalidate handles for cached symbols.
 		result.symbolizeHandles.Delete(uri)
@@ -2045,7 +2058,7 @@ func (s *snapshot) clone(ctx, bgCtx context.Context, changes map[span.URI]*fileC
 		var invalidateMetadata, pkgFileChanged, importDeleted bool
 		if strings.HasSuffix(uri.Filename(), ".go") {
 			invalidateMetadata, pkgFileChanged, importDeleted = metadataChanges(ctx, s, originalFH, change.fileHandle)
-		} else if goputil.FileKind(path.Ext(uri.Filename())) != goputil.FileUnknown { // goxls: Support Go+
+		} else if s.view.IsGopFile(uri) { // goxls: Support Go+
 			invalidateMetadata, pkgFileChanged, importDeleted = gopMetadataChanges(ctx, s, originalFH, change.fileHandle)
 		}
 
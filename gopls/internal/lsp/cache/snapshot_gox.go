@@ -7,6 +7,7 @@ package cache
 import (
 	"context"
 	"go/token"
+	"path/filepath"
 
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/mod/gopmod"
@@ -14,14 +15,30 @@ import (
 	"golang.org/x/tools/gopls/internal/bug"
 	"golang.org/x/tools/gopls/internal/goxls/parserutil"
 	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
 )
 
-func checkGopFile(fh source.FileHandle, fext string) bool {
-	return goputil.FileKind(fext) != goputil.FileUnknown
+// IsGopFile reports whether uri is a Go+ file: a file with a builtin Go+
+// extension or a classfile registered by a workspace module.
+func (v *View) IsGopFile(uri span.URI) bool {
+	return v.gopClasses.fileKind(uri.Filename()) != goputil.FileUnknown
 }
 
-func gopExtensions() string {
-	return goputil.Exts()
+// gopExtensions returns the extensions of the Go+ files of the view, without
+// leading dots, as a comma-separated list.
+func (v *View) gopExtensions() string {
+	return v.gopClasses.exts()
+}
+
+// gopModChanged reports whether the change to uri is a change on disk to the
+// gop.mod file of a workspace module, whose classfiles may have changed.
+func gopModChanged(uri span.URI, change *fileChange, wsModFiles map[span.URI]struct{}) bool {
+	if filepath.Base(uri.Filename()) != "gop.mod" || !change.fileHandle.Saved() || change.isUnchanged {
+		return false
+	}
+	modURI := span.URIFromPath(filepath.Join(filepath.Dir(uri.Filename()), "go.mod"))
+	_, ok := wsModFiles[modURI]
+	return ok
 }
 
 // gopMetadataChanges detects features of the change from oldFH->newFH that may
@@ -37,9 +37,13 @@ func (s *snapshot) symbolize(ctx context.Context, uri span.URI) ([]source.Symbol
 			return nil, err
 		}
 		type symbolHandleKey source.Hash
-		key := symbolHandleKey(fh.FileIdentity().Hash)
+		var key interface{} = symbolHandleKey(fh.FileIdentity().Hash)
+		impl := symbolizeImpl
+		if s.view.FileKind(fh) == source.Gop { // goxls: Go+
+			key, impl = gopSymbolHandleKey(fh.FileIdentity().Hash), gopSymbolizeImpl
+		}
 		promise, release := s.store.Promise(key, func(ctx context.Context, arg interface{}) interface{} {
-			symbols, err := symbolizeImpl(ctx, arg.(*snapshot), fh)
+			symbols, err := impl(ctx, arg.(*snapshot), fh)
 			return symbolizeResult{symbols, err}
 		})
 
@@ -0,0 +1,243 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+// gopSymbolHandleKey is the key of the symbols of a Go+ file in the store,
+// which differ from the ones of a Go file of the same content.
+type gopSymbolHandleKey source.Hash
+
+// gopSymbolizeImpl reads and parses a Go+ file and extracts symbols from it.
+//
+// The type of a class file is named after the class, and the class fields
+// and methods are its fields and methods.
+func gopSymbolizeImpl(ctx context.Context, snapshot *snapshot, fh source.FileHandle) ([]source.Symbol, error) {
+	mod, err := snapshot.GopModForFile(ctx, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	pgfs, err := snapshot.view.parseCache.parseGopFiles(ctx, mod, token.NewFileSet(), parserutil.ParseFull, false, fh)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &gopSymbolWalker{
+		tokFile: pgfs[0].Tok,
+		mapper:  pgfs[0].Mapper,
+	}
+	file := pgfs[0].File
+	if classType, ok := parserutil.GetClassType(file, fh.URI().Filename()); ok {
+		w.classDecls(file, classType)
+	} else {
+		w.fileDecls(file.Decls)
+	}
+
+	return w.symbols, w.firstError
+}
+
+// gopSymbolWalker is the Go+ counterpart of symbolWalker.
+type gopSymbolWalker struct {
+	// for computing positions
+	tokFile *token.File
+	mapper  *protocol.Mapper
+
+	symbols    []source.Symbol
+	firstError error
+}
+
+func (w *gopSymbolWalker) atNode(node ast.Node, name string, kind protocol.SymbolKind, path ...*ast.Ident) {
+	w.atRange(node.Pos(), node.End(), name, kind, path...)
+}
+
+func (w *gopSymbolWalker) atRange(start, end token.Pos, name string, kind protocol.SymbolKind, path ...*ast.Ident) {
+	var b strings.Builder
+	for _, ident := range path {
+		if ident != nil {
+			b.WriteString(ident.Name)
+			b.WriteString(".")
+		}
+	}
+	b.WriteString(name)
+
+	rng, err := w.mapper.PosRange(w.tokFile, start, end)
+	if err != nil {
+		w.error(err)
+		return
+	}
+	sym := source.Symbol{
+		Name:  b.String(),
+		Kind:  kind,
+		Range: rng,
+	}
+	w.symbols = append(w.symbols, sym)
+}
+
+func (w *gopSymbolWalker) error(err error) {
+	if err != nil && w.firstError == nil {
+		w.firstError = err
+	}
+}
+
+// classDecls processes the declarations of a class file: the class type,
+// which is declared at the start of the file, its fields, declared by the
+// first var declaration, and its methods.
+func (w *gopSymbolWalker) classDecls(file *ast.File, classType string) {
+	class := &ast.Ident{NamePos: file.Pos(), Name: classType}
+	w.atRange(class.Pos(), class.Pos(), classType, protocol.Class)
+
+	decls := file.Decls
+	for i, decl := range decls {
+		if decl, ok := decl.(*ast.GenDecl); ok {
+			if decl.Tok == token.VAR {
+				for _, spec := range decl.Specs {
+					w.walkClassField(spec.(*ast.ValueSpec), class)
+				}
+				decls = append(decls[:i:i], decls[i+1:]...)
+				break
+			}
+			continue
+		}
+		break
+	}
+
+	for _, decl := range decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok && (decl.Recv == nil || decl.IsClass) {
+			name := decl.Name.Name
+			if decl.Shadow && name == "main" {
+				if file.IsProj {
+					name = "MainEntry"
+				} else {
+					name = "Main"
+				}
+			}
+			w.atNode(decl.Name, name, protocol.Method, class)
+			continue
+		}
+		w.fileDecls([]ast.Decl{decl})
+	}
+}
+
+// walkClassField processes the symbols of a class field.
+func (w *gopSymbolWalker) walkClassField(spec *ast.ValueSpec, class *ast.Ident) {
+	if len(spec.Names) == 0 {
+		// embedded type
+		if typ, ok := spec.Type.(*ast.StarExpr); ok {
+			w.walkField(&ast.Field{Type: typ.X}, protocol.Field, protocol.Field, class)
+			return
+		}
+	}
+	w.walkField(&ast.Field{Names: spec.Names, Type: spec.Type}, protocol.Field, protocol.Field, class)
+}
+
+func (w *gopSymbolWalker) fileDecls(decls []ast.Decl) {
+	for _, decl := range decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			kind := protocol.Function
+			var recv *ast.Ident
+			if decl.Recv.NumFields() > 0 {
+				kind = protocol.Method
+				recv = gopRecvTypeName(decl.Recv.List[0].Type)
+			}
+			w.atNode(decl.Name, decl.Name.Name, kind, recv)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					kind := gopGuessKind(spec)
+					w.atNode(spec.Name, spec.Name.Name, kind)
+					w.walkType(spec.Type, spec.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						kind := protocol.Variable
+						if decl.Tok == token.CONST {
+							kind = protocol.Constant
+						}
+						w.atNode(name, name.Name, kind)
+					}
+				}
+			}
+		}
+	}
+}
+
+// gopRecvTypeName returns the type name identifier of a receiver type
+// expression, or nil if there is none.
+func gopRecvTypeName(rtyp ast.Expr) *ast.Ident {
+	for {
+		switch t := rtyp.(type) {
+		case *ast.ParenExpr:
+			rtyp = t.X
+		case *ast.StarExpr:
+			rtyp = t.X
+		case *ast.IndexExpr:
+			rtyp = t.X
+		case *ast.IndexListExpr:
+			rtyp = t.X
+		case *ast.Ident:
+			return t
+		default:
+			return nil
+		}
+	}
+}
+
+func gopGuessKind(spec *ast.TypeSpec) protocol.SymbolKind {
+	switch spec.Type.(type) {
+	case *ast.InterfaceType:
+		return protocol.Interface
+	case *ast.StructType:
+		return protocol.Struct
+	case *ast.FuncType:
+		return protocol.Function
+	}
+	return protocol.Class
+}
+
+// walkType processes symbols related to a type expression. path is path of
+// nested type identifiers to the type expression.
+func (w *gopSymbolWalker) walkType(typ ast.Expr, path ...*ast.Ident) {
+	switch st := typ.(type) {
+	case *ast.StructType:
+		for _, field := range st.Fields.List {
+			w.walkField(field, protocol.Field, protocol.Field, path...)
+		}
+	case *ast.InterfaceType:
+		for _, field := range st.Methods.List {
+			w.walkField(field, protocol.Interface, protocol.Method, path...)
+		}
+	}
+}
+
+// walkField processes symbols related to the struct field or interface method.
+//
+// unnamedKind and namedKind are the symbol kinds if the field is resp. unnamed
+// or named. path is the path of nested identifiers containing the field.
+func (w *gopSymbolWalker) walkField(field *ast.Field, unnamedKind, namedKind protocol.SymbolKind, path ...*ast.Ident) {
+	if len(field.Names) == 0 {
+		switch typ := field.Type.(type) {
+		case *ast.SelectorExpr:
+			// embedded qualified type
+			w.atNode(field, typ.Sel.Name, unnamedKind, path...)
+		default:
+			w.atNode(field, typesutil.ExprString(field.Type), unnamedKind, path...)
+		}
+	}
+	for _, name := range field.Names {
+		w.atNode(name, name.Name, namedKind, path...)
+		w.walkType(field.Type, append(path, name)...)
+	}
+}
@@ -58,6 +58,7 @@ type View struct {
 
 	importsState    *importsState
 	gopImportsState *gopImportsState // goxls: Go+
+	gopClasses      gopClassRegistry // goxls: Go+ classfiles of the workspace
 
 	// moduleUpgrades tracks known upgrades for module paths in each modfile.
 	// Each modfile has a map of module name to upgrade version.
@@ -416,6 +417,10 @@ func (v *View) FileKind(fh source.FileHandle) source.FileKind {
 	// TextDocumentItem.LanguageID field in the didChange event,
 	// not from the file name. They may differ.
 	if o, ok := fh.(*Overlay); ok {
+		// goxls: clients may not know the gop.mod language
+		if o.kind == source.Mod && filepath.Base(fh.URI().Filename()) == "gop.mod" {
+			return source.GopMod
+		}
 		if o.kind != source.UnknownKind {
 			return o.kind
 		}
@@ -426,13 +431,16 @@ func (v *View) FileKind(fh source.FileHandle) source.FileKind {
 	case ".go":
 		return source.Go
 	case ".mod":
+		if filepath.Base(fh.URI().Filename()) == "gop.mod" { // goxls: gop.mod
+			return source.GopMod
+		}
 		return source.Mod
 	case ".sum":
 		return source.Sum
 	case ".work":
 		return source.Work
 	default:
-		if checkGopFile(fh, fext) { // goxls: check Go+ file
+		if v.IsGopFile(fh.URI()) { // goxls: check Go+ file
 			return source.Gop
 		}
 	}
@@ -866,6 +874,7 @@ func (s *snapshot) loadWorkspace(ctx context.Context, firstAttempt bool) (loadEr
 	} else {
 		scopes = append(scopes, viewLoadScope("LOAD_VIEW"))
 	}
+	s.view.gopClasses.load(ctx, s.workspaceModFiles) // goxls: Go+ classfiles
 
 	// If we're loading anything, ensure we also load builtin,
 	// since it provides fake definitions (and documentation)
@@ -6,9 +6,14 @@ package cache
 
 import (
 	"context"
+	"path/filepath"
+	"sync"
 
+	"github.com/goplus/mod/gopmod"
+	"golang.org/x/tools/gop/goputil"
 	"golang.org/x/tools/gopls/internal/goxls/imports"
 	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/event"
 )
 
 func (s *snapshot) GopRunProcessEnvFunc(ctx context.Context, fn func(context.Context, *imports.Options) error) error {
@@ -18,3 +23,58 @@ func (s *snapshot) GopRunProcessEnvFunc(ctx context.Context, fn func(context.Con
 func gopAllFilesExcluded(goFiles, gopFiles []string, filterFunc func(span.URI) bool) bool {
 	return allFilesExcluded(goFiles, filterFunc) && allFilesExcluded(gopFiles, filterFunc)
 }
+
+// gopClassRegistry records the classfiles registered by the modules of a
+// view's workspace (see gop.mod). Together with the builtin extensions, they
+// determine which files are Go+ files.
+//
+// The registry is loaded by each workspace load, which happens again when a
+// gop.mod file of the workspace changes.
+type gopClassRegistry struct {
+	mu        sync.Mutex
+	mods      []*gopmod.Module
+	classExts []string // extensions of the classfiles of mods
+}
+
+// load loads the Go+ modules of the go.mod files modFiles and replaces the
+// contents of the registry with their classfiles.
+func (r *gopClassRegistry) load(ctx context.Context, modFiles map[span.URI]struct{}) {
+	var mods []*gopmod.Module
+	var classExts []string
+	for modURI := range modFiles {
+		mod, exts, err := goputil.LoadMod(filepath.Dir(modURI.Filename()))
+		if err != nil {
+			// Keep the classfiles that could be imported.
+			event.Error(ctx, "loading Go+ classfiles", err)
+		}
+		if mod != nil {
+			mods = append(mods, mod)
+			classExts = append(classExts, exts...)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mods, r.classExts = mods, classExts
+}
+
+// fileKind returns the kind of the file fname, consulting the classfile
+// registries of the workspace modules.
+func (r *gopClassRegistry) fileKind(fname string) goputil.Kind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, mod := range r.mods {
+		if kind := goputil.FileKindOf(mod, fname); kind != goputil.FileUnknown {
+			return kind
+		}
+	}
+	return goputil.FileKindOf(nil, fname)
+}
+
+// exts returns the extensions of the Go+ files, without leading dots, as a
+// comma-separated list.
+func (r *gopClassRegistry) exts() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return goputil.ExtsOf(r.classExts)
+}
@@ -14,8 +14,11 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"unicode/utf8"
 
+	"golang.org/x/tools/gop/goputil"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
 	"golang.org/x/tools/gopls/internal/lsp"
 	"golang.org/x/tools/gopls/internal/lsp/protocol"
 	"golang.org/x/tools/gopls/internal/lsp/source"
@@ -106,17 +109,9 @@ func (c *semtok) Run(ctx context.Context, args ...string) error {
 	if err != nil {
 		return err
 	}
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, args[0], buf, 0)
-	if err != nil {
-		log.Printf("parsing %s failed %v", args[0], err)
+	if err := semtokParse(args[0], buf); err != nil {
 		return err
 	}
-	tok := fset.File(f.Pos())
-	if tok == nil {
-		// can't happen; just parsed this file
-		return fmt.Errorf("can't find %s in fset", args[0])
-	}
 	colmap = protocol.NewMapper(uri, buf)
 	err = decorate(file.uri.Filename(), resp.Data)
 	if err != nil {
@@ -125,6 +120,36 @@ func (c *semtok) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
+// semtokParse checks that the file can be parsed, using the Go+ parser
+// for Go+ files (goxls), including the classfiles registered by the Go+
+// module of the file.
+func semtokParse(filename string, buf []byte) error {
+	fset := token.NewFileSet()
+	mod, _, err := goputil.LoadMod(filepath.Dir(filename))
+	if err != nil {
+		log.Printf("loading the Go+ module of %s failed %v", filename, err)
+	}
+	if goputil.FileKindOf(mod, filename) != goputil.FileUnknown {
+		// a Go+ script may have no package clause, so there is no
+		// position to look up.
+		if _, err := parserutil.ParseFileEx(mod, fset, filename, buf, 0); err != nil {
+			log.Printf("parsing %s failed %v", filename, err)
+			return err
+		}
+		return nil
+	}
+	f, err := parser.ParseFile(fset, filename, buf, 0)
+	if err != nil {
+		log.Printf("parsing %s failed %v", filename, err)
+		return err
+	}
+	if tok := fset.File(f.Pos()); tok == nil {
+		// can't happen; just parsed this file
+		return fmt.Errorf("can't find %s in fset", filename)
+	}
+	return nil
+}
+
 type mark struct {
 	line, offset int // 1-based, from RangeSpan
 	len          int // bytes, not runes
@@ -59,7 +59,7 @@ func (s *Server) gopCodeAction(
 	wantQuickFixes := want[protocol.QuickFix] && len(diagnostics) > 0
 
 	// Code actions requiring syntax information alone.
-	if wantQuickFixes || want[protocol.SourceOrganizeImports] || want[protocol.RefactorExtract] {
+	if wantQuickFixes || want[protocol.SourceOrganizeImports] || want[protocol.RefactorExtract] || want[protocol.RefactorRewrite] || want[protocol.SourceGopStyle] {
 		pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
 		if err != nil {
 			return nil, err
@@ -114,6 +114,30 @@ func (s *Server) gopCodeAction(
 			}
 			actions = append(actions, extractions...)
 		}
+
+		if want[protocol.RefactorExtract] || want[protocol.RefactorRewrite] {
+			moves, err := gopRefactorMove(ctx, snapshot, fh, pgf, params.Range, want)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, moves...)
+		}
+
+		if want[protocol.SourceGopStyle] && pgf.ParseErr == nil {
+			edits, err := source.GopStyle(ctx, snapshot, fh)
+			if err != nil {
+				return nil, err
+			}
+			if len(edits) > 0 {
+				actions = append(actions, protocol.CodeAction{
+					Title: "Convert to Go+ style",
+					Kind:  protocol.SourceGopStyle,
+					Edit: &protocol.WorkspaceEdit{
+						DocumentChanges: documentChanges(fh, edits),
+					},
+				})
+			}
+		}
 	}
 
 	var stubMethodsDiagnostics []protocol.Diagnostic
@@ -126,7 +150,7 @@ func (s *Server) gopCodeAction(
 	}
 
 	// Code actions requiring type information.
-	if len(stubMethodsDiagnostics) > 0 || want[protocol.RefactorRewrite] || want[protocol.GoTest] {
+	if len(stubMethodsDiagnostics) > 0 || want[protocol.RefactorRewrite] || want[protocol.RefactorInline] || want[protocol.GoTest] {
 		pkg, pgf, err := source.NarrowestPackageForGopFile(ctx, snapshot, fh.URI())
 		if err != nil {
 			return nil, err
@@ -182,6 +206,14 @@ func (s *Server) gopCodeAction(
 			actions = append(actions, rewrites...)
 		}
 
+		if want[protocol.RefactorInline] {
+			rewrites, err := gopRefactorInline(pkg, pgf, params.Range)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, rewrites...)
+		}
+
 		if want[protocol.GoTest] {
 			fixes, err := gopTest(ctx, snapshot, pkg, pgf, params.Range)
 			if err != nil {
@@ -249,6 +281,43 @@ func gopRefactorExtract(ctx context.Context, snapshot source.Snapshot, pgf *sour
 	return actions, nil
 }
 
+// gopRefactorMove returns the actions of the wanted kinds that move the
+// top-level declarations selected by rng into a new Go+ file (an extraction)
+// or an existing one (a rewrite).
+func gopRefactorMove(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, pgf *source.ParsedGopFile, rng protocol.Range, want map[protocol.CodeActionKind]bool) ([]protocol.CodeAction, error) {
+	// Avoid type checking if no declaration is selected.
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(source.GopSelectedDecls(pgf.File, start, end)) == 0 {
+		return nil, nil
+	}
+
+	pkg, pgf, err := source.NarrowestPackageForGopFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	moves, err := source.GopMoveDecls(ctx, snapshot, pkg, pgf, rng)
+	if err != nil {
+		return nil, err
+	}
+	var actions []protocol.CodeAction
+	for _, move := range moves {
+		if !want[move.Kind] {
+			continue
+		}
+		actions = append(actions, protocol.CodeAction{
+			Title: move.Title,
+			Kind:  move.Kind,
+			Edit: &protocol.WorkspaceEdit{
+				DocumentChanges: move.Changes,
+			},
+		})
+	}
+	return actions, nil
+}
+
 func gopRefactorRewrite(ctx context.Context, snapshot source.Snapshot, pkg source.Package, pgf *source.ParsedGopFile, fh source.FileHandle, rng protocol.Range) (_ []protocol.CodeAction, rerr error) {
 	// golang/go#61693: code actions were refactored to run outside of the
 	// analysis framework, but as a result they lost their panic recovery.
@@ -340,7 +409,43 @@ func gopRefactorRewrite(ctx context.Context, snapshot source.Snapshot, pkg sourc
 	return actions, nil
 }
 
+// gopRefactorInline returns inline actions available at the specified range.
+func gopRefactorInline(pkg source.Package, pgf *source.ParsedGopFile, rng protocol.Range) ([]protocol.CodeAction, error) {
+	var commands []protocol.Command
+
+	// If range is within call expression, offer inline action.
+	if _, fn, err := source.GopEnclosingStaticCall(pkg, pgf, rng); err == nil {
+		cmd, err := command.NewApplyFixCommand(fmt.Sprintf("Inline call to %s", fn.Name()), command.ApplyFixArgs{
+			URI:   protocol.URIFromSpanURI(pgf.URI),
+			Fix:   source.InlineCall,
+			Range: rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+
+	// Convert commands to actions.
+	var actions []protocol.CodeAction
+	for i := range commands {
+		actions = append(actions, protocol.CodeAction{
+			Title:   commands[i].Title,
+			Kind:    protocol.RefactorInline,
+			Command: &commands[i],
+		})
+	}
+	return actions, nil
+}
+
 func gopTest(ctx context.Context, snapshot source.Snapshot, pkg source.Package, pgf *source.ParsedGopFile, rng protocol.Range) ([]protocol.CodeAction, error) {
+	if test, ok, err := source.GopCaseTests(pkg, pgf); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return gopCaseTest(pgf, test, rng)
+	}
+
 	fns, err := source.GopTestsAndBenchmarks(ctx, snapshot, pkg, pgf)
 	if err != nil {
 		return nil, err
@@ -374,3 +479,23 @@ func gopTest(ctx context.Context, snapshot source.Snapshot, pkg source.Package,
 		Command: &cmd,
 	}}, nil
 }
+
+// gopCaseTest returns the code action that runs the test of a Go+ test
+// classfile with gop test, or only its subtests in rng, if any.
+func gopCaseTest(pgf *source.ParsedGopFile, test source.GopCaseTest, rng protocol.Range) ([]protocol.CodeAction, error) {
+	var subtests []string
+	for _, sub := range test.Subtests {
+		if protocol.Intersect(sub.Rng, rng) {
+			subtests = append(subtests, sub.Name)
+		}
+	}
+	cmd, err := source.GopCaseTestCommand("Run tests", pgf.URI, test.Name, subtests)
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.CodeAction{{
+		Title:   cmd.Title,
+		Kind:    protocol.GoTest,
+		Command: &cmd,
+	}}, nil
+}
@@ -34,6 +34,8 @@ func (s *Server) codeLens(ctx context.Context, params *protocol.CodeLensParams)
 		lenses = source.LensFuncs()
 	case source.Gop: // goxls: Go+
 		lenses = source.GopLensFuncs()
+	case source.GopMod: // goxls: gop.mod
+		lenses = mod.GopModLensFuncs()
 	default:
 		// Unsupported file kind for a code lens.
 		return nil, nil
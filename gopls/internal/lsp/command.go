@@ -157,6 +157,9 @@ func (c *commandHandler) run(ctx context.Context, cfg commandConfig, run command
 	if cfg.async {
 		go func() {
 			if err := runcmd(); err != nil {
+				// goxls: runcmd cancelled ctx when it returned, and a
+				// notification is never sent with a cancelled context.
+				ctx := xcontext.Detach(ctx)
 				if showMessageErr := c.s.client.ShowMessage(ctx, &protocol.ShowMessageParams{
 					Type:    protocol.Error,
 					Message: err.Error(),
@@ -553,7 +556,7 @@ func (c *commandHandler) Generate(ctx context.Context, args command.GenerateArgs
 		if err := deps.snapshot.RunGoCommandPiped(ctx, source.Normal, inv, er, stderr); err != nil {
 			return err
 		}
-		return nil
+		return c.gopGenerate(ctx, deps, args, er, stderr) // goxls: Go+
 	})
 }
 
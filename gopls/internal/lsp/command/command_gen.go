@@ -28,6 +28,7 @@ const (
 	GCDetails             Command = "gc_details"
 	Generate              Command = "generate"
 	GoGetPackage          Command = "go_get_package"
+	GopModRegister        Command = "gop_mod_register"
 	ListImports           Command = "list_imports"
 	ListKnownPackages     Command = "list_known_packages"
 	MemStats              Command = "mem_stats"
@@ -60,6 +61,7 @@ var Commands = []Command{
 	GCDetails,
 	Generate,
 	GoGetPackage,
+	GopModRegister,
 	ListImports,
 	ListKnownPackages,
 	MemStats,
@@ -138,6 +140,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.GoGetPackage(ctx, a0)
+	case "gopls.gop_mod_register":
+		var a0 GopModRegisterArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.GopModRegister(ctx, a0)
 	case "gopls.list_imports":
 		var a0 URIArg
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -364,6 +372,18 @@ func NewGoGetPackageCommand(title string, a0 GoGetPackageArgs) (protocol.Command
 	}, nil
 }
 
+func NewGopModRegisterCommand(title string, a0 GopModRegisterArgs) (protocol.Command, error) {
+	args, err := MarshalArgs(a0)
+	if err != nil {
+		return protocol.Command{}, err
+	}
+	return protocol.Command{
+		Title:     title,
+		Command:   "gopls.gop_mod_register",
+		Arguments: args,
+	}, nil
+}
+
 func NewListImportsCommand(title string, a0 URIArg) (protocol.Command, error) {
 	args, err := MarshalArgs(a0)
 	if err != nil {
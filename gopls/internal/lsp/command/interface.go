@@ -192,6 +192,12 @@ type Interface interface {
 
 	// RunGopCommand: run `gop <command> [args...]`
 	RunGopCommand(context.Context, RunGopCommandArgs) error
+
+	// GopModRegister: Register classfile frameworks
+	//
+	// Adds register directives for the given classfile framework modules to a
+	// gop.mod file.
+	GopModRegister(context.Context, GopModRegisterArgs) error
 }
 
 type RunTestsArgs struct {
@@ -512,3 +518,10 @@ type RunGopCommandArgs struct {
 	// Args for gop command arguments
 	Args []string
 }
+
+type GopModRegisterArgs struct {
+	// The gop.mod file to modify.
+	URI protocol.DocumentURI
+	// The module paths of the classfile frameworks to register.
+	Modules []string
+}
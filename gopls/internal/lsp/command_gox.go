@@ -5,12 +5,22 @@
 package lsp
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
 	"sort"
+	"strings"
 
 	"golang.org/x/tools/gop/ast/astutil"
 	"golang.org/x/tools/gopls/internal/goxls/parserutil"
 	"golang.org/x/tools/gopls/internal/lsp/command"
+	"golang.org/x/tools/gopls/internal/lsp/mod"
+	"golang.org/x/tools/gopls/internal/lsp/progress"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
 	"golang.org/x/tools/gopls/internal/lsp/source"
 	"golang.org/x/tools/internal/tokeninternal"
 )
@@ -52,5 +62,70 @@ func gopListImportsCmd(result *command.ListImportsResult, ctx context.Context, a
 }
 
 func (c *commandHandler) RunGopCommand(ctx context.Context, args command.RunGopCommandArgs) error {
+	return c.run(ctx, commandConfig{
+		async:       true, // need to be async to be cancellable
+		requireSave: true,
+		progress:    "Running gop " + args.Command,
+		forURI:      args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		if err := runGopCommand(ctx, deps, args); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			return fmt.Errorf("running gop %s failed: %w", args.Command, err)
+		}
+		return nil
+	})
+}
+
+// runGopCommand runs `gop <command> [args...]` in the directory args.URI,
+// streaming its output as log messages and progress reports.
+func runGopCommand(ctx context.Context, deps commandDeps, args command.RunGopCommandArgs) error {
+	// Keep the output so that it can be reported if the command fails.
+	buf := &bytes.Buffer{}
+	ew := progress.NewEventWriter(ctx, "gop")
+	out := io.MultiWriter(ew, progress.NewWorkDoneWriter(ctx, deps.work), buf)
+
+	cmd := exec.CommandContext(ctx, "gop", append([]string{args.Command}, args.Args...)...)
+	cmd.Dir = args.URI.SpanURI().Filename()
+	cmd.Env = append(os.Environ(), deps.snapshot.View().Options().EnvSlice()...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if output := strings.TrimSpace(buf.String()); output != "" {
+			return fmt.Errorf("%w\n%s", err, output)
+		}
+		return err
+	}
 	return nil
 }
+
+func (c *commandHandler) GopModRegister(ctx context.Context, args command.GopModRegisterArgs) error {
+	return c.run(ctx, commandConfig{
+		progress: "Registering classfile frameworks",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		edits, err := mod.GopModRegisterEdits(deps.fh, args.Modules)
+		if err != nil {
+			return err
+		}
+		if len(edits) == 0 {
+			return nil // already registered
+		}
+		response, err := c.s.client.ApplyEdit(ctx, &protocol.ApplyWorkspaceEditParams{
+			Edit: protocol.WorkspaceEdit{
+				DocumentChanges: documentChanges(deps.fh, edits),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !response.Applied {
+			return fmt.Errorf("edits not applied because of %s", response.FailureReason)
+		}
+		return nil
+	})
+}
@@ -49,6 +49,7 @@ const (
 	workSource
 	modCheckUpgradesSource
 	modVulncheckSource // source.Govulncheck + source.Vulncheck
+	gopModParseSource  // goxls: gop.mod
 )
 
 // A diagnosticReport holds results for a single diagnostic source.
@@ -111,6 +112,8 @@ func (d diagnosticSource) String() string {
 		return "FromCheckForUpgrades"
 	case modVulncheckSource:
 		return "FromModVulncheck"
+	case gopModParseSource: // goxls: gop.mod
+		return "FromGopModParse"
 	default:
 		return fmt.Sprintf("From?%d?", d)
 	}
@@ -305,6 +308,13 @@ func (s *Server) diagnose(ctx context.Context, snapshot source.Snapshot, analyze
 	}
 	store(modParseSource, "diagnosing go.mod file", modReports, modErr, true)
 
+	// goxls: Diagnose gop.mod file.
+	gopModReports, gopModErr := mod.GopModDiagnostics(ctx, snapshot)
+	if ctx.Err() != nil {
+		return
+	}
+	store(gopModParseSource, "diagnosing gop.mod file", gopModReports, gopModErr, true)
+
 	// Diagnose go.mod upgrades.
 	upgradeReports, upgradeErr := mod.UpgradeDiagnostics(ctx, snapshot)
 	if ctx.Err() != nil {
@@ -366,14 +376,16 @@ func (s *Server) diagnose(ctx context.Context, snapshot source.Snapshot, analyze
 	)
 	for _, m := range workspace {
 		var hasNonIgnored, hasOpenFile bool
-		// goxls: TODO - how about Go+ files?
-		for _, uri := range m.CompiledNongenGoFiles {
-			seen[uri] = struct{}{}
-			if !hasNonIgnored && !snapshot.IgnoredFile(uri) {
-				hasNonIgnored = true
-			}
-			if !hasOpenFile && snapshot.IsOpen(uri) {
-				hasOpenFile = true
+		// goxls: Go+ files
+		for _, uris := range [][]span.URI{m.CompiledNongenGoFiles, m.CompiledGopFiles} {
+			for _, uri := range uris {
+				seen[uri] = struct{}{}
+				if !hasNonIgnored && !snapshot.IgnoredFile(uri) {
+					hasNonIgnored = true
+				}
+				if !hasOpenFile && snapshot.IsOpen(uri) {
+					hasOpenFile = true
+				}
 			}
 		}
 		if hasNonIgnored {
@@ -472,6 +484,9 @@ func (s *Server) diagnosePkgs(ctx context.Context, snapshot source.Snapshot, toD
 
 	wg.Wait()
 
+	// goxls: move the go command errors of gop_autogen files to Go+ files
+	gopAutogenDiagnostics(ctx, snapshot, toDiagnose, pkgDiags)
+
 	// TODO(rfindley): remove the guards against snapshot.IsBuiltin, after the
 	// gopls@v0.12.0 release. Packages should not be producing diagnostics for
 	// the builtin file: I do not know why this logic existed previously.
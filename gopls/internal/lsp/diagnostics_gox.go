@@ -3,3 +3,222 @@
 // license that can be found in the LICENSE file.
 
 package lsp
+
+import (
+	"bytes"
+	"context"
+	"go/scanner"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/gop/packages"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/safetoken"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/event"
+)
+
+// gopAutogenDiagnostics moves the diagnostics of pkgDiags on gop_autogen.go
+// files, which are reported by the go command, to the Go+ files of
+// toDiagnose that the code they point to is generated from. The diagnostics
+// that can't be mapped to a Go+ file, such as the ones on the imports of a
+// generated file, are dropped: gop_autogen.go files aren't edited by users.
+func gopAutogenDiagnostics(ctx context.Context, snapshot source.Snapshot, toDiagnose map[source.PackageID]*source.Metadata, pkgDiags map[span.URI][]*source.Diagnostic) {
+	for uri, diags := range pkgDiags {
+		if !strings.HasPrefix(filepath.Base(uri.Filename()), "gop_autogen") {
+			continue
+		}
+		delete(pkgDiags, uri)
+
+		dir := filepath.Dir(uri.Filename())
+		var gopFiles []string
+		for _, m := range toDiagnose {
+			for _, gopURI := range m.CompiledGopFiles {
+				if filepath.Dir(gopURI.Filename()) == dir {
+					gopFiles = append(gopFiles, gopURI.Filename())
+				}
+			}
+		}
+		if len(gopFiles) == 0 {
+			continue
+		}
+		for _, diag := range diags {
+			gopDiag, err := gopAutogenDiagnostic(ctx, snapshot, diag, gopFiles)
+			if err != nil {
+				event.Error(ctx, "mapping diagnostic of gop_autogen.go", err)
+				continue
+			}
+			if gopDiag != nil {
+				pkgDiags[gopDiag.URI] = append(pkgDiags[gopDiag.URI], gopDiag)
+			}
+		}
+	}
+}
+
+// gopAutogenDiagnostic returns diag, a diagnostic on a gop_autogen.go file,
+// moved to the position in gopFiles it is generated from, or nil if there
+// is none.
+func gopAutogenDiagnostic(ctx context.Context, snapshot source.Snapshot, diag *source.Diagnostic, gopFiles []string) (*source.Diagnostic, error) {
+	fh, err := snapshot.ReadFile(ctx, diag.URI)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := protocol.NewMapper(diag.URI, content).RangeOffsets(diag.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scan the file to record the //line directives in tf.
+	fset := token.NewFileSet()
+	tf := fset.AddFile(diag.URI.Filename(), -1, len(content))
+	var s scanner.Scanner
+	s.Init(tf, content, nil, scanner.ScanComments)
+	var directives []int // lines of the //line directives
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.COMMENT && strings.HasPrefix(lit, "//line ") {
+			directives = append(directives, safetoken.Line(tf, pos))
+		}
+	}
+
+	startPos := packages.GopPositionOf(fset, tf.Pos(start), gopFiles)
+	endPos := packages.GopPositionOf(fset, tf.Pos(end), gopFiles)
+	if !gopContains(gopFiles, startPos.Filename) {
+		return nil, nil
+	}
+	gopURI := span.URIFromPath(startPos.Filename)
+	gopFH, err := snapshot.ReadFile(ctx, gopURI)
+	if err != nil {
+		return nil, err
+	}
+	gopContent, err := gopFH.Content()
+	if err != nil {
+		return nil, err
+	}
+	lines := bytes.Split(content, []byte("\n"))
+	gopLines := bytes.Split(gopContent, []byte("\n"))
+	startPos = gopInheritedPosition(fset, tf, lines, start, startPos, directives, gopFiles, gopLines)
+	gopStart := gopPositionOffset(gopContent, startPos)
+	gopEnd := gopStart
+	if endPos.Filename == startPos.Filename {
+		endPos = gopInheritedPosition(fset, tf, lines, end, endPos, directives, gopFiles, gopLines)
+		if offset := gopPositionOffset(gopContent, endPos); offset > gopStart {
+			gopEnd = offset
+		}
+	}
+	rng, err := protocol.NewMapper(gopURI, gopContent).OffsetRange(gopStart, gopEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	gopDiag := *diag
+	gopDiag.URI = gopURI
+	gopDiag.Range = rng
+	gopDiag.SuggestedFixes = nil // the fixes edit gop_autogen.go
+	return &gopDiag, nil
+}
+
+// gopInheritedPosition corrects pos, the position in the Go+ file of offset
+// in tf, when the line of offset has no //line directive of its own. Such a
+// line, like a //go:embed comment or the closing brace of a function, takes
+// its line from the last directive before it, counting the lines in between,
+// which is off when the Go+ compiler dropped blank lines. The lines after the
+// directive are counted on the non-blank lines of both files instead, up to
+// the line of the next directive.
+func gopInheritedPosition(fset *token.FileSet, tf *token.File, lines [][]byte, offset int, pos token.Position, directives []int, gopFiles []string, gopLines [][]byte) token.Position {
+	line := safetoken.Line(tf, tf.Pos(offset))
+	last, limit := -1, -1
+	for _, d := range directives {
+		if d >= line {
+			if d < tf.LineCount() {
+				if next := packages.GopPositionOf(fset, tf.LineStart(d+1), gopFiles); next.Filename == pos.Filename {
+					limit = next.Line
+				}
+			}
+			break
+		}
+		last = d
+	}
+	if last < 0 || last == line-1 || line > len(lines) {
+		return pos // no directive, or the line has its own
+	}
+	anchor := packages.GopPositionOf(fset, tf.LineStart(last+1), gopFiles)
+	if anchor.Filename != pos.Filename {
+		return pos
+	}
+
+	text, indent := gopLineText(lines[line-1])
+	lineStart := int(tf.LineStart(line)) - tf.Base()
+	if text == "" || offset-lineStart < indent {
+		return pos
+	}
+	n := 0 // the non-blank lines from the line after the directive to line
+	for l := last + 2; l <= line; l++ {
+		if text, _ := gopLineText(lines[l-1]); text != "" {
+			n++
+		}
+	}
+	gopLine := anchor.Line
+	for n > 0 {
+		gopLine++
+		if gopLine > len(gopLines) || limit >= 0 && gopLine >= limit {
+			return pos
+		}
+		if text, _ := gopLineText(gopLines[gopLine-1]); text != "" {
+			n--
+		}
+	}
+	_, gopIndent := gopLineText(gopLines[gopLine-1])
+	pos.Line = gopLine
+	pos.Column = gopIndent + offset - lineStart - indent + 1
+	return pos
+}
+
+// gopLineText returns the text of line without its leading and trailing
+// spaces, and the length of its indentation.
+func gopLineText(line []byte) (string, int) {
+	text := bytes.TrimLeft(line, " \t")
+	return string(bytes.TrimRight(text, " \t\r")), len(line) - len(text)
+}
+
+// gopPositionOffset returns the offset in content of pos. Its column is
+// clamped to its line, and an unknown column is the start of its line.
+func gopPositionOffset(content []byte, pos token.Position) int {
+	offset := 0
+	for line := 1; line < pos.Line; line++ {
+		i := bytes.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return len(content)
+		}
+		offset += i + 1
+	}
+	lineEnd := len(content)
+	if i := bytes.IndexByte(content[offset:], '\n'); i >= 0 {
+		lineEnd = offset + i
+	}
+	if pos.Column > 1 {
+		offset += pos.Column - 1
+	}
+	if offset > lineEnd {
+		offset = lineEnd
+	}
+	return offset
+}
+
+func gopContains(files []string, file string) bool {
+	for _, f := range files {
+		if f == file {
+			return true
+		}
+	}
+	return false
+}
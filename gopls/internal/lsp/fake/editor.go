@@ -282,6 +282,7 @@ func (e *Editor) initialize(ctx context.Context) error {
 	params.Capabilities.Workspace.WorkspaceEdit = &protocol.WorkspaceEditClientCapabilities{
 		ResourceOperations: []protocol.ResourceOperationKind{
 			"rename",
+			"create", // goxls: extract declarations to a new file
 		},
 	}
 
@@ -492,6 +493,7 @@ var defaultFileAssociations = map[string]*regexp.Regexp{
 	"go.mod":  regexp.MustCompile(`^go\.mod$`),
 	"go.sum":  regexp.MustCompile(`^go(\.work)?\.sum$`),
 	"go.work": regexp.MustCompile(`^go\.work$`),
+	"gop.mod": regexp.MustCompile(`^gop\.mod$`), // goxls: gop.mod
 	"gotmpl":  regexp.MustCompile(`^.*tmpl$`),
 }
 
@@ -874,6 +876,14 @@ func (e *Editor) ApplyQuickFixes(ctx context.Context, loc protocol.Location, dia
 func (e *Editor) ApplyCodeAction(ctx context.Context, action protocol.CodeAction) error {
 	if action.Edit != nil {
 		for _, change := range action.Edit.DocumentChanges {
+			// goxls: file creation, and edits of files that aren't open
+			if change.CreateFile != nil || change.TextDocumentEdit != nil &&
+				!e.HasBuffer(e.sandbox.Workdir.URIToPath(change.TextDocumentEdit.TextDocument.URI)) {
+				if err := e.applyDocumentChange(ctx, change); err != nil {
+					return err
+				}
+				continue
+			}
 			if change.TextDocumentEdit != nil {
 				path := e.sandbox.Workdir.URIToPath(change.TextDocumentEdit.TextDocument.URI)
 				if int32(e.buffers[path].version) != change.TextDocumentEdit.TextDocument.Version {
@@ -1310,7 +1320,12 @@ func (e *Editor) applyDocumentChange(ctx context.Context, change protocol.Docume
 	if change.TextDocumentEdit != nil {
 		return e.applyTextDocumentEdit(ctx, *change.TextDocumentEdit)
 	}
-	panic("Internal error: one of RenameFile or TextDocumentEdit must be set")
+	// goxls: file creation
+	if change.CreateFile != nil {
+		path := e.sandbox.Workdir.URIToPath(change.CreateFile.URI)
+		return e.sandbox.Workdir.WriteFile(ctx, path, "")
+	}
+	panic("Internal error: one of RenameFile, TextDocumentEdit or CreateFile must be set")
 }
 
 func (e *Editor) applyTextDocumentEdit(ctx context.Context, change protocol.TextDocumentEdit) error {
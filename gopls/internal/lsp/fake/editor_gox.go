@@ -0,0 +1,68 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+)
+
+// RangeFormatBuffer formats the range loc of a buffer.
+func (e *Editor) RangeFormatBuffer(ctx context.Context, loc protocol.Location) error {
+	if e.Server == nil {
+		return nil
+	}
+	path := e.sandbox.Workdir.URIToPath(loc.URI)
+	return e.formatBufferWith(ctx, path, func() ([]protocol.TextEdit, error) {
+		params := &protocol.DocumentRangeFormattingParams{Range: loc.Range}
+		params.TextDocument.URI = loc.URI
+		edits, err := e.Server.RangeFormatting(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("textDocument/rangeFormatting: %w", err)
+		}
+		return edits, nil
+	})
+}
+
+// OnTypeFormatBuffer formats a buffer as if ch had just been typed before
+// loc.
+func (e *Editor) OnTypeFormatBuffer(ctx context.Context, loc protocol.Location, ch string) error {
+	if e.Server == nil {
+		return nil
+	}
+	path := e.sandbox.Workdir.URIToPath(loc.URI)
+	return e.formatBufferWith(ctx, path, func() ([]protocol.TextEdit, error) {
+		params := &protocol.DocumentOnTypeFormattingParams{Position: loc.Range.Start, Ch: ch}
+		params.TextDocument.URI = loc.URI
+		edits, err := e.Server.OnTypeFormatting(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("textDocument/onTypeFormatting: %w", err)
+		}
+		return edits, nil
+	})
+}
+
+// formatBufferWith applies the formatting edits returned by format to the
+// buffer path.
+func (e *Editor) formatBufferWith(ctx context.Context, path string, format func() ([]protocol.TextEdit, error)) error {
+	e.mu.Lock()
+	version := e.buffers[path].version
+	e.mu.Unlock()
+	edits, err := format()
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if versionAfter := e.buffers[path].version; versionAfter != version {
+		return fmt.Errorf("before receipt of formatting edits, buffer version changed from %d to %d", version, versionAfter)
+	}
+	if len(edits) == 0 {
+		return nil
+	}
+	return e.editBufferLocked(ctx, path, edits)
+}
@@ -17,13 +17,23 @@ func (s *Server) foldingRange(ctx context.Context, params *protocol.FoldingRange
 	ctx, done := event.Start(ctx, "lsp.Server.foldingRange", tag.URI.Of(params.TextDocument.URI))
 	defer done()
 
-	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.Go)
+	// goxls: Go+
+	// snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.Go)
+	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.UnknownKind)
 	defer release()
 	if !ok {
 		return nil, err
 	}
 
-	ranges, err := source.FoldingRange(ctx, snapshot, fh, snapshot.View().Options().LineFoldingOnly)
+	var ranges []*source.FoldingRangeInfo
+	switch snapshot.View().FileKind(fh) {
+	case source.Go:
+		ranges, err = source.FoldingRange(ctx, snapshot, fh, snapshot.View().Options().LineFoldingOnly)
+	case source.Gop: // goxls: Go+
+		ranges, err = source.GopFoldingRange(ctx, snapshot, fh, snapshot.View().Options().LineFoldingOnly)
+	default:
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3,3 +3,48 @@
 // license that can be found in the LICENSE file.
 
 package lsp
+
+import (
+	"context"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/event/tag"
+)
+
+// gopOnTypeFormattingTriggers are the characters after which Go+ files are
+// formatted as the user types: the first one is the newline.
+var gopOnTypeFormattingTriggers = []string{"\n", "}"}
+
+func (s *Server) rangeFormatting(ctx context.Context, params *protocol.DocumentRangeFormattingParams) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "lsp.Server.rangeFormatting", tag.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.UnknownKind)
+	defer release()
+	if !ok {
+		return nil, err
+	}
+	switch snapshot.View().FileKind(fh) {
+	case source.Gop:
+		return source.FormatGopRange(ctx, snapshot, fh, params.Range)
+	}
+	return nil, nil
+}
+
+func (s *Server) onTypeFormatting(ctx context.Context, params *protocol.DocumentOnTypeFormattingParams) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "lsp.Server.onTypeFormatting", tag.URI.Of(params.TextDocument.URI))
+	defer done()
+
+	snapshot, fh, ok, release, err := s.beginFileRequest(ctx, params.TextDocument.URI, source.UnknownKind)
+	defer release()
+	if !ok {
+		return nil, err
+	}
+	switch snapshot.View().FileKind(fh) {
+	case source.Gop:
+		return source.FormatGopOnType(ctx, snapshot, fh, params.Position, params.Ch)
+	}
+	return nil, nil
+}
@@ -155,6 +155,14 @@ See https://github.com/golang/go/issues/45732 for more information.`,
 			ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
 				Commands: nonNilSliceString(options.SupportedCommands),
 			},
+			// goxls: range and on-type formatting of Go+ files
+			DocumentRangeFormattingProvider: &protocol.Or_ServerCapabilities_documentRangeFormattingProvider{
+				Value: true,
+			},
+			DocumentOnTypeFormattingProvider: &protocol.DocumentOnTypeFormattingOptions{
+				FirstTriggerCharacter: gopOnTypeFormattingTriggers[0],
+				MoreTriggerCharacter:  gopOnTypeFormattingTriggers[1:],
+			},
 			FoldingRangeProvider:      &protocol.Or_ServerCapabilities_foldingRangeProvider{Value: true},
 			HoverProvider:             &protocol.Or_ServerCapabilities_hoverProvider{Value: true},
 			DocumentHighlightProvider: &protocol.Or_ServerCapabilities_documentHighlightProvider{Value: true},
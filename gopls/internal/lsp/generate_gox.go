@@ -0,0 +1,210 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gop/langserver"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/command"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
+)
+
+const ggDirective = "//go:generate"
+
+// gopGenerate completes a go generate command for the Go+ packages in
+// args.Dir: go generate only sees the directives of Go files, so gopGenerate
+// runs the //go:generate directives of the Go+ files itself. It then
+// regenerates the gop_autogen.go files of the packages and reloads their
+// metadata.
+func (c *commandHandler) gopGenerate(ctx context.Context, deps commandDeps, args command.GenerateArgs, stdout, stderr io.Writer) error {
+	dir := args.Dir.SpanURI().Filename()
+	gopDirs, err := gopPackageDirs(dir, args.Recursive, c.s.isGopFile)
+	if err != nil || len(gopDirs) == 0 {
+		return err
+	}
+	env := append(os.Environ(), deps.snapshot.View().Options().EnvSlice()...)
+	for _, gopDir := range gopDirs {
+		if err := gopGenerateDir(ctx, gopDir, c.s.isGopFile, env, stdout, stderr); err != nil {
+			return err
+		}
+	}
+
+	pattern := dir
+	if args.Recursive {
+		pattern += "/..."
+	}
+	if err := langserver.GenGo(ctx, pattern); err != nil {
+		return err
+	}
+	var mods []source.FileModification
+	for _, gopDir := range gopDirs {
+		mods = append(mods, source.FileModification{
+			URI:    span.URIFromPath(filepath.Join(gopDir, "gop_autogen.go")),
+			Action: source.InvalidateMetadata,
+		})
+	}
+	return c.s.didModifyFiles(ctx, mods, FromRegenerateGop)
+}
+
+// gopPackageDirs returns the directories that contain Go+ files, as reported
+// by isGopFile: dir itself and, if recursive is set, its subdirectories. As with gop go, directories
+// whose names begin with "_" or "." are skipped.
+func gopPackageDirs(dir string, recursive bool, isGopFile func(span.URI) bool) (dirs []string, err error) {
+	seen := make(map[string]bool)
+	err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if name := d.Name(); !recursive || strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isGopFile(span.URIFromPath(path)) {
+			if gopDir := filepath.Dir(path); !seen[gopDir] {
+				seen[gopDir] = true
+				dirs = append(dirs, gopDir)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// gopGenerateDir runs the //go:generate directives of the Go+ files in dir,
+// as reported by isGopFile, in file name order.
+func gopGenerateDir(ctx context.Context, dir string, isGopFile func(span.URI) bool, env []string, stdout, stderr io.Writer) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isGopFile(span.URIFromPath(filepath.Join(dir, entry.Name()))) {
+			continue
+		}
+		if err := gopGenerateFile(ctx, filepath.Join(dir, entry.Name()), env, stdout, stderr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gopGenerateFile runs the //go:generate directives of a Go+ file the way go
+// generate does for Go files: each directive is run in the directory of the
+// file, with $GOFILE, $GOLINE, $GOPACKAGE and $DOLLAR set.
+func gopGenerateFile(ctx context.Context, filename string, env []string, stdout, stderr io.Writer) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(src, []byte(ggDirective)) {
+		return nil
+	}
+	f, err := parserutil.ParseFile(token.NewFileSet(), filename, src, parser.PackageClauseOnly)
+	if err != nil {
+		return err
+	}
+	pkgName := "main" // Go+ files may omit the package clause
+	if f.Name != nil {
+		pkgName = f.Name.Name
+	}
+
+	for i, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if !strings.HasPrefix(line, ggDirective+" ") && !strings.HasPrefix(line, ggDirective+"\t") {
+			continue
+		}
+		vars := map[string]string{
+			"GOFILE":    filepath.Base(filename),
+			"GOLINE":    strconv.Itoa(i + 1),
+			"GOPACKAGE": pkgName,
+			"DOLLAR":    "$",
+		}
+		words, err := gopGenerateWords(line[len(ggDirective):])
+		if err != nil {
+			return fmt.Errorf("%s:%d: %v", filename, i+1, err)
+		}
+		for j, word := range words {
+			words[j] = os.Expand(word, func(name string) string {
+				if v, ok := vars[name]; ok {
+					return v
+				}
+				return os.Getenv(name)
+			})
+		}
+		fmt.Fprintln(stderr, strings.Join(words, " "))
+
+		cmd := exec.CommandContext(ctx, words[0], words[1:]...)
+		cmd.Dir = filepath.Dir(filename)
+		cmd.Env = env
+		for name, v := range vars {
+			cmd.Env = append(cmd.Env, name+"="+v)
+		}
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%s:%d: running %q: %v", filename, i+1, words[0], err)
+		}
+	}
+	return nil
+}
+
+// gopGenerateWords splits the arguments of a //go:generate directive into
+// words. As with go generate, a double-quoted Go string is a single word.
+func gopGenerateWords(line string) ([]string, error) {
+	var words []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if line[0] == '"' {
+			end := 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, errors.New("unterminated quoted string")
+			}
+			word, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, word)
+			line = line[end+1:]
+			continue
+		}
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			end = len(line)
+		}
+		words = append(words, line[:end])
+		line = line[end:]
+	}
+	if len(words) == 0 {
+		return nil, errors.New("no arguments to directive")
+	}
+	return words, nil
+}
@@ -36,6 +36,8 @@ func (s *Server) hover(ctx context.Context, params *protocol.HoverParams) (*prot
 		return template.Hover(ctx, snapshot, fh, params.Position)
 	case source.Work:
 		return work.Hover(ctx, snapshot, fh, params.Position)
+	case source.GopMod: // goxls: gop.mod
+		return mod.GopModHover(ctx, snapshot, fh, params.Position)
 	}
 	return nil, nil
 }
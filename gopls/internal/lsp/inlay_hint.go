@@ -28,6 +28,8 @@ func (s *Server) inlayHint(ctx context.Context, params *protocol.InlayHintParams
 		return mod.InlayHint(ctx, snapshot, fh, params.Range)
 	case source.Go:
 		return source.InlayHint(ctx, snapshot, fh, params.Range)
+	case source.Gop: // goxls: Go+
+		return source.GopInlayHint(ctx, snapshot, fh, params.Range)
 	}
 	return nil, nil
 }
@@ -35,8 +35,12 @@ func (s *Server) documentLink(ctx context.Context, params *protocol.DocumentLink
 	switch snapshot.View().FileKind(fh) {
 	case source.Mod:
 		links, err = modLinks(ctx, snapshot, fh)
+	case source.GopMod: // goxls: gop.mod
+		links, err = gopModLinks(ctx, snapshot, fh)
 	case source.Go:
 		links, err = goLinks(ctx, snapshot, fh)
+	case source.Gop: // goxls: Go+
+		links, err = gopLinks(ctx, snapshot, fh)
 	}
 	// Don't return errors for document links.
 	if err != nil {
@@ -0,0 +1,238 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	gopenv "github.com/goplus/gop/env"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/mod/modfile"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/safetoken"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+// gopModPath is the path of the Go+ module. Its packages, such as
+// github.com/goplus/gop/builtin, are provided by the Go+ installation.
+const gopModPath = "github.com/goplus/gop"
+
+// gopModLinks returns the set of hyperlink annotations for the specified
+// gop.mod file: the package paths of its project, import and register
+// directives, and the links contained in its comments.
+func gopModLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]protocol.DocumentLink, error) {
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseLax(fh.URI().Filename(), content, nil)
+	if err != nil {
+		return nil, err
+	}
+	m := protocol.NewMapper(fh.URI(), content)
+
+	var links []protocol.DocumentLink
+	addLink := func(line *modfile.Line, pkgPath string) error {
+		// Don't link to modules matching GOPRIVATE.
+		if snapshot.View().IsGoPrivatePath(pkgPath) {
+			return nil
+		}
+		start, end := line.Start.Byte, line.End.Byte
+		i := bytes.Index(content[start:end], []byte(pkgPath))
+		if i == -1 {
+			return nil
+		}
+		target := source.BuildLink(snapshot.View().Options().LinkTarget, pkgPath, "")
+		l, err := toProtocolLink(m, target, start+i, start+i+len(pkgPath))
+		if err != nil {
+			return err
+		}
+		links = append(links, l)
+		return nil
+	}
+	for _, proj := range f.Projects {
+		for _, pkgPath := range proj.PkgPaths {
+			if err := addLink(proj.Syntax, pkgPath); err != nil {
+				return nil, err
+			}
+		}
+		for _, imp := range proj.Import {
+			if err := addLink(imp.Syntax, imp.Path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The register directive is not interpreted by modfile.ParseLax.
+	urlRegexp := snapshot.View().Options().URLRegexp
+	for _, stmt := range f.Syntax.Stmt {
+		switch stmt := stmt.(type) {
+		case *modfile.Line:
+			if len(stmt.Token) > 1 && stmt.Token[0] == "register" {
+				for _, tok := range stmt.Token[1:] {
+					if err := addLink(stmt, gopModUnquote(tok)); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case *modfile.LineBlock:
+			if len(stmt.Token) == 1 && stmt.Token[0] == "register" {
+				for _, line := range stmt.Line {
+					for _, tok := range line.Token {
+						if err := addLink(line, gopModUnquote(tok)); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+
+		// Get all the links that are contained in the comments of the file.
+		comments := stmt.Comment()
+		if comments == nil {
+			continue
+		}
+		for _, section := range [][]modfile.Comment{comments.Before, comments.Suffix, comments.After} {
+			for _, comment := range section {
+				l, err := findLinksInString(urlRegexp, comment.Token, comment.Start.Byte, m)
+				if err != nil {
+					return nil, err
+				}
+				links = append(links, l...)
+			}
+		}
+	}
+	return links, nil
+}
+
+// gopModUnquote returns tok with the quotes of a quoted gop.mod token
+// removed.
+func gopModUnquote(tok string) string {
+	if s, err := strconv.Unquote(tok); err == nil {
+		return s
+	}
+	return tok
+}
+
+// gopLinks returns the set of hyperlink annotations for the specified Go+
+// file.
+func gopLinks(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]protocol.DocumentLink, error) {
+	view := snapshot.View()
+
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []protocol.DocumentLink
+
+	// Create links for import specs.
+	if view.Options().ImportShortcut.ShowLinks() {
+
+		// If links are to pkg.go.dev, append module version suffixes.
+		// This requires the import map from the package metadata. Ignore errors.
+		var depsByImpPath map[source.ImportPath]source.PackageID
+		if strings.ToLower(view.Options().LinkTarget) == "pkg.go.dev" {
+			if meta, err := source.NarrowestMetadataForFile(ctx, snapshot, fh.URI()); err == nil {
+				depsByImpPath = meta.DepsByImpPath
+			}
+		}
+
+		for _, imp := range pgf.File.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || path == "" {
+				continue // bad import
+			}
+			importPath := source.ImportPath(path)
+			// Don't link to modules matching GOPRIVATE.
+			if view.IsGoPrivatePath(string(importPath)) {
+				continue
+			}
+
+			urlPath := string(importPath)
+
+			// For pkg.go.dev, append module version suffix to package import path.
+			if m := snapshot.Metadata(depsByImpPath[importPath]); m != nil && m.Module != nil && m.Module.Path != "" && m.Module.Version != "" {
+				urlPath = strings.Replace(urlPath, m.Module.Path, m.Module.Path+"@"+m.Module.Version, 1)
+			} else if depsByImpPath != nil && gopenv.Installed() {
+				urlPath = gopLinkPath(urlPath, gopenv.Version())
+			}
+
+			start, end, err := safetoken.Offsets(pgf.Tok, imp.Path.Pos(), imp.Path.End())
+			if err != nil {
+				return nil, err
+			}
+			targetURL := source.BuildLink(view.Options().LinkTarget, urlPath, "")
+			// Account for the quotation marks in the positions.
+			l, err := toProtocolLink(pgf.Mapper, targetURL, start+len(`"`), end-len(`"`))
+			if err != nil {
+				return nil, err
+			}
+			links = append(links, l)
+		}
+	}
+
+	urlRegexp := snapshot.View().Options().URLRegexp
+
+	// Gather links found in string literals.
+	var str []*ast.BasicLit
+	ast.Inspect(pgf.File, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ImportSpec:
+			return false // don't process import strings again
+		case *ast.BasicLit:
+			if n.Kind == token.STRING {
+				str = append(str, n)
+			}
+		}
+		return true
+	})
+	for _, s := range str {
+		strOffset, err := safetoken.Offset(pgf.Tok, s.Pos())
+		if err != nil {
+			return nil, err
+		}
+		l, err := findLinksInString(urlRegexp, s.Value, strOffset, pgf.Mapper)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l...)
+	}
+
+	// Gather links found in comments.
+	for _, commentGroup := range pgf.File.Comments {
+		for _, comment := range commentGroup.List {
+			commentOffset, err := safetoken.Offset(pgf.Tok, comment.Pos())
+			if err != nil {
+				return nil, err
+			}
+			l, err := findLinksInString(urlRegexp, comment.Text, commentOffset, pgf.Mapper)
+			if err != nil {
+				return nil, err
+			}
+			links = append(links, l...)
+		}
+	}
+
+	return links, nil
+}
+
+// gopLinkPath returns the link path of a package of the Go+ installation,
+// which is not required by go.mod: it is linked at the version of the Go+
+// installation, gopVersion. Other package paths are returned unchanged.
+func gopLinkPath(pkgPath, gopVersion string) string {
+	if pkgPath != gopModPath && !strings.HasPrefix(pkgPath, gopModPath+"/") {
+		return pkgPath
+	}
+	if !strings.HasPrefix(gopVersion, "v") || strings.ContainsAny(gopVersion, " /") {
+		return pkgPath // not a release, e.g. a development build
+	}
+	return gopModPath + "@" + gopVersion + pkgPath[len(gopModPath):]
+}
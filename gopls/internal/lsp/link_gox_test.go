@@ -0,0 +1,23 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import "testing"
+
+func TestGopLinkPath(t *testing.T) {
+	for _, test := range []struct {
+		pkgPath, gopVersion, want string
+	}{
+		{"github.com/goplus/gop/builtin", "v1.2.6", "github.com/goplus/gop@v1.2.6/builtin"},
+		{"github.com/goplus/gop", "v1.2.6", "github.com/goplus/gop@v1.2.6"},
+		{"github.com/goplus/gop/builtin", "devel", "github.com/goplus/gop/builtin"},
+		{"github.com/goplus/gopx", "v1.2.6", "github.com/goplus/gopx"},
+		{"fmt", "v1.2.6", "fmt"},
+	} {
+		if got := gopLinkPath(test.pkgPath, test.gopVersion); got != test.want {
+			t.Errorf("gopLinkPath(%q, %q) = %q, want %q", test.pkgPath, test.gopVersion, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"context"
+
+	"golang.org/x/tools/gopls/internal/lsp/command"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+// gopModPath is the path of the Go+ module, whose classfiles are always
+// registered.
+const gopModPath = "github.com/goplus/gop"
+
+// GopModLensFuncs returns the supported lensFuncs for gop.mod files.
+func GopModLensFuncs() map[command.Command]source.LensFunc {
+	return map[command.Command]source.LensFunc{
+		command.GopModRegister: gopModRegisterLenses,
+	}
+}
+
+// gopModRegisterLenses returns a lens for each classfile framework required
+// by go.mod that is not registered, neither by the gop.mod file nor by a
+// //gop:class comment in go.mod.
+func gopModRegisterLenses(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]protocol.CodeLens, error) {
+	pm, err := parseGopMod(fh)
+	if err != nil || pm.Syntax == nil {
+		return nil, err
+	}
+	gomod, err := parseGoModOf(ctx, snapshot, fh.URI())
+	if err != nil || gomod.File == nil {
+		return nil, nil // errors reported by ModParseDiagnostics
+	}
+	registered := make(map[string]bool)
+	for _, reg := range pm.registers() {
+		registered[reg.Path] = true
+	}
+	var paths []string
+	for _, req := range gomod.File.Require {
+		if path := req.Mod.Path; path != gopModPath && !registered[path] && !isClassRequire(req) {
+			paths = append(paths, path)
+		}
+	}
+	mods, err := listGopClassMods(ctx, snapshot, fh.URI(), paths)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := protocol.URIFromSpanURI(fh.URI())
+	var lenses []protocol.CodeLens
+	for _, mod := range mods {
+		if len(mod.Projects) == 0 {
+			continue
+		}
+		cmd, err := command.NewGopModRegisterCommand("Register "+mod.Path, command.GopModRegisterArgs{
+			URI:     uri,
+			Modules: []string{mod.Path},
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Put the lenses at the top of the file.
+		lenses = append(lenses, protocol.CodeLens{Command: &cmd})
+	}
+	return lenses, nil
+}
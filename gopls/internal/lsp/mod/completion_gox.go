@@ -0,0 +1,137 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/internal/event"
+)
+
+// GopModCompletion returns completions of the module paths of classfile
+// frameworks in the register directives of a gop.mod file.
+func GopModCompletion(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, position protocol.Position) (*protocol.CompletionList, error) {
+	ctx, done := event.Start(ctx, "mod.GopModCompletion")
+	defer done()
+
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	m := protocol.NewMapper(fh.URI(), content)
+	cursor, err := m.PositionOffset(position)
+	if err != nil {
+		return nil, fmt.Errorf("computing cursor offset: %w", err)
+	}
+
+	// The file is usually incomplete while the user types, so find the
+	// register directive the cursor is in textually.
+	if !inGopModRegister(content, cursor) {
+		return &protocol.CompletionList{}, nil
+	}
+	start, end := cursor, cursor
+	for start > 0 && !isGopModSpace(content[start-1]) {
+		start--
+	}
+	for end < len(content) && !isGopModSpace(content[end]) {
+		end++
+	}
+	rng, err := m.OffsetRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	prefix := string(content[start:cursor])
+
+	// Offer the known frameworks, and the frameworks required by go.mod,
+	// that are not yet registered.
+	registered := make(map[string]bool)
+	if pm, err := parseGopMod(fh); err == nil {
+		for _, reg := range pm.registers() {
+			registered[reg.Path] = true
+		}
+	}
+	candidates := make(map[string]string) // module path -> detail
+	for _, path := range knownClassfileMods {
+		candidates[path] = "classfile framework"
+	}
+	if gomod, err := parseGoModOf(ctx, snapshot, fh.URI()); err == nil && gomod.File != nil {
+		var paths []string
+		for _, req := range gomod.File.Require {
+			paths = append(paths, req.Mod.Path)
+		}
+		mods, err := listGopClassMods(ctx, snapshot, fh.URI(), paths)
+		if err != nil {
+			event.Error(ctx, "listing classfile frameworks", err)
+		}
+		for _, mod := range mods {
+			if len(mod.Projects) > 0 {
+				candidates[mod.Path] = "classfile framework " + mod.Version
+			}
+		}
+	}
+
+	var completions []string
+	for path := range candidates {
+		if !registered[path] && strings.HasPrefix(path, prefix) {
+			completions = append(completions, path)
+		}
+	}
+	sort.Strings(completions)
+
+	items := []protocol.CompletionItem{} // must be a slice
+	for _, c := range completions {
+		items = append(items, protocol.CompletionItem{
+			Label:    c,
+			Kind:     protocol.ModuleCompletion,
+			Detail:   candidates[c],
+			TextEdit: &protocol.TextEdit{Range: rng, NewText: c},
+		})
+	}
+	return &protocol.CompletionList{Items: items}, nil
+}
+
+// inGopModRegister reports whether the offset cursor of the gop.mod file
+// content is in the arguments of a register directive, either on a register
+// line or in a register block.
+func inGopModRegister(content []byte, cursor int) bool {
+	lineStart := bytes.LastIndexByte(content[:cursor], '\n') + 1
+	inBlock := false
+	for _, line := range bytes.Split(content[:lineStart], []byte("\n")) {
+		fields := strings.Fields(string(stripGopModComment(line)))
+		switch {
+		case len(fields) == 2 && fields[0] == "register" && fields[1] == "(":
+			inBlock = true
+		case len(fields) > 0 && strings.HasPrefix(fields[0], ")"):
+			inBlock = false
+		}
+	}
+	before := strings.Fields(string(content[lineStart:cursor]))
+	if inBlock {
+		// The module path is the first and only token of a block line.
+		return len(before) == 0 || len(before) == 1 && !isGopModSpace(content[cursor-1])
+	}
+	if len(before) == 0 || before[0] != "register" {
+		return false
+	}
+	return len(before) > 1 || isGopModSpace(content[cursor-1])
+}
+
+// stripGopModComment returns line without its comment, if any.
+func stripGopModComment(line []byte) []byte {
+	if i := bytes.Index(line, []byte("//")); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+func isGopModSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
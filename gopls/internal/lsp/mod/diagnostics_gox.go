@@ -0,0 +1,110 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/event"
+)
+
+// GopModDiagnostics returns diagnostics for the gop.mod files of the modules
+// in the workspace.
+func GopModDiagnostics(ctx context.Context, snapshot source.Snapshot) (map[span.URI][]*source.Diagnostic, error) {
+	ctx, done := event.Start(ctx, "mod.GopModDiagnostics", source.SnapshotLabels(snapshot)...)
+	defer done()
+
+	reports := make(map[span.URI][]*source.Diagnostic)
+	for _, modURI := range snapshot.ModFiles() {
+		uri := span.URIFromPath(filepath.Join(filepath.Dir(modURI.Filename()), "gop.mod"))
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fh.Content(); err != nil {
+			continue // no gop.mod file
+		}
+		diagnostics, err := gopModDiagnostics(ctx, snapshot, fh)
+		if err != nil {
+			return nil, err
+		}
+		reports[uri] = diagnostics
+	}
+	return reports, nil
+}
+
+// gopModDiagnostics reports the errors in the gop.mod file fh: its syntax
+// errors, unknown directives, and registered modules that the go.mod file
+// next to it doesn't require.
+func gopModDiagnostics(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle) ([]*source.Diagnostic, error) {
+	pm, err := parseGopMod(fh)
+	if err != nil {
+		return nil, err
+	}
+	diagnostics := pm.ParseErrors
+	if pm.Syntax == nil {
+		return diagnostics, nil
+	}
+
+	verbError := func(line *modfile.Line, msg string) error {
+		rng, err := pm.Mapper.OffsetRange(line.Start.Byte, line.Start.Byte+len(line.Token[0]))
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, pm.diagnostic(rng, protocol.SeverityError, source.GopModError, msg))
+		return nil
+	}
+	for _, stmt := range pm.Syntax.Stmt {
+		var line *modfile.Line
+		block := false
+		switch stmt := stmt.(type) {
+		case *modfile.Line:
+			line = stmt
+		case *modfile.LineBlock:
+			line, block = &modfile.Line{Start: stmt.Start, Token: stmt.Token}, true
+		default:
+			continue
+		}
+		if len(line.Token) == 0 {
+			continue
+		}
+		switch verb := line.Token[0]; {
+		case !gopModDirectives[verb]:
+			err = verbError(line, fmt.Sprintf("unknown directive: %s", verb))
+		case verb == "register" && len(line.Token) == 1 && !block:
+			err = verbError(line, "usage: register module/path...")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	regs := pm.registers()
+	if len(regs) == 0 {
+		return diagnostics, nil
+	}
+	gomod, err := parseGoModOf(ctx, snapshot, fh.URI())
+	if err != nil {
+		return diagnostics, nil // errors reported by ModParseDiagnostics
+	}
+	for _, reg := range regs {
+		if requiredMod(gomod, reg.Path) != nil {
+			continue
+		}
+		rng, err := pm.Mapper.OffsetRange(reg.Start, reg.End)
+		if err != nil {
+			return nil, err
+		}
+		msg := fmt.Sprintf("%s is not required by go.mod", reg.Path)
+		diagnostics = append(diagnostics, pm.diagnostic(rng, protocol.SeverityWarning, source.GopModError, msg))
+	}
+	return diagnostics, nil
+}
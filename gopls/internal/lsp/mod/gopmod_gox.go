@@ -0,0 +1,298 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+// This file defines the parsing and the module queries shared by the gop.mod
+// features: diagnostics, hover, completion and code lenses.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	gopmodfile "github.com/goplus/mod/modfile"
+	qerrors "github.com/qiniu/x/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/span"
+)
+
+// gopModDirectives are the directives of a gop.mod file. Besides the
+// directives that declare classfiles, register registers the classfile
+// frameworks of the modules it lists.
+var gopModDirectives = map[string]bool{
+	"gop":      true,
+	"project":  true,
+	"class":    true,
+	"import":   true,
+	"register": true,
+}
+
+// knownClassfileMods are the module paths of well-known classfile frameworks.
+// Completion offers them even if go.mod doesn't require them yet.
+var knownClassfileMods = []string{
+	"github.com/goplus/spx",
+	"github.com/goplus/yap",
+}
+
+// A parsedGopMod is a parsed gop.mod file.
+type parsedGopMod struct {
+	URI    span.URI
+	Mapper *protocol.Mapper
+	Syntax *modfile.FileSyntax // nil if the file has syntax errors
+
+	// ParseErrors are the syntax errors of the file, or the errors in the
+	// arguments of its directives.
+	ParseErrors []*source.Diagnostic
+}
+
+// parseGopMod parses the gop.mod file fh. Like the go command, it ignores
+// unknown directives.
+func parseGopMod(fh source.FileHandle) (*parsedGopMod, error) {
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	filename := fh.URI().Filename()
+	pm := &parsedGopMod{
+		URI:    fh.URI(),
+		Mapper: protocol.NewMapper(fh.URI(), content),
+	}
+	f, err := modfile.ParseLax(filename, content, nil)
+	if err != nil {
+		errs, ok := err.(modfile.ErrorList)
+		if !ok {
+			return nil, fmt.Errorf("unexpected parse error type %v", err)
+		}
+		for _, e := range errs {
+			rng, err := pm.Mapper.OffsetRange(e.Pos.Byte, e.Pos.Byte)
+			if err != nil {
+				return nil, err
+			}
+			pm.ParseErrors = append(pm.ParseErrors, pm.diagnostic(rng, protocol.SeverityError, source.ParseError, e.Err.Error()))
+		}
+		return pm, nil
+	}
+	pm.Syntax = f.Syntax
+
+	if _, err := gopmodfile.ParseLax(filename, content, nil); err != nil {
+		var errs gopmodfile.ErrorList
+		if !errors.As(err, &errs) {
+			return nil, fmt.Errorf("unexpected parse error type %v", err)
+		}
+		for _, e := range errs {
+			e, ok := e.(*gopmodfile.Error)
+			if !ok {
+				continue
+			}
+			rng, err := pm.lineRange(e.Pos.Byte)
+			if err != nil {
+				return nil, err
+			}
+			pm.ParseErrors = append(pm.ParseErrors, pm.diagnostic(rng, protocol.SeverityError, source.ParseError, qerrors.Summary(e.Err)))
+		}
+	}
+	return pm, nil
+}
+
+func (pm *parsedGopMod) diagnostic(rng protocol.Range, severity protocol.DiagnosticSeverity, src source.DiagnosticSource, msg string) *source.Diagnostic {
+	return &source.Diagnostic{
+		URI:      pm.URI,
+		Range:    rng,
+		Severity: severity,
+		Source:   src,
+		Message:  msg,
+	}
+}
+
+// lineRange returns the range from offset to the end of its line.
+func (pm *parsedGopMod) lineRange(offset int) (protocol.Range, error) {
+	end := len(pm.Mapper.Content)
+	if offset < end {
+		if i := bytes.IndexByte(pm.Mapper.Content[offset:], '\n'); i >= 0 {
+			end = offset + i
+		}
+	}
+	if trimmed := len(bytes.TrimRight(pm.Mapper.Content[:end], "\r\t ")); trimmed > offset {
+		end = trimmed
+	} else {
+		end = offset
+	}
+	return pm.Mapper.OffsetRange(offset, end)
+}
+
+// A gopModRegister is a module path listed by a register directive.
+type gopModRegister struct {
+	Path       string
+	Start, End int // offsets of the path, including any quotes
+}
+
+// registers returns the module paths listed by the register directives of
+// pm, in order.
+func (pm *parsedGopMod) registers() []gopModRegister {
+	if pm.Syntax == nil {
+		return nil
+	}
+	var regs []gopModRegister
+	add := func(line *modfile.Line, start int, toks []string) {
+		end := line.End.Byte
+		for _, tok := range toks {
+			i := bytes.Index(pm.Mapper.Content[start:end], []byte(tok))
+			if i < 0 {
+				continue // can't happen
+			}
+			start += i
+			regs = append(regs, gopModRegister{
+				Path:  unquoteGopModToken(tok),
+				Start: start,
+				End:   start + len(tok),
+			})
+			start += len(tok)
+		}
+	}
+	for _, stmt := range pm.Syntax.Stmt {
+		switch stmt := stmt.(type) {
+		case *modfile.Line:
+			if len(stmt.Token) > 1 && stmt.Token[0] == "register" {
+				// Skip the verb: it may contain a module path, such as "reg".
+				add(stmt, stmt.Start.Byte+len("register"), stmt.Token[1:])
+			}
+		case *modfile.LineBlock:
+			if len(stmt.Token) == 1 && stmt.Token[0] == "register" {
+				for _, line := range stmt.Line {
+					add(line, line.Start.Byte, line.Token)
+				}
+			}
+		}
+	}
+	return regs
+}
+
+// unquoteGopModToken returns tok with the quotes of a quoted gop.mod token
+// removed.
+func unquoteGopModToken(tok string) string {
+	if s, err := strconv.Unquote(tok); err == nil {
+		return s
+	}
+	return tok
+}
+
+// parseGoModOf parses the go.mod file next to the gop.mod file uri.
+func parseGoModOf(ctx context.Context, snapshot source.Snapshot, uri span.URI) (*source.ParsedModule, error) {
+	modURI := span.URIFromPath(filepath.Join(filepath.Dir(uri.Filename()), "go.mod"))
+	fh, err := snapshot.ReadFile(ctx, modURI)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.ParseMod(ctx, fh)
+}
+
+// requiredMod returns the requirement of pm that provides the module or
+// package path, if any.
+func requiredMod(pm *source.ParsedModule, path string) *modfile.Require {
+	if pm == nil || pm.File == nil {
+		return nil
+	}
+	for _, req := range pm.File.Require {
+		if p := req.Mod.Path; path == p || strings.HasPrefix(path, p+"/") {
+			return req
+		}
+	}
+	return nil
+}
+
+// isClassRequire reports whether req registers the classfile frameworks of
+// its module by a //gop:class comment, the way go.mod files register them.
+func isClassRequire(req *modfile.Require) bool {
+	if line := req.Syntax; line != nil {
+		for _, c := range line.Suffix {
+			text := strings.TrimLeft(strings.TrimPrefix(c.Token, "//"), " \t")
+			if strings.HasPrefix(text, "gop:class") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A gopClassMod describes a module that may provide classfile frameworks.
+type gopClassMod struct {
+	Path     string
+	Version  string
+	Err      string                // error finding the module, eg. if it isn't required
+	Projects []*gopmodfile.Project // classfile frameworks declared by its gop.mod
+}
+
+// listGopClassMods lists the module paths in the directory of the gop.mod
+// file uri, and reads the classfile frameworks that the modules declare.
+// Modules that are not in the module cache are not downloaded and have no
+// projects.
+func listGopClassMods(ctx context.Context, snapshot source.Snapshot, uri span.URI, paths []string) ([]*gopClassMod, error) {
+	listed, err := snapshot.GopListModules(ctx, uri, paths)
+	if err != nil {
+		return nil, err
+	}
+	var mods []*gopClassMod
+	for _, m := range listed {
+		mod := &gopClassMod{Path: m.Path, Version: m.Version, Err: m.Err}
+		if m.Dir != "" {
+			fh, err := snapshot.ReadFile(ctx, span.URIFromPath(filepath.Join(m.Dir, "gop.mod")))
+			if err != nil {
+				return nil, err
+			}
+			if content, err := fh.Content(); err == nil {
+				if f, err := gopmodfile.ParseLax(fh.URI().Filename(), content, nil); err == nil {
+					mod.Projects = f.Projects
+				}
+			}
+		}
+		mods = append(mods, mod)
+	}
+	return mods, nil
+}
+
+// GopModRegisterEdits returns the edits to the gop.mod file fh that register
+// the classfile frameworks of the given modules. Modules that are already
+// registered are ignored.
+func GopModRegisterEdits(fh source.FileHandle, modules []string) ([]protocol.TextEdit, error) {
+	pm, err := parseGopMod(fh)
+	if err != nil {
+		return nil, err
+	}
+	if pm.Syntax == nil {
+		return nil, fmt.Errorf("%s has syntax errors", fh.URI().Filename())
+	}
+	registered := make(map[string]bool)
+	for _, reg := range pm.registers() {
+		registered[reg.Path] = true
+	}
+	var b strings.Builder
+	content := pm.Mapper.Content
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	added := false
+	for _, path := range modules {
+		if registered[path] {
+			continue
+		}
+		registered[path] = true
+		fmt.Fprintf(&b, "register %s\n", modfile.AutoQuote(path))
+		added = true
+	}
+	if !added {
+		return nil, nil
+	}
+	rng, err := pm.Mapper.OffsetRange(len(content), len(content))
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.TextEdit{{Range: rng, NewText: b.String()}}, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/internal/event"
+)
+
+// GopModHover returns hover information for the module path of a register
+// directive of a gop.mod file: the version of the module, and the classfile
+// frameworks it provides.
+func GopModHover(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, position protocol.Position) (*protocol.Hover, error) {
+	ctx, done := event.Start(ctx, "mod.GopModHover")
+	defer done()
+
+	pm, err := parseGopMod(fh)
+	if err != nil {
+		return nil, fmt.Errorf("parsing gop.mod: %w", err)
+	}
+	offset, err := pm.Mapper.PositionOffset(position)
+	if err != nil {
+		return nil, fmt.Errorf("computing cursor position: %w", err)
+	}
+
+	// Confirm that the cursor is at the position of a registered module.
+	var reg *gopModRegister
+	regs := pm.registers()
+	for i := range regs {
+		if regs[i].Start <= offset && offset <= regs[i].End {
+			reg = &regs[i]
+			break
+		}
+	}
+	if reg == nil {
+		return nil, nil
+	}
+
+	mods, err := listGopClassMods(ctx, snapshot, fh.URI(), []string{reg.Path})
+	if err != nil {
+		return nil, err
+	}
+	if len(mods) != 1 {
+		return nil, nil
+	}
+	rng, err := pm.Mapper.OffsetRange(reg.Start, reg.End)
+	if err != nil {
+		return nil, err
+	}
+	options := snapshot.View().Options()
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  options.PreferredContentFormat,
+			Value: formatGopClassMod(mods[0], options),
+		},
+		Range: rng,
+	}, nil
+}
+
+// formatGopClassMod formats the classfile frameworks of mod for hover.
+func formatGopClassMod(mod *gopClassMod, options *source.Options) string {
+	var b strings.Builder
+	header := mod.Path
+	if mod.Version != "" {
+		header += "@" + mod.Version
+	}
+	b.WriteString(formatHeader(header, options))
+	switch {
+	case mod.Err != "":
+		b.WriteString(mod.Err)
+	case len(mod.Projects) == 0:
+		b.WriteString("not a classfile framework")
+	default:
+		var lines []string
+		for _, proj := range mod.Projects {
+			if proj.Class != "" {
+				lines = append(lines, fmt.Sprintf("project %s %s %s", proj.Ext, proj.Class, strings.Join(proj.PkgPaths, " ")))
+			}
+			for _, work := range proj.Works {
+				lines = append(lines, fmt.Sprintf("class %s %s", work.Ext, work.Class))
+			}
+		}
+		if options.PreferredContentFormat == protocol.Markdown {
+			b.WriteString("```\n" + strings.Join(lines, "\n") + "\n```")
+		} else {
+			b.WriteString(strings.Join(lines, "\n"))
+		}
+	}
+	return b.String()
+}
@@ -7,5 +7,8 @@ package protocol
 // Custom code actions that aren't explicitly stated in LSP
 const (
 	GoTest CodeActionKind = "goTest"
+	// goxls: SourceGopStyle converts Go-style code to idiomatic Go+, like
+	// gop fmt --smart.
+	SourceGopStyle CodeActionKind = "source.gopStyle"
 	// TODO: Add GoGenerate, RegenerateCgo etc.
 )
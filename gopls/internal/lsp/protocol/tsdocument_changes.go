@@ -14,6 +14,7 @@ import (
 type DocumentChanges struct {
 	TextDocumentEdit *TextDocumentEdit
 	RenameFile       *RenameFile
+	CreateFile       *CreateFile // goxls: extract declarations to a new file
 }
 
 func (d *DocumentChanges) UnmarshalJSON(data []byte) error {
@@ -28,6 +29,12 @@ func (d *DocumentChanges) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, d.TextDocumentEdit)
 	}
 
+	// goxls: file creation
+	if m["kind"] == "create" {
+		d.CreateFile = new(CreateFile)
+		return json.Unmarshal(data, d.CreateFile)
+	}
+
 	d.RenameFile = new(RenameFile)
 	return json.Unmarshal(data, d.RenameFile)
 }
@@ -37,6 +44,8 @@ func (d *DocumentChanges) MarshalJSON() ([]byte, error) {
 		return json.Marshal(d.TextDocumentEdit)
 	} else if d.RenameFile != nil {
 		return json.Marshal(d.RenameFile)
+	} else if d.CreateFile != nil {
+		return json.Marshal(d.CreateFile)
 	}
 	return nil, fmt.Errorf("Empty DocumentChanges union value")
 }
@@ -4,13 +4,18 @@
 
 package regtest
 
-import "golang.org/x/tools/gopls/internal/lsp/fake"
+import (
+	"golang.org/x/tools/gopls/internal/lsp/fake"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
 
 type runConfig struct {
 	editor    fake.EditorConfig
 	sandbox   fake.SandboxConfig
 	modes     Mode
 	skipHooks bool
+
+	optionsHook func(*source.Options) // goxls: see OptionsHook
 }
 
 func defaultConfig() runConfig {
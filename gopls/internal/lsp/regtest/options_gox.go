@@ -0,0 +1,31 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regtest
+
+import "golang.org/x/tools/gopls/internal/lsp/source"
+
+// OptionsHook configures the options of the gopls sessions of the test with
+// hook, after the options hook of the runner, for instance to install the
+// GopFormat hook. The Forwarded and SeparateProcess modes share a server
+// between tests, so it has no effect in these modes.
+func OptionsHook(hook func(*source.Options)) RunOption {
+	return optionSetter(func(opts *runConfig) {
+		opts.optionsHook = hook
+	})
+}
+
+// serverOptionsHook returns the options hook of the gopls sessions of the
+// test, given the options hook of the runner.
+func (c *runConfig) serverOptionsHook(runnerHook func(*source.Options)) func(*source.Options) {
+	if c.optionsHook == nil {
+		return runnerHook
+	}
+	return func(o *source.Options) {
+		if runnerHook != nil {
+			runnerHook(o)
+		}
+		c.optionsHook(o)
+	}
+}
@@ -212,7 +212,7 @@ func (r *Runner) Run(t *testing.T, files string, test TestFunc, opts ...RunOptio
 				}
 			}()
 
-			ss := tc.getServer(r.OptionsHook)
+			ss := tc.getServer(config.serverOptionsHook(r.OptionsHook)) // goxls: see OptionsHook
 
 			framer := jsonrpc2.NewRawStream
 			ls := &loggingFramer{}
@@ -0,0 +1,25 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regtest
+
+import "golang.org/x/tools/gopls/internal/lsp/protocol"
+
+// RangeFormatBuffer formats the range loc of an editor buffer, calling
+// t.Fatal on any error.
+func (e *Env) RangeFormatBuffer(loc protocol.Location) {
+	e.T.Helper()
+	if err := e.Editor.RangeFormatBuffer(e.Ctx, loc); err != nil {
+		e.T.Fatal(err)
+	}
+}
+
+// OnTypeFormatBuffer formats an editor buffer as if ch had just been typed
+// before loc, calling t.Fatal on any error.
+func (e *Env) OnTypeFormatBuffer(loc protocol.Location, ch string) {
+	e.T.Helper()
+	if err := e.Editor.OnTypeFormatBuffer(e.Ctx, loc, ch); err != nil {
+		e.T.Fatal(err)
+	}
+}
@@ -34,6 +34,10 @@ func (s *Server) selectionRange(ctx context.Context, params *protocol.SelectionR
 		return nil, err
 	}
 
+	if snapshot.View().FileKind(fh) == source.Gop { // goxls: Go+
+		return gopSelectionRange(ctx, snapshot, fh, params.Positions)
+	}
+
 	pgf, err := snapshot.ParseGo(ctx, fh, source.ParseFull)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,100 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gop/ast/astutil"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+// gopSelectionRange is the Go+ version of selectionRange.
+//
+// In addition to the Go syntax, the enclosing path may contain Go+ nodes
+// such as lambdas, comprehensions and command-style calls. Statements of a
+// script or class file belong to an implicit "main" function that spans
+// all the top-level statements; its body has no braces and no range of
+// its own.
+func gopSelectionRange(ctx context.Context, snapshot source.Snapshot, fh source.FileHandle, positions []protocol.Position) ([]protocol.SelectionRange, error) {
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]protocol.SelectionRange, len(positions))
+	for i, protocolPos := range positions {
+		pos, err := pgf.PositionPos(protocolPos)
+		if err != nil {
+			return nil, err
+		}
+
+		path, _ := astutil.PathEnclosingInterval(pgf.File, pos, pos)
+
+		tail := &result[i] // tail of the Parent linked list, built head first
+
+		first := true
+		for _, node := range path {
+			if !gopHasSelectionRange(node) {
+				continue
+			}
+			rng, err := pgf.PosRange(node.Pos(), gopSelectionEnd(node))
+			if err != nil {
+				return nil, err
+			}
+			// A command-style call and its statement share the same range.
+			if !first && rng == tail.Range {
+				continue
+			}
+
+			// Add node to tail.
+			if !first {
+				tail.Parent = &protocol.SelectionRange{}
+				tail = tail.Parent
+			}
+			tail.Range = rng
+			first = false
+		}
+	}
+
+	return result, nil
+}
+
+// gopHasSelectionRange reports whether n has a source range. The braceless
+// body of the implicit "main" function of a script or class file has none.
+func gopHasSelectionRange(n ast.Node) bool {
+	if n, ok := n.(*ast.BlockStmt); ok {
+		return n.Lbrace.IsValid()
+	}
+	return n.Pos().IsValid()
+}
+
+// gopSelectionEnd returns the end of n, excluding the whitespace that
+// follows the last argument of a command-style call ending n.
+func gopSelectionEnd(n ast.Node) token.Pos {
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		if num := len(n.Args); n.IsCommand() && num != 0 {
+			return n.Args[num-1].End()
+		}
+	case *ast.ExprStmt:
+		return gopSelectionEnd(n.X)
+	case *ast.FuncDecl:
+		if n.Shadow && n.Body != nil {
+			if num := len(n.Body.List); num != 0 {
+				return gopSelectionEnd(n.Body.List[num-1])
+			}
+		}
+	case *ast.File:
+		if num := len(n.Decls); num != 0 {
+			return gopSelectionEnd(n.Decls[num-1])
+		}
+	}
+	return n.End()
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+)
+
+// A function declared without a body, such as one implemented in assembly,
+// ends with its signature.
+func TestGopSelectionEndBodylessFunc(t *testing.T) {
+	const src = `package a
+
+func f() int
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "a.gop", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := file.Decls[len(file.Decls)-1].(*ast.FuncDecl)
+	if fn.Body != nil {
+		t.Fatalf("f has a body")
+	}
+	if got, want := gopSelectionEnd(file), fn.End(); got != want {
+		t.Errorf("gopSelectionEnd = %v, want %v", fset.Position(got), fset.Position(want))
+	}
+}
@@ -88,6 +88,9 @@ func (s *Server) computeSemanticTokens(ctx context.Context, td protocol.TextDocu
 		}
 		return template.SemanticTokens(ctx, snapshot, fh.URI(), add, data)
 	}
+	if kind == source.Gop { // goxls: Go+
+		return s.gopComputeSemanticTokens(ctx, snapshot, fh.URI(), rng)
+	}
 	if kind != source.Go {
 		return nil, nil
 	}
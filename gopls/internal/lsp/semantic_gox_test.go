@@ -0,0 +1,72 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gopls/internal/lsp/cache"
+	"golang.org/x/tools/gopls/internal/span"
+)
+
+// ast.Walk doesn't know the matrix literals of Go+, so the semantic tokens of
+// their elements are reported by the inspector itself.
+func TestGopSemanticMatrix(t *testing.T) {
+	const src = `row := [4, 5, 6]
+echo [
+	1, 2, 3
+	row...
+]
+`
+	checkGopSemanticItems(t, src, []gopSemanticItem{
+		{2, 1, 1, tokNumber},   // 2
+		{3, 1, 3, tokVariable}, // row
+		{3, 4, 3, tokOperator}, // ...
+	})
+}
+
+func TestGopSemanticStringAndErrWrap(t *testing.T) {
+	const src = `name := "world"
+println "hello ${name}!"
+n := strconv.Atoi("12")!
+`
+	checkGopSemanticItems(t, src, []gopSemanticItem{
+		{1, 8, 9, tokString},    // "hello ${
+		{1, 17, 4, tokVariable}, // name
+		{1, 21, 3, tokString},   // }!"
+		{2, 23, 1, tokOperator}, // !
+	})
+}
+
+type gopSemanticItem struct {
+	line, start, len uint32
+	typ              tokenType
+}
+
+// checkGopSemanticItems checks that the semantic tokens of the Go+ source src,
+// computed without type information, include want.
+func checkGopSemanticItems(t *testing.T, src string, want []gopSemanticItem) {
+	t.Helper()
+	ctx := context.Background()
+	pgf, _ := cache.ParseGopSrc(ctx, nil, token.NewFileSet(), span.URIFromPath("/a/main.gop"), []byte(src), parser.ParseComments, false)
+	e := &gopEncoded{encoded: encoded{ctx: ctx}, pgf: pgf}
+	if err := e.init(); err != nil {
+		t.Fatal(err)
+	}
+	e.semantics()
+
+	got := make(map[gopSemanticItem]bool)
+	for _, x := range e.items {
+		got[gopSemanticItem{x.line, x.start, x.len, x.typeStr}] = true
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("missing semantic token %+v in %+v", w, e.items)
+		}
+	}
+}
@@ -3,3 +3,20 @@
 // license that can be found in the LICENSE file.
 
 package lsp
+
+import (
+	"path/filepath"
+
+	"golang.org/x/tools/gop/goputil"
+	"golang.org/x/tools/gopls/internal/span"
+)
+
+// isGopFile reports whether uri is a Go+ file of the view containing it,
+// whose workspace modules may register classfiles with custom extensions.
+func (s *Server) isGopFile(uri span.URI) bool {
+	view, err := s.session.ViewOf(uri)
+	if err != nil {
+		return goputil.FileKind(filepath.Ext(uri.Filename())) != goputil.FileUnknown
+	}
+	return view.IsGopFile(uri)
+}
@@ -6,6 +6,7 @@ package source
 
 import (
 	"context"
+	"go/constant"
 	"go/types"
 	"log"
 	"path/filepath"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
 	"golang.org/x/tools/gopls/internal/goxls"
 	"golang.org/x/tools/gopls/internal/goxls/parserutil"
 	"golang.org/x/tools/gopls/internal/lsp/command"
@@ -90,7 +93,7 @@ func gopRunTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) (
 func GopTestsAndBenchmarks(ctx context.Context, snapshot Snapshot, pkg Package, pgf *ParsedGopFile) (testFns, error) {
 	var out testFns
 
-	if !strings.HasSuffix(pgf.URI.Filename(), "_test.go") {
+	if !strings.HasSuffix(pgf.URI.Filename(), "_test.gop") {
 		return out, nil
 	}
 
@@ -156,7 +159,36 @@ func gopMatchTestFunc(fn *ast.FuncDecl, pkg Package, nameRe *regexp.Regexp, para
 }
 
 func gopGenerateCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
-	return nil, nil // goxls: todo
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	const ggDirective = "//go:generate"
+	for _, c := range pgf.File.Comments {
+		for _, l := range c.List {
+			if !strings.HasPrefix(l.Text, ggDirective) {
+				continue
+			}
+			rng, err := pgf.PosRange(l.Pos(), l.Pos()+token.Pos(len(ggDirective)))
+			if err != nil {
+				return nil, err
+			}
+			dir := protocol.URIFromSpanURI(span.URIFromPath(filepath.Dir(fh.URI().Filename())))
+			nonRecursiveCmd, err := command.NewGenerateCommand("run go generate", command.GenerateArgs{Dir: dir, Recursive: false})
+			if err != nil {
+				return nil, err
+			}
+			recursiveCmd, err := command.NewGenerateCommand("run go generate ./...", command.GenerateArgs{Dir: dir, Recursive: true})
+			if err != nil {
+				return nil, err
+			}
+			return []protocol.CodeLens{
+				{Range: rng, Command: &recursiveCmd},
+				{Range: rng, Command: &nonRecursiveCmd},
+			}, nil
+		}
+	}
+	return nil, nil
 }
 
 func gopToggleDetailsCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
@@ -182,6 +214,9 @@ func gopToggleDetailsCodeLens(ctx context.Context, snapshot Snapshot, fh FileHan
 
 func gopCommandCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
 	filename := fh.URI().Filename()
+	if strings.HasSuffix(filename, "test.gox") {
+		return gopCaseTestCodeLens(ctx, snapshot, fh)
+	}
 	if strings.HasSuffix(filename, "_test.go") || strings.HasSuffix(filename, "_test.gop") {
 		return nil, nil
 	}
@@ -206,3 +241,139 @@ func gopCommandCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) (
 	}
 	return nil, nil
 }
+
+// gopCaseTestCodeLens returns the code lenses that run the tests of a Go+ test
+// classfile with gop test: one at the top of the file for the whole case, and
+// one for each of its subtests.
+//
+// There are no debug lenses: as for the run test lenses of Go tests, the
+// server has no command to start a debugger, and debugging a test is left to
+// the editor, which runs it with its own debug adapter.
+func gopCaseTestCodeLens(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.CodeLens, error) {
+	pkg, pgf, err := NarrowestPackageForGopFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, err
+	}
+	test, ok, err := GopCaseTests(pkg, pgf)
+	if err != nil || !ok {
+		return nil, err
+	}
+	rng := protocol.Range{Start: test.Rng.Start, End: test.Rng.Start}
+	cmd, err := GopCaseTestCommand("run test", fh.URI(), test.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+	codeLens := []protocol.CodeLens{{Range: rng, Command: &cmd}}
+	for _, sub := range test.Subtests {
+		rng := protocol.Range{Start: sub.Rng.Start, End: sub.Rng.Start}
+		cmd, err := GopCaseTestCommand("run test", fh.URI(), test.Name, []string{sub.Name})
+		if err != nil {
+			return nil, err
+		}
+		codeLens = append(codeLens, protocol.CodeLens{Range: rng, Command: &cmd})
+	}
+	return codeLens, nil
+}
+
+// GopCaseTest describes the test of a Go+ test classfile, such as
+// foo_test.gox.
+type GopCaseTest struct {
+	testFn            // the test function that gop generates for the case
+	Subtests []testFn // subtests run by the case, named as passed to Run
+}
+
+// GopCaseTests returns the test of pgf, if it is a Go+ test classfile. The
+// subtests are those that the body of the case runs by calling the Run method
+// of test.Case with a constant name, eg. `run "sum", t => { ... }`.
+func GopCaseTests(pkg Package, pgf *ParsedGopFile) (test GopCaseTest, ok bool, err error) {
+	name, ok := parserutil.GetTestName(pgf.File, pgf.URI.Filename())
+	if !ok {
+		return
+	}
+	rng, err := pgf.PosRange(pgf.File.Pos(), pgf.File.End())
+	if err != nil {
+		return
+	}
+	test.testFn = testFn{name, rng}
+
+	info := pkg.GopTypesInfo()
+	entry := pgf.File.ShadowEntry
+	if info == nil || entry == nil || entry.Body == nil {
+		return
+	}
+	ast.Inspect(entry.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.LambdaExpr, *ast.LambdaExpr2:
+			return false // nested subtests are not supported
+		case *ast.CallExpr:
+			if len(n.Args) == 0 || !gopIsCaseRun(info, n.Fun) {
+				return true
+			}
+			tv := info.Types[n.Args[0]]
+			if tv.Value == nil || tv.Value.Kind() != constant.String {
+				return true
+			}
+			rng, err := pgf.NodeRange(n)
+			if err != nil {
+				return true
+			}
+			test.Subtests = append(test.Subtests, testFn{constant.StringVal(tv.Value), rng})
+		}
+		return true
+	})
+	return
+}
+
+// gopIsCaseRun reports whether fun refers to the Run method of test.Case,
+// which runs a subtest.
+func gopIsCaseRun(info *typesutil.Info, fun ast.Expr) bool {
+	var id *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return false
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Name() != "Run" {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	typ := recv.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == gopTestPkgPath && obj.Name() == "Case"
+}
+
+// gopTestPkgPath is the path of the package that Go+ test classfiles
+// are based on.
+const gopTestPkgPath = "github.com/goplus/gop/test"
+
+// GopCaseTestCommand returns a command that runs test, the test of the Go+
+// test classfile uri, with gop test. If subtests is not empty, it runs only
+// those subtests of test.
+func GopCaseTestCommand(title string, uri span.URI, test string, subtests []string) (protocol.Command, error) {
+	pattern := "^" + regexp.QuoteMeta(test) + "$"
+	if len(subtests) > 0 {
+		subs := make([]string, len(subtests))
+		for i, sub := range subtests {
+			// The testing package rewrites spaces in subtest names.
+			subs[i] = regexp.QuoteMeta(strings.ReplaceAll(sub, " ", "_"))
+		}
+		pattern += "/^(" + strings.Join(subs, "|") + ")$"
+	}
+	dir := protocol.URIFromSpanURI(span.URIFromPath(filepath.Dir(uri.Filename())))
+	args := command.RunGopCommandArgs{URI: dir, Command: "test", Args: []string{"-run", pattern}}
+	return command.NewRunGopCommandCommand(title, args)
+}
@@ -4,17 +4,123 @@
 
 package completion
 
-import "log"
+import (
+	"go/types"
+	"math"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+)
 
 // wantLabelCompletion returns true if we want (only) label
 // completions at the position.
 func (c *gopCompleter) wantLabelCompletion() labelType {
-	// goxls: TODO
+	if _, ok := c.path[0].(*ast.Ident); ok && len(c.path) > 1 {
+		// We want a label if we are an *ast.Ident child of a statement
+		// that accepts a label, e.g. "break Lo<>".
+		return gopTakesLabel(c.path[1])
+	}
+
+	return labelNone
+}
+
+// gopTakesLabel returns the corresponding labelType if n is a statement
+// that accepts a label, otherwise labelNone.
+func gopTakesLabel(n ast.Node) labelType {
+	if bs, ok := n.(*ast.BranchStmt); ok {
+		switch bs.Tok {
+		case token.BREAK:
+			return labelBreak
+		case token.CONTINUE:
+			return labelContinue
+		case token.GOTO:
+			return labelGoto
+		}
+	}
 	return labelNone
 }
 
 // labels adds completion items for labels defined in the enclosing
 // function.
 func (c *gopCompleter) labels(lt labelType) {
-	log.Panicln("todo")
+	// The statements of a Go+ script are the body of an implicit main
+	// function, for which there is no enclosingFunc.
+	body := gopLabelScope(c.path)
+	if body == nil {
+		return
+	}
+
+	addLabel := func(score float64, l *ast.LabeledStmt) {
+		labelObj := c.pkg.GopTypesInfo().ObjectOf(l.Label)
+		if labelObj == nil {
+			labelObj = types.NewLabel(l.Label.Pos(), c.pkg.GetTypes(), l.Label.Name)
+		}
+		c.deepState.enqueue(candidate{obj: labelObj, score: score})
+	}
+
+	switch lt {
+	case labelBreak, labelContinue:
+		// "break" and "continue" only accept labels from enclosing statements.
+
+		for i, p := range c.path {
+			switch p := p.(type) {
+			case *ast.FuncLit, *ast.LambdaExpr2:
+				// Labels are function scoped, so don't continue out of functions.
+				return
+			case *ast.LabeledStmt:
+				switch p.Stmt.(type) {
+				case *ast.ForStmt, *ast.RangeStmt, *ast.ForPhraseStmt:
+					// Loop labels can be used for "break" or "continue".
+					addLabel(highScore*math.Pow(.99, float64(i)), p)
+				case *ast.SwitchStmt, *ast.SelectStmt, *ast.TypeSwitchStmt:
+					// Switch and select labels can be used only for "break".
+					if lt == labelBreak {
+						addLabel(highScore*math.Pow(.99, float64(i)), p)
+					}
+				}
+			}
+		}
+	case labelGoto:
+		// Goto accepts any label in the same function not in a nested
+		// block. It also doesn't take labels that would jump across
+		// variable definitions, but ignore that case for now.
+		ast.Inspect(body, func(n ast.Node) bool {
+			if n == nil {
+				return false
+			}
+
+			switch n := n.(type) {
+			// Only search into block-like nodes enclosing our "goto".
+			// This prevents us from finding labels in nested blocks.
+			case *ast.BlockStmt, *ast.CommClause, *ast.CaseClause:
+				for _, p := range c.path {
+					if n == p {
+						return true
+					}
+				}
+				return false
+			case *ast.LabeledStmt:
+				addLabel(highScore, n)
+			}
+
+			return true
+		})
+	}
+}
+
+// gopLabelScope returns the body of the innermost function in path, which is
+// the scope of labels. It may be the body of a function literal, a
+// block-bodied lambda, or the implicit main function of a script.
+func gopLabelScope(path []ast.Node) *ast.BlockStmt {
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return n.Body
+		case *ast.LambdaExpr2:
+			return n.Body
+		case *ast.FuncDecl:
+			return n.Body
+		}
+	}
+	return nil
 }
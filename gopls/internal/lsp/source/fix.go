@@ -44,6 +44,7 @@ const (
 	ExtractMethod     = "extract_method"
 	InvertIfCondition = "invert_if_condition"
 	AddEmbedImport    = "add_embed_import"
+	InlineCall        = "inline_call" // goxls: Go+ only, see source/inline_gox.go
 )
 
 // suggestedFixes maps a suggested fix command id to its handler.
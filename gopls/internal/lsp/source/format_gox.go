@@ -10,12 +10,11 @@ import (
 	"fmt"
 	"strings"
 
-	"path/filepath"
-
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/format"
 	"github.com/goplus/gop/parser"
 	"github.com/goplus/gop/token"
+	xformat "github.com/goplus/gop/x/format"
 	"golang.org/x/tools/gop/goputil"
 	"golang.org/x/tools/gopls/internal/goxls/imports"
 	"golang.org/x/tools/gopls/internal/goxls/parserutil"
@@ -39,15 +38,24 @@ func FormatGop(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protoco
 	if err != nil {
 		return nil, err
 	}
+	formatted, err := gopFormatFile(ctx, snapshot, fh, pgf)
+	if err != nil {
+		return nil, err
+	}
+	return gopComputeTextEdits(ctx, snapshot, pgf, formatted)
+}
+
+// gopFormatFile returns the formatted source of the Go+ file fh.
+func gopFormatFile(ctx context.Context, snapshot Snapshot, fh FileHandle, pgf *ParsedGopFile) (string, error) {
 	// Even if this file has parse errors, it might still be possible to format it.
 	// Using format.Node on an AST with errors may result in code being modified.
 	// Attempt to format the source of this file instead.
 	if pgf.ParseErr != nil {
-		formatted, err := formatGopSource(ctx, fh)
+		formatted, err := formatGopSource(ctx, snapshot, fh)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		return gopComputeTextEdits(ctx, snapshot, pgf, string(formatted))
+		return string(formatted), nil
 	}
 
 	// format.Node changes slightly from one release to another, so the version
@@ -57,15 +65,16 @@ func FormatGop(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protoco
 	buf := &bytes.Buffer{}
 	fset := tokeninternal.FileSetFor(pgf.Tok)
 	if err := format.Node(buf, fset, pgf.File); err != nil {
-		return nil, err
+		return "", err
 	}
 	formatted := buf.String()
 
-	// Apply additional formatting, if any is supported. Currently, the only
-	// supported additional formatter is gofumpt.
-	if format := snapshot.View().Options().GofumptFormat; snapshot.View().Options().Gofumpt && format != nil {
-		// gofumpt can customize formatting based on language version and module
-		// path, if available.
+	// Apply additional formatting, if any is supported. gofumpt only
+	// understands Go syntax, so Go+ files use the GopFormat hook instead,
+	// whenever it is set: unlike gofumpt, it is not a user setting.
+	if format := snapshot.View().Options().GopFormat; format != nil {
+		// The formatter can customize formatting based on language version and
+		// module path, if available.
 		//
 		// Try to derive this information, but fall-back on the default behavior.
 		//
@@ -80,16 +89,41 @@ func FormatGop(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protoco
 				modulePath = mi.Path
 			}
 		}
-		b, err := format(ctx, langVersion, modulePath, buf.Bytes())
+		b, err := format(ctx, langVersion, modulePath, buf.Bytes(), isClass(ctx, snapshot, fh))
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		formatted = string(b)
 	}
-	return gopComputeTextEdits(ctx, snapshot, pgf, formatted)
+	return formatted, nil
 }
 
-func formatGopSource(ctx context.Context, fh FileHandle) ([]byte, error) {
+// GopStyle rewrites the Go+ file fh from Go style to idiomatic Go+ style,
+// like gop fmt --smart: for example, fmt.Println calls become println, and the
+// main function of package main becomes the top-level code of the file.
+// Like gop fmt --smart, it doesn't rewrite classfiles.
+func GopStyle(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "gop.GopStyle")
+	defer done()
+
+	if isClass(ctx, snapshot, fh) {
+		return nil, nil
+	}
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	if pgf.ParseErr != nil {
+		return nil, fmt.Errorf("can't convert %q to Go+ style: %v", fh.URI().Filename(), pgf.ParseErr)
+	}
+	styled, err := xformat.GopstyleSource(pgf.Src, fh.URI().Filename())
+	if err != nil {
+		return nil, err
+	}
+	return gopComputeTextEdits(ctx, snapshot, pgf, string(styled))
+}
+
+func formatGopSource(ctx context.Context, snapshot Snapshot, fh FileHandle) ([]byte, error) {
 	_, done := event.Start(ctx, "gop.formatSource")
 	defer done()
 
@@ -97,12 +131,17 @@ func formatGopSource(ctx context.Context, fh FileHandle) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return format.Source(data, isClass(fh))
+	return format.Source(data, isClass(ctx, snapshot, fh))
 }
 
-func isClass(fh FileHandle) bool {
-	fext := filepath.Ext(fh.URI().Filename())
-	return goputil.FileKind(fext) == goputil.FileGopClass
+// isClass reports whether fh is a classfile of its Go+ module, which may
+// register classfiles of its own.
+func isClass(ctx context.Context, snapshot Snapshot, fh FileHandle) bool {
+	mod, err := snapshot.GopModForFile(ctx, fh.URI())
+	if err != nil {
+		mod = nil // only the builtin classfiles are known
+	}
+	return goputil.FileKindOf(mod, fh.URI().Filename()) == goputil.FileGopClass
 }
 
 // GopAllImportsFixes formats f for each possible fix to the imports.
@@ -0,0 +1,399 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+// This file defines range and on-type formatting of Go+ files.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/safetoken"
+	"golang.org/x/tools/internal/event"
+)
+
+// FormatGopRange formats the statements or declarations of a Go+ file that
+// intersect rng, leaving the rest of the file unchanged.
+func FormatGopRange(ctx context.Context, snapshot Snapshot, fh FileHandle, rng protocol.Range) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "gop.FormatRange")
+	defer done()
+
+	// Generated files shouldn't be edited. So, don't format them
+	if IsGenerated(ctx, snapshot, fh.URI()) {
+		return nil, fmt.Errorf("can't format %q: file is generated", fh.URI().Filename())
+	}
+
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	if pgf.ParseErr != nil {
+		// The statements can't be found reliably.
+		return nil, fmt.Errorf("can't format range of %q: %v", fh.URI().Filename(), pgf.ParseErr)
+	}
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	nodes := gopEnclosingStmts(pgf.File, start, end)
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	first, last := nodes[0], nodes[len(nodes)-1]
+	startPos := first.Pos()
+	switch decl := first.(type) {
+	case *ast.FuncDecl:
+		if decl.Doc != nil {
+			startPos = decl.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if decl.Doc != nil {
+			startPos = decl.Doc.Pos()
+		}
+	}
+	// Format whole lines, so that the indentation of the nodes is fixed too.
+	lineStart := pgf.Tok.LineStart(safetoken.Line(pgf.Tok, startPos))
+	stmtsRng, err := pgf.PosRange(lineStart, last.End())
+	if err != nil {
+		return nil, err
+	}
+
+	// Format the file, keeping only the edits within the nodes.
+	formatted, err := gopFormatFile(ctx, snapshot, fh, pgf)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := gopComputeTextEdits(ctx, snapshot, pgf, formatted)
+	if err != nil {
+		return nil, err
+	}
+	var result []protocol.TextEdit
+	for _, edit := range edits {
+		if protocol.ComparePosition(stmtsRng.Start, edit.Range.Start) <= 0 && protocol.ComparePosition(edit.Range.End, stmtsRng.End) <= 0 {
+			result = append(result, edit)
+		}
+	}
+	return result, nil
+}
+
+// gopEnclosingStmts returns the statements of the innermost statement list
+// enclosing [start, end] that intersect it. At the top level of the file,
+// these are declarations and statements: the statements of a classfile, or of
+// a file without a main function, are the body of its shadow entry, which has
+// no braces.
+func gopEnclosingStmts(file *ast.File, start, end token.Pos) []ast.Node {
+	// The top level of the file.
+	var list []ast.Node
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Shadow {
+			for _, stmt := range fn.Body.List {
+				list = append(list, stmt)
+			}
+			continue
+		}
+		list = append(list, decl)
+	}
+
+	// Find the innermost block containing [start, end].
+	var inner token.Pos // start of the innermost block
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		var open, close token.Pos
+		var stmts []ast.Stmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Shadow {
+				return true // the shadow entry has no braces, nor a valid end
+			}
+		case *ast.BlockStmt:
+			open, close, stmts = n.Lbrace, n.Rbrace, n.List
+		case *ast.CaseClause:
+			open, close, stmts = n.Colon, n.End(), n.Body
+		case *ast.CommClause:
+			open, close, stmts = n.Colon, n.End(), n.Body
+		}
+		if open.IsValid() && close.IsValid() && open < start && end <= close && open >= inner {
+			inner = open
+			list = list[:0:0]
+			for _, stmt := range stmts {
+				list = append(list, stmt)
+			}
+		}
+		return n.Pos() <= end && start <= n.End()
+	})
+
+	var nodes []ast.Node
+	for _, n := range list {
+		if n.Pos() <= end && start <= n.End() {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// FormatGopOnType returns the edits that fix the indentation of the line at
+// pos of a Go+ file after ch is typed: a newline, or a closing brace. A new
+// line is indented one more level after an opening brace, a lambda arrow, or
+// the first line of a command-style call continued on the next line, such as
+//
+//	echo a,
+//		b
+//
+// A closing brace alone on its line is aligned with the line of the matching
+// opening brace. The top-level statements of a classfile form an implicit
+// body without braces, so they are not indented. A line that starts within a
+// comment or a string literal is left unchanged.
+func FormatGopOnType(ctx context.Context, snapshot Snapshot, fh FileHandle, pos protocol.Position, ch string) ([]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "gop.FormatOnType")
+	defer done()
+
+	content, err := fh.Content()
+	if err != nil {
+		return nil, err
+	}
+	pgf, err := snapshot.ParseGop(ctx, fh, parserutil.ParseFull)
+	if err != nil {
+		return nil, err
+	}
+	m := protocol.NewMapper(fh.URI(), content)
+	lines := bytes.Split(content, []byte("\n"))
+	code, text := gopCodeLines(pgf, content)
+	line := int(pos.Line)
+	if line >= len(lines) || text[line] {
+		return nil, nil
+	}
+
+	var indent string
+	switch ch {
+	case "\n":
+		trimmed := gopTrimLine(code[line])
+		if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, ")") || strings.HasPrefix(trimmed, "]") {
+			// The line closes a block: align it with the opening line.
+			i, ok := gopOpeningLine(code, line)
+			if !ok {
+				return nil, nil
+			}
+			indent = gopIndent(lines[i])
+			break
+		}
+		prev := line - 1
+		for prev >= 0 && gopTrimLine(code[prev]) == "" {
+			prev--
+		}
+		if prev < 0 {
+			return nil, nil
+		}
+		indent = gopIndent(lines[prev])
+		switch trimmed := gopTrimLine(code[prev]); {
+		case strings.HasSuffix(trimmed, "{"), strings.HasSuffix(trimmed, "("), strings.HasSuffix(trimmed, "["), strings.HasSuffix(trimmed, "=>"):
+			indent += "\t"
+		case strings.HasSuffix(trimmed, ","):
+			// Only the first line of a command-style call is less indented
+			// than its arguments.
+			if prev == 0 || !strings.HasSuffix(gopTrimLine(code[prev-1]), ",") {
+				if gopIsCommandCall(trimmed) {
+					indent += "\t"
+				}
+			}
+		default:
+			if isClass(ctx, snapshot, fh) && gopDepth(code, line) == 0 {
+				// A statement of the implicit body of the classfile, such as
+				// the one after the arguments of a command-style call.
+				indent = ""
+			}
+		}
+	case "}":
+		if gopTrimLine(code[line]) != "}" {
+			return nil, nil
+		}
+		i, ok := gopOpeningLine(code, line)
+		if !ok {
+			return nil, nil
+		}
+		indent = gopIndent(lines[i])
+	default:
+		return nil, nil
+	}
+
+	old := gopIndent(lines[line])
+	if old == indent {
+		return nil, nil
+	}
+	offset, err := m.PositionOffset(protocol.Position{Line: uint32(line)})
+	if err != nil {
+		return nil, err
+	}
+	rng, err := m.OffsetRange(offset, offset+len(old))
+	if err != nil {
+		return nil, err
+	}
+	return []protocol.TextEdit{{Range: rng, NewText: indent}}, nil
+}
+
+// gopCodeLines returns the lines of content, the source of pgf, with their
+// comments blanked out and their string and rune literals replaced with
+// underscores, so that the brackets and markers in them aren't taken for
+// code. text reports the lines that start within a comment or a literal.
+//
+// The comments and literals are those of the parsed file. If it has parse
+// errors, they are found line by line instead, see gopCodeLen, and the
+// comments and raw strings spanning lines aren't recognized.
+func gopCodeLines(pgf *ParsedGopFile, content []byte) (lines [][]byte, text []bool) {
+	if pgf.ParseErr != nil || pgf.FixedSrc || pgf.FixedAST || !bytes.Equal(pgf.Src, content) {
+		lines = bytes.Split(content, []byte("\n"))
+		for i, line := range lines {
+			lines[i] = line[:gopCodeLen(line)]
+		}
+		return lines, make([]bool, len(lines))
+	}
+
+	code := append([]byte(nil), content...)
+	starts := make(map[int]bool) // offsets of the lines starting within text
+	blank := func(start, end token.Pos, c byte) {
+		s, e, err := safetoken.Offsets(pgf.Tok, start, end)
+		if err != nil {
+			return
+		}
+		for i := s; i < e; i++ {
+			if code[i] == '\n' {
+				starts[i+1] = true
+			} else {
+				code[i] = c
+			}
+		}
+	}
+	for _, group := range pgf.File.Comments {
+		for _, c := range group.List {
+			blank(c.Pos(), c.End(), ' ')
+		}
+	}
+	ast.Inspect(pgf.File, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.BasicLit); ok {
+			switch lit.Kind {
+			case token.STRING, token.CHAR, token.CSTRING, token.PYSTRING:
+				blank(lit.Pos(), lit.End(), '_')
+			}
+			return false
+		}
+		return true
+	})
+
+	lines = bytes.Split(code, []byte("\n"))
+	text = make([]bool, len(lines))
+	offset := 0
+	for i, line := range lines {
+		text[i] = starts[offset]
+		offset += len(line) + 1
+	}
+	return lines, text
+}
+
+// gopIndent returns the leading whitespace of line.
+func gopIndent(line []byte) string {
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// gopTrimLine returns the code of a line, see gopCodeLines, without its
+// leading and trailing whitespace.
+func gopTrimLine(code []byte) string {
+	return strings.TrimSpace(string(code))
+}
+
+// gopCodeLen returns the length of line without its line comment, if any.
+// It skips the comment markers in string and rune literals.
+func gopCodeLen(line []byte) int {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return i
+		}
+	}
+	return len(line)
+}
+
+// gopOpeningLine returns the index of the line of the opening bracket that
+// matches the closing bracket starting code[line], see gopCodeLines.
+func gopOpeningLine(code [][]byte, line int) (int, bool) {
+	depth := 0
+	for i := line; i >= 0; i-- {
+		text := code[i]
+		if i == line {
+			// Count the closing bracket starting the line only.
+			text = bytes.TrimLeft(text, " \t")[:1]
+		}
+		for j := len(text) - 1; j >= 0; j-- {
+			switch text[j] {
+			case '}', ')', ']':
+				depth++
+			case '{', '(', '[':
+				depth--
+				if depth == 0 {
+					return i, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// gopDepth returns the number of brackets left open before code[line], see
+// gopCodeLines.
+func gopDepth(code [][]byte, line int) int {
+	depth := 0
+	for _, text := range code[:line] {
+		for _, c := range text {
+			switch c {
+			case '{', '(', '[':
+				depth++
+			case '}', ')', ']':
+				depth--
+			}
+		}
+	}
+	return depth
+}
+
+// gopIsCommandCall reports whether the line text starts a command-style
+// call, such as `echo a,`: a function name followed by its arguments without
+// parentheses.
+func gopIsCommandCall(text string) bool {
+	i := strings.IndexAny(text, " \t")
+	if i <= 0 {
+		return false
+	}
+	fun, args := text[:i], strings.TrimLeft(text[i:], " \t")
+	if token.Lookup(fun).IsKeyword() || args == "" {
+		return false
+	}
+	for _, part := range strings.Split(fun, ".") {
+		if !token.IsIdentifier(part) {
+			return false
+		}
+	}
+	// Exclude binary expressions and assignments, such as `a := b,`.
+	switch args[0] {
+	case '=', ':', '+', '-', '*', '/', '%', '&', '|', '^', '<', '>', '!', ',', '.', '(', '[', '{':
+		return false
+	}
+	return true
+}
@@ -385,7 +385,7 @@ func goHoverInGop(
 	singleLineSignature := signature
 
 	// TODO(rfindley): we could do much better for inferred signatures.
-	if inferred := gopInferredSignature(pkg.GopTypesInfo(), ident); inferred != nil {
+	if inferred := gopInferredSignature(pkg.GopTypesInfo(), pgf.File, ident); inferred != nil {
 		if s := inferredSignatureString(obj, qf, inferred); s != "" {
 			signature = s
 		}
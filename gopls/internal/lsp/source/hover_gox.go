@@ -165,7 +165,7 @@ func gopHover(ctx context.Context, snapshot Snapshot, fh FileHandle, pp protocol
 	singleLineSignature := signature
 
 	// TODO(rfindley): we could do much better for inferred signatures.
-	if inferred := gopInferredSignature(pkg.GopTypesInfo(), ident); inferred != nil {
+	if inferred := gopInferredSignature(pkg.GopTypesInfo(), pgf.File, ident); inferred != nil {
 		if s := inferredSignatureString(obj, qf, inferred); s != "" {
 			signature = s
 		}
@@ -10,14 +10,49 @@ import (
 
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gop/ast/astutil"
 )
 
 // gopInferredSignature determines the resolved non-generic signature for an
 // identifier in an instantiation expression.
 //
+// The Go+ compiler does not always record instances, so if info has none for
+// id, the signature is inferred from the call expression of id in file.
+//
 // If no such signature exists, it returns nil.
-func gopInferredSignature(info *typesutil.Info, id *ast.Ident) *types.Signature {
-	return nil // goxls: todo
+func gopInferredSignature(info *typesutil.Info, file *ast.File, id *ast.Ident) *types.Signature {
+	if inst, ok := info.Instances[id]; ok {
+		sig, _ := inst.Type.(*types.Signature)
+		return sig
+	}
+	path, _ := astutil.PathEnclosingInterval(file, id.Pos(), id.End())
+	if len(path) < 2 || path[0] != id {
+		return nil
+	}
+	var fun ast.Expr = id
+	for _, n := range path[1:] {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if n.Sel != id {
+				return nil
+			}
+			fun = n
+			continue
+		case *ast.IndexExpr, *ast.IndexListExpr:
+			// An explicit instantiation, e.g. "f[int]".
+			sig, _ := info.TypeOf(n.(ast.Expr)).(*types.Signature)
+			if sig == nil || sig.TypeParams().Len() > 0 {
+				return nil
+			}
+			return sig
+		case *ast.CallExpr:
+			if n.Fun == fun {
+				return gopInferCallSignature(info, n)
+			}
+		}
+		return nil
+	}
+	return nil
 }
 
 func gopSearchForEnclosing(info *typesutil.Info, path []ast.Node) *types.TypeName {
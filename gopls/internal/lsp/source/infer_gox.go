@@ -0,0 +1,525 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"fmt"
+	goast "go/ast"
+	gotoken "go/token"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+)
+
+// gopInferCallSignature infers the signature of the generic function called
+// by call from its arguments. The function may be a member of an overloaded
+// function.
+//
+// The Go+ compiler does not record the instances of the generic functions it
+// calls, so the call is type-checked again by go/types, with each argument
+// replaced by a variable or an untyped constant of its type. A lambda
+// argument is replaced by a variable of its signature: its parameter types
+// are inferred from the other arguments first, and its result types are those
+// of the expressions it returns, type-checked with these parameters.
+//
+// If the called function is not generic, or its type arguments cannot be
+// inferred, it returns nil.
+func gopInferCallSignature(info *typesutil.Info, call *ast.CallExpr) *types.Signature {
+	id := gopCallIdent(call)
+	if id == nil {
+		return nil
+	}
+	fn, _ := info.ObjectOf(id).(*types.Func)
+	if fn == nil {
+		return nil
+	}
+	sig, _ := fn.Type().(*types.Signature)
+	if sig == nil || sig.TypeParams().Len() == 0 {
+		return nil
+	}
+	inf := &gopInferrer{info: info, pkg: gopInfoPackage(info), call: call}
+
+	var lambdas map[int]*types.Signature
+	for i, arg := range call.Args {
+		if !gopIsLambda(arg) {
+			continue
+		}
+		if lambdas == nil {
+			lambdas = make(map[int]*types.Signature)
+			inf.inferPartial(sig)
+		}
+		if inf.partial == nil {
+			continue
+		}
+		if ptyp, ok := gopParamType(inf.partial, call, i).(*types.Signature); ok {
+			lambdas[i] = inf.lambdaType(arg, ptyp)
+		}
+	}
+
+	targs := inf.infer(sig, func(i int) types.Type {
+		if gopIsLambda(call.Args[i]) {
+			if typ := lambdas[i]; typ != nil {
+				return typ
+			}
+			return nil
+		}
+		return gopNoType
+	})
+	if targs == nil {
+		return nil
+	}
+	inst, err := types.Instantiate(nil, sig, targs, false)
+	if err != nil {
+		return nil
+	}
+	inferred, _ := inst.(*types.Signature)
+	return inferred
+}
+
+// gopNoType stands for the recorded type of an argument.
+var gopNoType types.Type = types.Typ[types.Invalid]
+
+// A gopInferrer infers the type arguments of the generic function called by
+// call, a call of a Go+ package with the type information info.
+type gopInferrer struct {
+	info *typesutil.Info
+	pkg  *types.Package // the package of info, if known
+	call *ast.CallExpr
+
+	// partial is the called signature instantiated with the type arguments
+	// bound by the arguments other than lambdas, and with unknown, type
+	// parameters of no signature, for the others.
+	partial *types.Signature
+	unknown []types.Type
+}
+
+// infer returns the type arguments of sig, a generic signature, inferred from
+// the arguments of the call, or nil if they can't be inferred. The type of
+// argument i is argType(i): nil for an argument that doesn't bind type
+// parameters, or gopNoType for the type recorded in the type information.
+func (inf *gopInferrer) infer(sig *types.Signature, argType func(i int) types.Type) []types.Type {
+	pkg := types.NewPackage(inf.pkgPath(), "_")
+	scope := pkg.Scope()
+	fun := goast.NewIdent("f")
+	scope.Insert(types.NewFunc(gotoken.NoPos, pkg, fun.Name, sig))
+	args := make([]goast.Expr, len(inf.call.Args))
+	for i, arg := range inf.call.Args {
+		switch typ := argType(i); typ {
+		case nil:
+			args[i] = goast.NewIdent("nil") // untyped nil doesn't bind type parameters
+		case gopNoType:
+			args[i] = inf.argExpr(scope, fmt.Sprintf("a%d", i), arg)
+		default:
+			name := fmt.Sprintf("a%d", i)
+			scope.Insert(types.NewVar(gotoken.NoPos, pkg, name, typ))
+			args[i] = goast.NewIdent(name)
+		}
+	}
+	expr := &goast.CallExpr{Fun: fun, Args: args}
+	if inf.call.Ellipsis.IsValid() {
+		expr.Ellipsis = 1
+	}
+	tinfo := &types.Info{Instances: make(map[*goast.Ident]types.Instance)}
+	if err := types.CheckExpr(gotoken.NewFileSet(), pkg, gotoken.NoPos, expr, tinfo); err != nil {
+		return nil
+	}
+	targs := tinfo.Instances[fun].TypeArgs
+	if targs == nil {
+		return nil
+	}
+	ret := make([]types.Type, targs.Len())
+	for i := range ret {
+		ret[i] = targs.At(i)
+	}
+	return ret
+}
+
+// inferPartial sets inf.partial to sig instantiated with the type arguments
+// bound by the arguments of the call other than lambdas. It leaves it nil if
+// they can't be inferred.
+//
+// go/types infers all the type arguments of a call or none, so the call is
+// checked against a signature without the parameters of the lambdas, and with
+// the type parameters of the other parameters only.
+func (inf *gopInferrer) inferPartial(sig *types.Signature) {
+	tparams := sig.TypeParams()
+	fresh := make([]types.Type, tparams.Len())
+	for i := range fresh {
+		tn := types.NewTypeName(gotoken.NoPos, nil, tparams.At(i).Obj().Name(), nil)
+		// The constraints may refer to the other type parameters, which
+		// would have to be substituted: use no constraints.
+		fresh[i] = types.NewTypeParam(tn, types.NewInterfaceType(nil, nil))
+	}
+	inst, err := types.Instantiate(nil, sig, fresh, false)
+	if err != nil {
+		return
+	}
+	isig := inst.(*types.Signature)
+
+	var (
+		params []*types.Var
+		argIdx []int
+	)
+	for i, arg := range inf.call.Args {
+		if gopIsLambda(arg) {
+			continue
+		}
+		ptyp := gopParamType(isig, inf.call, i)
+		if ptyp == nil {
+			return
+		}
+		params = append(params, types.NewParam(gotoken.NoPos, nil, "", ptyp))
+		argIdx = append(argIdx, i)
+	}
+	var used []*types.TypeParam
+	var usedIdx []int
+	for i, t := range fresh {
+		for _, p := range params {
+			if gopHasType(p.Type(), t) {
+				used = append(used, t.(*types.TypeParam))
+				usedIdx = append(usedIdx, i)
+				break
+			}
+		}
+	}
+	// The fresh type parameters the arguments don't bind stand for unknown
+	// types.
+	targs := make([]types.Type, len(fresh))
+	copy(targs, fresh)
+	if len(used) > 0 {
+		rsig := types.NewSignatureType(nil, nil, used, types.NewTuple(params...), nil, false)
+		rcall := *inf.call
+		rcall.Args = make([]ast.Expr, len(argIdx))
+		for i, j := range argIdx {
+			rcall.Args[i] = inf.call.Args[j]
+		}
+		rcall.Ellipsis = token.NoPos
+		rinf := &gopInferrer{info: inf.info, pkg: inf.pkg, call: &rcall}
+		rtargs := rinf.infer(rsig, func(int) types.Type { return gopNoType })
+		if rtargs == nil {
+			return
+		}
+		for i, j := range usedIdx {
+			targs[j] = rtargs[i]
+		}
+	}
+	inst, err = types.Instantiate(nil, sig, targs, false)
+	if err != nil {
+		return
+	}
+	inf.partial = inst.(*types.Signature)
+	for i, t := range targs {
+		if t == fresh[i] {
+			inf.unknown = append(inf.unknown, t)
+		}
+	}
+}
+
+// lambdaType returns the signature of the lambda expression x, an argument
+// for a parameter of type ptyp of inf.partial, or nil if it can't be
+// determined. Its parameter types are those of ptyp, which must not depend on
+// unknown types, and its result types those of the expressions it returns.
+func (inf *gopInferrer) lambdaType(x ast.Expr, ptyp *types.Signature) *types.Signature {
+	var (
+		lhs     []*ast.Ident
+		results []ast.Expr
+	)
+	switch x := x.(type) {
+	case *ast.LambdaExpr:
+		lhs, results = x.Lhs, x.Rhs
+	case *ast.LambdaExpr2:
+		lhs = x.Lhs
+		ast.Inspect(x.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit, *ast.LambdaExpr, *ast.LambdaExpr2:
+				return false
+			case *ast.ReturnStmt:
+				if results == nil {
+					results = n.Results
+				}
+			}
+			return results == nil
+		})
+	}
+	if len(lhs) != ptyp.Params().Len() {
+		return nil
+	}
+
+	pkg := types.NewPackage(inf.pkgPath(), "_")
+	scope := pkg.Scope()
+	params := make([]*types.Var, len(lhs))
+	for i, id := range lhs {
+		typ := ptyp.Params().At(i).Type()
+		for _, t := range inf.unknown {
+			if gopHasType(typ, t) {
+				return nil
+			}
+		}
+		params[i] = types.NewParam(gotoken.NoPos, pkg, id.Name, typ)
+		scope.Insert(params[i])
+	}
+	res := make([]*types.Var, len(results))
+	for i, r := range results {
+		expr := inf.goExpr(scope, r)
+		if expr == nil {
+			return nil
+		}
+		tinfo := &types.Info{Types: make(map[goast.Expr]types.TypeAndValue)}
+		if err := types.CheckExpr(gotoken.NewFileSet(), pkg, gotoken.NoPos, expr, tinfo); err != nil {
+			return nil
+		}
+		res[i] = types.NewParam(gotoken.NoPos, nil, "", types.Default(tinfo.Types[expr].Type))
+	}
+	return types.NewSignatureType(nil, nil, nil, types.NewTuple(params...), types.NewTuple(res...), false)
+}
+
+// argExpr returns an expression of go/ast standing for arg, an argument of
+// the call, declaring the objects it uses in scope under the given name. It is
+// a variable or an untyped constant of the recorded type of arg. A slice
+// literal, whose type Go+ infers from its elements, is a call of a generic
+// function of its elements.
+func (inf *gopInferrer) argExpr(scope *types.Scope, name string, arg ast.Expr) goast.Expr {
+	pkg := scope.Lookup("f").Pkg()
+	tv, ok := inf.info.Types[arg]
+	switch {
+	case ok && tv.Type != nil && !tv.IsNil():
+		if tv.Value != nil && gopIsUntyped(tv.Type) {
+			scope.Insert(types.NewConst(gotoken.NoPos, pkg, name, tv.Type, tv.Value))
+		} else {
+			scope.Insert(types.NewVar(gotoken.NoPos, pkg, name, types.Default(tv.Type)))
+		}
+		return goast.NewIdent(name)
+	case !ok:
+		if lit, ok := arg.(*ast.SliceLit); ok && len(lit.Elts) > 0 {
+			const sliceOf = "sliceOf"
+			if scope.Lookup(sliceOf) == nil {
+				scope.Insert(types.NewFunc(gotoken.NoPos, pkg, sliceOf, gopSliceOfSignature()))
+			}
+			call := &goast.CallExpr{Fun: goast.NewIdent(sliceOf)}
+			for i, elt := range lit.Elts {
+				call.Args = append(call.Args, inf.argExpr(scope, fmt.Sprintf("%s_%d", name, i), elt))
+			}
+			return call
+		}
+	}
+	return goast.NewIdent("nil")
+}
+
+// gopSliceOfSignature returns the signature of func[E any](...E) []E.
+func gopSliceOfSignature() *types.Signature {
+	e := types.NewTypeParam(types.NewTypeName(gotoken.NoPos, nil, "E", nil), types.NewInterfaceType(nil, nil))
+	params := types.NewTuple(types.NewParam(gotoken.NoPos, nil, "elts", types.NewSlice(e)))
+	results := types.NewTuple(types.NewParam(gotoken.NoPos, nil, "", types.NewSlice(e)))
+	return types.NewSignatureType(nil, nil, []*types.TypeParam{e}, params, results, true)
+}
+
+// goExpr returns x, an expression in the body of a lambda argument of the
+// call, as an expression of go/ast, declaring the objects it uses in scope,
+// which holds the parameters of the lambda. It returns nil if x is not one of
+// the expressions of Go that go/types can check alone.
+func (inf *gopInferrer) goExpr(scope *types.Scope, x ast.Expr) goast.Expr {
+	var conv func(x ast.Expr) goast.Expr
+	convList := func(xs []ast.Expr) []goast.Expr {
+		ret := make([]goast.Expr, len(xs))
+		for i, x := range xs {
+			if ret[i] = conv(x); ret[i] == nil {
+				return nil
+			}
+		}
+		return ret
+	}
+	conv = func(x ast.Expr) goast.Expr {
+		switch x := x.(type) {
+		case *ast.Ident:
+			if scope.Lookup(x.Name) == nil && types.Universe.Lookup(x.Name) == nil {
+				obj := inf.lookup(x)
+				if obj == nil {
+					return nil
+				}
+				scope.Insert(obj)
+			}
+			return goast.NewIdent(x.Name)
+		case *ast.BasicLit:
+			kind, ok := gopLitKinds[x.Kind]
+			if !ok || x.Extra != nil {
+				return nil
+			}
+			return &goast.BasicLit{Kind: kind, Value: x.Value}
+		case *ast.BinaryExpr:
+			op, ok := gopOperators[x.Op.String()]
+			l, r := conv(x.X), conv(x.Y)
+			if !ok || l == nil || r == nil {
+				return nil
+			}
+			return &goast.BinaryExpr{X: l, Op: op, Y: r}
+		case *ast.UnaryExpr:
+			op, ok := gopOperators[x.Op.String()]
+			e := conv(x.X)
+			if !ok || e == nil {
+				return nil
+			}
+			return &goast.UnaryExpr{Op: op, X: e}
+		case *ast.ParenExpr:
+			if e := conv(x.X); e != nil {
+				return &goast.ParenExpr{X: e}
+			}
+		case *ast.StarExpr:
+			if e := conv(x.X); e != nil {
+				return &goast.StarExpr{X: e}
+			}
+		case *ast.SelectorExpr:
+			if e := conv(x.X); e != nil {
+				return &goast.SelectorExpr{X: e, Sel: goast.NewIdent(x.Sel.Name)}
+			}
+		case *ast.IndexExpr:
+			e, i := conv(x.X), conv(x.Index)
+			if e != nil && i != nil {
+				return &goast.IndexExpr{X: e, Index: i}
+			}
+		case *ast.CallExpr:
+			fun, args := conv(x.Fun), convList(x.Args)
+			if fun == nil || args == nil && len(x.Args) > 0 {
+				return nil
+			}
+			call := &goast.CallExpr{Fun: fun, Args: args}
+			if x.Ellipsis.IsValid() {
+				call.Ellipsis = 1
+			}
+			return call
+		}
+		return nil
+	}
+	return conv(x)
+}
+
+// lookup returns the object id refers to in the scope of the call, or nil.
+func (inf *gopInferrer) lookup(id *ast.Ident) types.Object {
+	var inner *types.Scope
+	for _, s := range inf.info.Scopes {
+		if s.Pos().IsValid() && s.Contains(inf.call.Pos()) && (inner == nil || inner.Contains(s.Pos())) {
+			inner = s
+		}
+	}
+	if inner == nil && inf.pkg != nil {
+		inner = inf.pkg.Scope()
+	}
+	if inner == nil {
+		return nil
+	}
+	_, obj := inner.LookupParent(id.Name, gotoken.NoPos)
+	return obj
+}
+
+// pkgPath returns the path of the package of the call, which the packages
+// used to type-check its parts take so that its unexported names are visible.
+func (inf *gopInferrer) pkgPath() string {
+	if inf.pkg != nil {
+		return inf.pkg.Path()
+	}
+	return "_"
+}
+
+// gopInfoPackage returns the package info is the type information of, or nil
+// if it defines no object.
+func gopInfoPackage(info *typesutil.Info) *types.Package {
+	for _, obj := range info.Defs {
+		if obj != nil && obj.Pkg() != nil {
+			return obj.Pkg()
+		}
+	}
+	return nil
+}
+
+// gopParamType returns the type of the parameter of sig for argument i of
+// call, or nil if there is none.
+func gopParamType(sig *types.Signature, call *ast.CallExpr, i int) types.Type {
+	params := sig.Params()
+	switch last := params.Len() - 1; {
+	case i < last || i == last && (!sig.Variadic() || call.Ellipsis.IsValid()):
+		return params.At(i).Type()
+	case sig.Variadic() && i >= last:
+		return params.At(last).Type().(*types.Slice).Elem()
+	}
+	return nil
+}
+
+
+// gopHasType reports whether typ is or refers to t.
+func gopHasType(typ, t types.Type) bool {
+	switch typ := typ.(type) {
+	case *types.Pointer:
+		return gopHasType(typ.Elem(), t)
+	case *types.Slice:
+		return gopHasType(typ.Elem(), t)
+	case *types.Array:
+		return gopHasType(typ.Elem(), t)
+	case *types.Chan:
+		return gopHasType(typ.Elem(), t)
+	case *types.Map:
+		return gopHasType(typ.Key(), t) || gopHasType(typ.Elem(), t)
+	case *types.Tuple:
+		for i := 0; i < typ.Len(); i++ {
+			if gopHasType(typ.At(i).Type(), t) {
+				return true
+			}
+		}
+	case *types.Signature:
+		return gopHasType(typ.Params(), t) || gopHasType(typ.Results(), t)
+	case *types.Struct:
+		for i := 0; i < typ.NumFields(); i++ {
+			if gopHasType(typ.Field(i).Type(), t) {
+				return true
+			}
+		}
+	case *types.Named:
+		targs := typ.TypeArgs()
+		for i := 0; i < targs.Len(); i++ {
+			if gopHasType(targs.At(i), t) {
+				return true
+			}
+		}
+	case *types.TypeParam:
+		return typ == t
+	}
+	return false
+}
+
+// gopIsLambda reports whether x is a lambda expression.
+func gopIsLambda(x ast.Expr) bool {
+	switch x.(type) {
+	case *ast.LambdaExpr, *ast.LambdaExpr2:
+		return true
+	}
+	return false
+}
+
+// gopIsUntyped reports whether typ is an untyped basic type.
+func gopIsUntyped(typ types.Type) bool {
+	b, ok := typ.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
+
+// gopLitKinds maps the kinds of the Go+ basic literals that are literals of
+// Go to their go/token kinds.
+var gopLitKinds = map[token.Token]gotoken.Token{
+	token.INT:    gotoken.INT,
+	token.FLOAT:  gotoken.FLOAT,
+	token.IMAG:   gotoken.IMAG,
+	token.CHAR:   gotoken.CHAR,
+	token.STRING: gotoken.STRING,
+}
+
+// gopOperators maps the operators of Go to their go/token tokens.
+var gopOperators = func() map[string]gotoken.Token {
+	ops := make(map[string]gotoken.Token)
+	for tok := gotoken.Token(0); tok < gotoken.TILDE+1; tok++ {
+		if tok.IsOperator() {
+			ops[tok.String()] = tok
+		}
+	}
+	return ops
+}()
@@ -38,6 +38,7 @@ const (
 	CompositeLiteralTypes      = "compositeLiteralTypes"
 	CompositeLiteralFieldNames = "compositeLiteralFields"
 	FunctionTypeParameters     = "functionTypeParameters"
+	OverloadMembers            = "overloadMembers" // goxls: Go+
 )
 
 var AllInlayHints = map[string]*Hint{
@@ -76,6 +77,10 @@ var AllInlayHints = map[string]*Hint{
 		Doc:  "Enable/disable inlay hints for implicit type parameters on generic functions:\n```go\n\tmyFoo/*[int, string]*/(1, \"hello\")\n```",
 		Run:  funcTypeParams,
 	},
+	OverloadMembers: { // goxls: Go+ only, see gopInlayHints
+		Name: OverloadMembers,
+		Doc:  "Enable/disable inlay hints for the members that calls of overloaded Go+ functions resolve to:\n```go\n\tn.add/* Add__1*/ \"hello\"\n```",
+	},
 }
 
 func InlayHint(ctx context.Context, snapshot Snapshot, fh FileHandle, pRng protocol.Range) ([]protocol.InlayHint, error) {
@@ -94,7 +99,7 @@ func InlayHint(ctx context.Context, snapshot Snapshot, fh FileHandle, pRng proto
 		if !enabled {
 			continue
 		}
-		if h, ok := AllInlayHints[hint]; ok {
+		if h, ok := AllInlayHints[hint]; ok && h.Run != nil { // goxls: Go+ only hints have no Run
 			enabledHints = append(enabledHints, h.Run)
 		}
 	}
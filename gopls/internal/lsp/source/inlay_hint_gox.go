@@ -0,0 +1,299 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/internal/event"
+)
+
+type GopInlayHintFunc func(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, q *types.Qualifier) []protocol.InlayHint
+
+// gopInlayHints maps the names of the inlay hints that are supported in
+// Go+ files to their implementations.
+var gopInlayHints = map[string]GopInlayHintFunc{
+	AssignVariableTypes:        gopAssignVariableTypes,
+	ParameterNames:             gopParameterNames,
+	RangeVariableTypes:         gopRangeVariableTypes,
+	CompositeLiteralFieldNames: gopCompositeLiteralFields,
+	OverloadMembers:            gopOverloadMembers,
+}
+
+func GopInlayHint(ctx context.Context, snapshot Snapshot, fh FileHandle, pRng protocol.Range) ([]protocol.InlayHint, error) {
+	ctx, done := event.Start(ctx, "source.GopInlayHint")
+	defer done()
+
+	pkg, pgf, err := NarrowestPackageForGopFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, fmt.Errorf("getting file for GopInlayHint: %w", err)
+	}
+
+	// Collect a list of the inlay hints that are enabled.
+	inlayHintOptions := snapshot.View().Options().InlayHintOptions
+	var enabledHints []GopInlayHintFunc
+	for hint, enabled := range inlayHintOptions.Hints {
+		if !enabled {
+			continue
+		}
+		if fn, ok := gopInlayHints[hint]; ok {
+			enabledHints = append(enabledHints, fn)
+		}
+	}
+	if len(enabledHints) == 0 {
+		return nil, nil
+	}
+
+	info := pkg.GopTypesInfo()
+	q := GopQualifier(pgf.File, pkg.GetTypes(), info)
+
+	// Set the range to the full file if the range is not valid.
+	start, end := pgf.File.Pos(), pgf.File.End()
+	if pRng.Start.Line < pRng.End.Line || pRng.Start.Character < pRng.End.Character {
+		// Adjust start and end for the specified range.
+		var err error
+		start, end, err = pgf.RangePos(pRng)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var hints []protocol.InlayHint
+	ast.Inspect(pgf.File, func(node ast.Node) bool {
+		// If not in range, we can stop looking.
+		if node == nil || node.End() < start || node.Pos() > end {
+			return false
+		}
+		for _, fn := range enabledHints {
+			hints = append(hints, fn(node, pgf.Mapper, pgf.Tok, info, &q)...)
+		}
+		return true
+	})
+	return hints, nil
+}
+
+// gopCallSignature returns the signature of the function called by callExpr.
+// For a call of an overloaded function it is the signature of the member the
+// call was resolved to.
+func gopCallSignature(callExpr *ast.CallExpr, info *typesutil.Info) (*types.Signature, bool) {
+	if id := gopCallIdent(callExpr); id != nil {
+		if obj, ok := info.ObjectOf(id).(*types.Func); ok {
+			sig, ok := obj.Type().(*types.Signature)
+			return sig, ok
+		}
+	}
+	sig, ok := info.TypeOf(callExpr.Fun).(*types.Signature)
+	return sig, ok
+}
+
+// gopCallIdent returns the identifier naming the function called by
+// callExpr, or nil if there is none (e.g. "foo()()").
+func gopCallIdent(callExpr *ast.CallExpr) *ast.Ident {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	}
+	return nil
+}
+
+func gopParameterNames(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, _ *types.Qualifier) []protocol.InlayHint {
+	callExpr, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	signature, ok := gopCallSignature(callExpr, info)
+	if !ok {
+		return nil
+	}
+
+	var hints []protocol.InlayHint
+	for i, v := range callExpr.Args {
+		start, err := m.PosPosition(tf, v.Pos())
+		if err != nil {
+			continue
+		}
+		params := signature.Params()
+		// When a function has variadic params, we skip args after
+		// params.Len().
+		if i > params.Len()-1 {
+			break
+		}
+		param := params.At(i)
+		// param.Name is empty for built-ins like append
+		if param.Name() == "" {
+			continue
+		}
+		// Skip the parameter name hint if the arg matches
+		// the parameter name.
+		if i, ok := v.(*ast.Ident); ok && i.Name == param.Name() {
+			continue
+		}
+
+		label := param.Name()
+		if signature.Variadic() && i == params.Len()-1 {
+			label = label + "..."
+		}
+		hints = append(hints, protocol.InlayHint{
+			Position:     start,
+			Label:        buildLabel(label + ":"),
+			Kind:         protocol.Parameter,
+			PaddingRight: true,
+		})
+	}
+	return hints
+}
+
+func gopAssignVariableTypes(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, q *types.Qualifier) []protocol.InlayHint {
+	stmt, ok := node.(*ast.AssignStmt)
+	if !ok || stmt.Tok != token.DEFINE {
+		return nil
+	}
+
+	var hints []protocol.InlayHint
+	for _, v := range stmt.Lhs {
+		if h := gopVariableType(v, m, tf, info, q); h != nil {
+			hints = append(hints, *h)
+		}
+	}
+	return hints
+}
+
+// gopRangeVariableTypes adds the types of the variables of range statements
+// and of Go+ "for x <- ..." loops and list comprehensions.
+func gopRangeVariableTypes(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, q *types.Qualifier) []protocol.InlayHint {
+	var key, value ast.Expr
+	switch n := node.(type) {
+	case *ast.RangeStmt:
+		key, value = n.Key, n.Value
+	case *ast.ForPhrase: // also visited as part of *ast.ForPhraseStmt
+		key, value = gopIdentExpr(n.Key), gopIdentExpr(n.Value)
+	default:
+		return nil
+	}
+	var hints []protocol.InlayHint
+	if h := gopVariableType(key, m, tf, info, q); h != nil {
+		hints = append(hints, *h)
+	}
+	if h := gopVariableType(value, m, tf, info, q); h != nil {
+		hints = append(hints, *h)
+	}
+	return hints
+}
+
+// gopIdentExpr converts id to an ast.Expr, mapping a nil *ast.Ident to a nil
+// interface.
+func gopIdentExpr(id *ast.Ident) ast.Expr {
+	if id == nil {
+		return nil
+	}
+	return id
+}
+
+func gopVariableType(e ast.Expr, m *protocol.Mapper, tf *token.File, info *typesutil.Info, q *types.Qualifier) *protocol.InlayHint {
+	if e == nil {
+		return nil
+	}
+	typ := info.TypeOf(e)
+	if typ == nil {
+		return nil
+	}
+	end, err := m.PosPosition(tf, e.End())
+	if err != nil {
+		return nil
+	}
+	return &protocol.InlayHint{
+		Position:    end,
+		Label:       buildLabel(types.TypeString(typ, *q)),
+		Kind:        protocol.Type,
+		PaddingLeft: true,
+	}
+}
+
+func gopCompositeLiteralFields(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, q *types.Qualifier) []protocol.InlayHint {
+	compLit, ok := node.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	typ := info.TypeOf(compLit)
+	if typ == nil {
+		return nil
+	}
+	if t, ok := typ.(*types.Pointer); ok {
+		typ = t.Elem()
+	}
+	strct, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var hints []protocol.InlayHint
+	var allEdits []protocol.TextEdit
+	for i, v := range compLit.Elts {
+		if _, ok := v.(*ast.KeyValueExpr); !ok {
+			start, err := m.PosPosition(tf, v.Pos())
+			if err != nil {
+				continue
+			}
+			if i > strct.NumFields()-1 {
+				break
+			}
+			hints = append(hints, protocol.InlayHint{
+				Position:     start,
+				Label:        buildLabel(strct.Field(i).Name() + ":"),
+				Kind:         protocol.Parameter,
+				PaddingRight: true,
+			})
+			allEdits = append(allEdits, protocol.TextEdit{
+				Range:   protocol.Range{Start: start, End: start},
+				NewText: strct.Field(i).Name() + ": ",
+			})
+		}
+	}
+	// It is not allowed to have a mix of keyed and unkeyed fields, so
+	// have the text edits add keys to all fields.
+	for i := range hints {
+		hints[i].TextEdits = allEdits
+	}
+	return hints
+}
+
+// gopOverloadMembers shows which member of an overloaded function a call
+// was resolved to:
+//
+//	n.add/* Add__1*/ "hello"
+func gopOverloadMembers(node ast.Node, m *protocol.Mapper, tf *token.File, info *typesutil.Info, _ *types.Qualifier) []protocol.InlayHint {
+	callExpr, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	id := gopCallIdent(callExpr)
+	if id == nil {
+		return nil
+	}
+	if decl, _ := info.OverloadOf(id); decl == nil {
+		return nil
+	}
+	obj := info.ObjectOf(id)
+	if obj == nil {
+		return nil
+	}
+	end, err := m.PosPosition(tf, id.End())
+	if err != nil {
+		return nil
+	}
+	return []protocol.InlayHint{{
+		Position:    end,
+		Label:       buildLabel(obj.Name()),
+		PaddingLeft: true,
+	}}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+// This file defines the refactor.inline code action for Go+ files.
+
+import (
+	"context"
+	"fmt"
+	goast "go/ast"
+	"go/types"
+	"runtime/debug"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/gop/ast/astutil"
+	"golang.org/x/tools/gop/types/typeutil"
+	"golang.org/x/tools/gopls/internal/bug"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/safetoken"
+	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/event"
+	"golang.org/x/tools/internal/gop/refactor/inline"
+)
+
+// GopEnclosingStaticCall returns the innermost function call enclosing
+// the selected range, along with the callee, which may be declared in a
+// Go+ or a Go file.
+func GopEnclosingStaticCall(pkg Package, pgf *ParsedGopFile, rng protocol.Range) (*ast.CallExpr, *types.Func, error) {
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, _ := astutil.PathEnclosingInterval(pgf.File, start, end)
+
+	var call *ast.CallExpr
+loop:
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.LambdaExpr, *ast.LambdaExpr2:
+			break loop
+		case *ast.CallExpr:
+			call = n
+			break loop
+		}
+	}
+	if call == nil {
+		return nil, nil, fmt.Errorf("no enclosing call")
+	}
+	// A command-style call, such as `echo x`, has no parens.
+	lparen := call.Lparen
+	if call.IsCommand() {
+		lparen = call.Fun.End()
+	}
+	if safetoken.Line(pgf.Tok, lparen) != safetoken.Line(pgf.Tok, start) {
+		return nil, nil, fmt.Errorf("enclosing call is not on this line")
+	}
+	fn := typeutil.StaticCallee(pkg.GopTypesInfo(), call)
+	if fn == nil {
+		return nil, nil, fmt.Errorf("not a static call to a Go+ function")
+	}
+	return call, fn, nil
+}
+
+func gopInlineCall(ctx context.Context, snapshot Snapshot, fh FileHandle, rng protocol.Range) (_ *token.FileSet, _ *analysis.SuggestedFix, err error) {
+	// Find enclosing static call.
+	callerPkg, callerPGF, err := NarrowestPackageForGopFile(ctx, snapshot, fh.URI())
+	if err != nil {
+		return nil, nil, err
+	}
+	call, fn, err := GopEnclosingStaticCall(callerPkg, callerPGF, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The inliner assumes that input is well-typed,
+	// but that is frequently not the case within gopls.
+	// Until we are able to harden the inliner,
+	// report panics as errors to avoid crashing the server.
+	defer func() {
+		if x := recover(); x != nil {
+			err = bug.Errorf("inlining failed unexpectedly: %v\nstack: %v",
+				x, debug.Stack())
+		}
+	}()
+
+	// Users can consult the gopls event log to see
+	// why a particular inlining strategy was chosen.
+	logf := func(format string, args ...any) {
+		event.Log(ctx, "inliner: "+fmt.Sprintf(format, args...))
+	}
+
+	// Locate callee by file/line and analyze it.
+	callee, err := gopAnalyzeCallee(ctx, snapshot, logf, callerPkg.FileSet(), fn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Inline the call.
+	caller := &inline.Caller{
+		Fset:    callerPkg.FileSet(),
+		Types:   callerPkg.GetTypes(),
+		Info:    callerPkg.GopTypesInfo(),
+		File:    callerPGF.File,
+		Call:    call,
+		Content: callerPGF.Src,
+	}
+
+	got, err := inline.Inline(logf, caller, callee)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Suggest the fix.
+	return callerPkg.FileSet(), &analysis.SuggestedFix{
+		Message:   fmt.Sprintf("inline call of %v", callee),
+		TextEdits: gopDiffToTextEdits(callerPGF.Tok, diff.Bytes(callerPGF.Src, got)),
+	}, nil
+}
+
+// gopAnalyzeCallee analyzes fn, declared in a Go+ or a Go file, for
+// inlining into Go+ code.
+func gopAnalyzeCallee(ctx context.Context, snapshot Snapshot, logf func(string, ...any), fset *token.FileSet, fn *types.Func) (*inline.Callee, error) {
+	calleePosn := safetoken.StartPosition(fset, fn.Pos())
+	uri := span.URIFromPath(calleePosn.Filename)
+	fh, err := snapshot.ReadFile(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	samePos := func(fset *token.FileSet, pos token.Pos) bool {
+		posn := safetoken.StartPosition(fset, pos)
+		return posn.Line == calleePosn.Line && posn.Column == calleePosn.Column
+	}
+
+	// goxls: check Go/Go+ file kind
+	if snapshot.View().FileKind(fh) == Gop {
+		calleePkg, calleePGF, err := NarrowestPackageForGopFile(ctx, snapshot, uri)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range calleePGF.File.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && samePos(calleePkg.FileSet(), decl.Name.Pos()) {
+				return inline.AnalyzeCallee(logf, calleePkg.FileSet(), calleePkg.GetTypes(), calleePkg.GopTypesInfo(), decl, calleePGF.Src)
+			}
+		}
+		return nil, fmt.Errorf("can't find callee")
+	}
+
+	calleePkg, calleePGF, err := NarrowestPackageForFile(ctx, snapshot, uri)
+	if err != nil {
+		return nil, err
+	}
+	for _, decl := range calleePGF.File.Decls {
+		if decl, ok := decl.(*goast.FuncDecl); ok && samePos(calleePkg.FileSet(), decl.Name.Pos()) {
+			return inline.AnalyzeGoCallee(logf, calleePkg.FileSet(), calleePkg.GetTypes(), calleePkg.GetTypesInfo(), decl, calleePGF.Src)
+		}
+	}
+	return nil, fmt.Errorf("can't find callee")
+}
+
+// gopDiffToTextEdits converts diff edits of the file tok to analysis
+// text edits.
+func gopDiffToTextEdits(tok *token.File, diffs []diff.Edit) []analysis.TextEdit {
+	edits := make([]analysis.TextEdit, 0, len(diffs))
+	for _, edit := range diffs {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     tok.Pos(edit.Start),
+			End:     tok.Pos(edit.End),
+			NewText: []byte(edit.New),
+		})
+	}
+	return edits
+}
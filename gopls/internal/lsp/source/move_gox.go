@@ -0,0 +1,715 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package source
+
+// This file defines the code actions that move top-level declarations of
+// a Go+ file into a new or an existing Go+ file of the same package.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gopls/internal/goxls/imports"
+	"golang.org/x/tools/gopls/internal/goxls/parserutil"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	"golang.org/x/tools/gopls/internal/lsp/safetoken"
+	"golang.org/x/tools/gopls/internal/span"
+	"golang.org/x/tools/internal/diff"
+)
+
+// A GopMove describes the move of the selected declarations of a Go+ file
+// into another Go+ file. Its Kind is refactor.extract for a move into a new
+// file, and refactor.rewrite for a move into an existing one.
+type GopMove struct {
+	Title   string
+	Kind    protocol.CodeActionKind
+	Changes []protocol.DocumentChanges
+}
+
+// GopSelectedDecls returns the top-level declarations of file, other than
+// imports and top-level statements, that intersect the range [start, end).
+// An empty range selects the declaration whose header contains it.
+func GopSelectedDecls(file *ast.File, start, end token.Pos) []ast.Decl {
+	var decls []ast.Decl
+	for _, decl := range file.Decls {
+		declEnd := decl.End()
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+		case *ast.FuncDecl:
+			if decl.Shadow {
+				continue
+			}
+			if start == end && decl.Body != nil {
+				declEnd = decl.Body.Lbrace
+			}
+		}
+		if start == end {
+			if decl.Pos() <= start && start <= declEnd {
+				decls = append(decls, decl)
+			}
+		} else if start < declEnd && decl.Pos() < end {
+			decls = append(decls, decl)
+		}
+	}
+	return decls
+}
+
+// GopMoveDecls returns the moves of the top-level declarations of pgf
+// selected by rng into a new Go+ file, if the client supports file
+// creation, and into each other Go+ file of pkg that can hold them.
+func GopMoveDecls(ctx context.Context, snapshot Snapshot, pkg Package, pgf *ParsedGopFile, rng protocol.Range) ([]GopMove, error) {
+	start, end, err := pgf.RangePos(rng)
+	if err != nil {
+		return nil, err
+	}
+	decls := GopSelectedDecls(pgf.File, start, end)
+	if len(decls) == 0 {
+		return nil, nil
+	}
+	m := &gopMover{snapshot: snapshot, pkg: pkg, pgf: pgf, decls: decls}
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+
+	var moves []GopMove
+	for _, op := range snapshot.View().Options().SupportedResourceOperations {
+		if op == protocol.Create {
+			if move, err := m.toNewFile(ctx); err == nil {
+				moves = append(moves, *move)
+			}
+			break
+		}
+	}
+	for _, dst := range pkg.CompiledGopFiles() {
+		if dst.URI == pgf.URI {
+			continue
+		}
+		// Errors only mean that the declarations can't move to dst.
+		if move, err := m.toFile(ctx, dst); err == nil {
+			moves = append(moves, *move)
+		}
+	}
+	return moves, nil
+}
+
+// A gopMover moves declarations out of a Go+ file.
+type gopMover struct {
+	snapshot Snapshot
+	pkg      Package
+	pgf      *ParsedGopFile
+	decls    []ast.Decl
+
+	// Computed by init.
+	starts, ends []int       // offsets of the declarations, with their doc comments
+	deletes      []diff.Edit // deletion of the declarations from pgf
+	imports      []*imports.ImportFix
+	unused       []*imports.ImportFix
+}
+
+// init computes the parts of the move that don't depend on its target.
+func (m *gopMover) init() error {
+	src, tok := m.pgf.Src, m.pgf.Tok
+	for _, decl := range m.decls {
+		pos := decl.Pos()
+		if doc := gopDeclDoc(decl); doc != nil {
+			pos = doc.Pos()
+		}
+		start, end, err := safetoken.Offsets(tok, pos, decl.End())
+		if err != nil {
+			return err
+		}
+		m.starts = append(m.starts, start)
+		m.ends = append(m.ends, end)
+
+		// Delete the lines of the declaration, and the blank lines
+		// following it, or at the end of the file, those preceding it.
+		for start > 0 && src[start-1] != '\n' {
+			start--
+		}
+		for end < len(src) && src[end] != '\n' {
+			end++
+		}
+		next := end
+		for next < len(src) && (src[next] == '\n' || src[next] == ' ' || src[next] == '\t' || src[next] == '\r') {
+			if src[next] == '\n' {
+				end = next + 1
+			}
+			next++
+		}
+		if next == len(src) {
+			for start > 1 && src[start-1] == '\n' && src[start-2] == '\n' {
+				start--
+			}
+		}
+		m.deletes = append(m.deletes, diff.Edit{Start: start, End: end})
+	}
+	return m.initImports()
+}
+
+// initImports computes the imports that the moved declarations need, and
+// the imports of the source file that only they use.
+func (m *gopMover) initImports() error {
+	info := m.pkg.GopTypesInfo()
+	tok := m.pgf.Tok
+	moved := func(pos token.Pos) bool {
+		offset, err := safetoken.Offset(tok, pos)
+		if err != nil {
+			return false
+		}
+		for i := range m.starts {
+			if m.starts[i] <= offset && offset < m.ends[i] {
+				return true
+			}
+		}
+		return false
+	}
+	inFile := func(pos token.Pos) bool {
+		return tok.Base() <= int(pos) && int(pos) <= tok.Base()+tok.Size()
+	}
+
+	// Dot imports of the source file.
+	dots := make(map[*types.Package]*types.PkgName)
+	for _, spec := range m.pgf.File.Imports {
+		if spec.Name != nil && spec.Name.Name == "." {
+			pkgName, ok := GopImportedPkgName(info, spec)
+			if !ok {
+				pkgName, ok = info.Implicits[spec].(*types.PkgName)
+			}
+			if ok {
+				dots[pkgName.Imported()] = pkgName
+			}
+		}
+	}
+
+	type uses struct{ in, out int }
+	pkgNames := make(map[*types.PkgName]*uses)
+	for id, obj := range info.Uses {
+		if !inFile(id.Pos()) {
+			continue
+		}
+		pkgName, ok := obj.(*types.PkgName)
+		if !ok && obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+			pkgName = dots[obj.Pkg()] // reference through a dot import?
+		}
+		if pkgName == nil {
+			continue
+		}
+		u := pkgNames[pkgName]
+		if u == nil {
+			u = new(uses)
+			pkgNames[pkgName] = u
+		}
+		if moved(id.Pos()) {
+			u.in++
+		} else {
+			u.out++
+		}
+	}
+
+	for pkgName, u := range pkgNames {
+		if u.in == 0 {
+			continue
+		}
+		stmt := imports.ImportInfo{ImportPath: pkgName.Imported().Path()}
+		if pkgName.Name() != pkgName.Imported().Name() {
+			stmt.Name = pkgName.Name()
+		}
+		m.imports = append(m.imports, &imports.ImportFix{
+			StmtInfo:  stmt,
+			IdentName: pkgName.Name(),
+			FixType:   imports.AddImport,
+		})
+		// Implicit imports of class files have no import declaration.
+		if u.out == 0 && gopImportSpec(info, m.pgf.File, pkgName) != nil {
+			m.unused = append(m.unused, &imports.ImportFix{
+				StmtInfo:  stmt,
+				IdentName: pkgName.Name(),
+				FixType:   imports.DeleteImport,
+			})
+		}
+	}
+	for _, fixes := range [][]*imports.ImportFix{m.imports, m.unused} {
+		sort.Slice(fixes, func(i, j int) bool {
+			return fixes[i].StmtInfo.ImportPath < fixes[j].StmtInfo.ImportPath
+		})
+	}
+	return nil
+}
+
+// toNewFile returns the move of the declarations into a new Go+ file
+// named after the first of them.
+func (m *gopMover) toNewFile(ctx context.Context) (*GopMove, error) {
+	texts, notes, err := m.convert(&ast.File{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(m.pgf.URI.Filename())
+	base := strings.ToLower(gopDeclName(m.decls[0]))
+	if base == "" || base[0] == '_' {
+		base = "decls" // files named _* are ignored
+	}
+	var uri span.URI
+	for i := 0; ; i++ {
+		name := base + ".gop"
+		if i > 0 {
+			name = fmt.Sprintf("%s%d.gop", base, i)
+		}
+		uri = span.URIFromPath(filepath.Join(dir, name))
+		fh, err := m.snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fh.Content(); err != nil {
+			break // the file doesn't exist
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", m.pkg.GetTypes().Name())
+	buf.WriteString(strings.Join(texts, "\n\n"))
+	buf.WriteString("\n")
+	content, err := imports.ApplyFixes(m.imports, uri.Filename(), buf.Bytes(), gopImportOptions(m.snapshot), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	srcEdits, err := m.sourceEdits()
+	if err != nil {
+		return nil, err
+	}
+	title := fmt.Sprintf("Extract %s to new file", m.what())
+	protocolURI := protocol.URIFromSpanURI(uri)
+	return &GopMove{
+		Title: gopMoveTitle(title, notes),
+		Kind:  protocol.RefactorExtract,
+		Changes: []protocol.DocumentChanges{
+			{CreateFile: &protocol.CreateFile{Kind: "create", URI: protocolURI}},
+			{TextDocumentEdit: &protocol.TextDocumentEdit{
+				TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: protocolURI},
+				},
+				Edits: []protocol.TextEdit{{NewText: string(content)}},
+			}},
+			gopTextDocumentEdit(m.pgf.URI, m.version(ctx, m.pgf.URI), srcEdits),
+		},
+	}, nil
+}
+
+// toFile returns the move of the declarations to the end of dst.
+func (m *gopMover) toFile(ctx context.Context, dst *ParsedGopFile) (*GopMove, error) {
+	texts, notes, err := m.convert(dst.File, gopClassRecv(m.pkg, dst))
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the missing imports, unless their names are taken.
+	info := m.pkg.GopTypesInfo()
+	var fixes []*imports.ImportFix
+	for _, fix := range m.imports {
+		missing := true
+		for _, spec := range dst.File.Imports {
+			pkgName, ok := GopImportedPkgName(info, spec)
+			if !ok || pkgName.Name() != fix.IdentName {
+				continue
+			}
+			if pkgName.Imported().Path() != fix.StmtInfo.ImportPath {
+				return nil, fmt.Errorf("%s imports another package as %s", dst.URI.Filename(), fix.IdentName)
+			}
+			missing = false
+		}
+		if missing {
+			fixes = append(fixes, fix)
+		}
+	}
+	var dstEdits []protocol.TextEdit
+	if len(fixes) > 0 {
+		dstEdits, err = gopComputeFixEdits(m.snapshot, dst, gopImportOptions(m.snapshot), fixes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	text := "\n" + strings.Join(texts, "\n\n") + "\n"
+	if !bytes.HasSuffix(dst.Src, []byte("\n")) {
+		text = "\n" + text
+	}
+	rng, err := dst.Mapper.OffsetRange(len(dst.Src), len(dst.Src))
+	if err != nil {
+		return nil, err
+	}
+	dstEdits = append(dstEdits, protocol.TextEdit{Range: rng, NewText: text})
+
+	srcEdits, err := m.sourceEdits()
+	if err != nil {
+		return nil, err
+	}
+	title := fmt.Sprintf("Move %s to %s", m.what(), filepath.Base(dst.URI.Filename()))
+	return &GopMove{
+		Title: gopMoveTitle(title, notes),
+		Kind:  protocol.RefactorRewrite,
+		Changes: []protocol.DocumentChanges{
+			gopTextDocumentEdit(dst.URI, m.version(ctx, dst.URI), dstEdits),
+			gopTextDocumentEdit(m.pgf.URI, m.version(ctx, m.pgf.URI), srcEdits),
+		},
+	}, nil
+}
+
+// sourceEdits returns the edits of the source file that delete the moved
+// declarations and the imports that only they use.
+func (m *gopMover) sourceEdits() ([]protocol.TextEdit, error) {
+	var edits []protocol.TextEdit
+	if len(m.unused) > 0 {
+		importEdits, err := gopComputeFixEdits(m.snapshot, m.pgf, gopImportOptions(m.snapshot), m.unused)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, importEdits...)
+	}
+	for _, del := range m.deletes {
+		rng, err := m.pgf.Mapper.OffsetRange(del.Start, del.End)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, protocol.TextEdit{Range: rng})
+	}
+	return edits, nil
+}
+
+// convert returns the source of the declarations as they appear in dst,
+// a Go+ class file with methods of type dstRecv if dstRecv isn't nil,
+// and notes on how they were converted. It reports an error if the
+// declarations can't move to dst.
+func (m *gopMover) convert(dst *ast.File, dstRecv types.Type) (texts, notes []string, err error) {
+	info := m.pkg.GopTypesInfo()
+	src, file := m.pgf.Src, m.pgf.File
+	qual := func(p *types.Package) string {
+		if p == m.pkg.GetTypes() {
+			return ""
+		}
+		return p.Name()
+	}
+	note := func(format string, args ...any) {
+		s := fmt.Sprintf(format, args...)
+		for _, n := range notes {
+			if n == s {
+				return
+			}
+		}
+		notes = append(notes, s)
+	}
+
+	for i, decl := range m.decls {
+		start := m.starts[i]
+		var edits []diff.Edit // relative to start
+		edit := func(pos, end token.Pos, new string) {
+			s, e, err := safetoken.Offsets(m.pgf.Tok, pos, end)
+			if err == nil {
+				edits = append(edits, diff.Edit{Start: s - start, End: e - start, New: new})
+			}
+		}
+
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.VAR {
+				if file.IsClass {
+					return nil, nil, fmt.Errorf("cannot move the fields of a Go+ class")
+				}
+				if dst.IsClass {
+					return nil, nil, fmt.Errorf("variables would become fields of a Go+ class")
+				}
+			}
+
+		case *ast.OverloadFuncDecl:
+			if file.IsClass || dst.IsClass {
+				return nil, nil, fmt.Errorf("cannot move overloaded functions of a Go+ class file")
+			}
+
+		case *ast.FuncDecl:
+			fn, ok := info.Defs[decl.Name].(*types.Func)
+			if !ok {
+				return nil, nil, fmt.Errorf("no type information for %s", decl.Name.Name)
+			}
+			sig := fn.Type().(*types.Signature)
+			switch {
+			case gopIsClassMethod(file, decl):
+				// Class methods have an implicit receiver named this.
+				if decl.Operator || sig.Recv() == nil {
+					return nil, nil, fmt.Errorf("cannot move operator %s of a Go+ class", decl.Name.Name)
+				}
+				recv := types.TypeString(sig.Recv().Type(), qual)
+				if decl.Static {
+					// func .New() => func T.New()
+					edit(decl.Type.Func+token.Pos(len("func")), decl.Name.Pos(), " "+strings.TrimPrefix(recv, "*")+".")
+					note("class methods become static methods of %s", strings.TrimPrefix(recv, "*"))
+				} else {
+					edit(decl.Name.Pos(), decl.Name.Pos(), "(this "+recv+") ")
+					for _, id := range gopImplicitThisRefs(info, decl.Body) {
+						edit(id.Pos(), id.Pos(), "this.")
+					}
+					note("class methods become methods of %s", recv)
+				}
+
+			case decl.Recv == nil || len(decl.Recv.List) == 0:
+				if dst.IsClass {
+					return nil, nil, fmt.Errorf("functions would become methods of a Go+ class")
+				}
+
+			case dstRecv == nil || decl.Operator:
+				// Methods with explicit receivers move unchanged.
+
+			case decl.Static:
+				// func T.New() => func .New()
+				typ := decl.Recv.List[0].Type
+				if tname, ok := gopTypeNameOf(info, typ); ok && types.Identical(types.NewPointer(tname.Type()), dstRecv) {
+					edit(typ.Pos(), typ.End(), "")
+					note("static methods of %s become class methods", tname.Name())
+				}
+
+			case types.Identical(sig.Recv().Type(), dstRecv):
+				// func (r *T) M() => func M(), where r becomes this.
+				if refs, ok := gopReceiverRefs(info, decl, sig.Recv()); ok {
+					edit(decl.Recv.Opening, decl.Name.Pos(), "")
+					for _, id := range refs {
+						edit(id.Pos(), id.End(), "this")
+					}
+					note("methods of %s become class methods", types.TypeString(dstRecv, qual))
+				}
+			}
+		}
+
+		text, err := diff.Apply(string(src[start:m.ends[i]]), edits)
+		if err != nil {
+			return nil, nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, notes, nil
+}
+
+// what describes the moved declarations in action titles.
+func (m *gopMover) what() string {
+	if len(m.decls) == 1 {
+		if name := gopDeclName(m.decls[0]); name != "" {
+			return name
+		}
+	}
+	return "declarations"
+}
+
+// version returns the version of the file uri, or 0 if unknown.
+func (m *gopMover) version(ctx context.Context, uri span.URI) int32 {
+	fh, err := m.snapshot.ReadFile(ctx, uri)
+	if err != nil {
+		return 0
+	}
+	return fh.Version()
+}
+
+func gopImportOptions(snapshot Snapshot) *imports.Options {
+	return &imports.Options{
+		LocalPrefix: snapshot.View().Options().Local,
+		// Defaults.
+		AllErrors:  true,
+		Comments:   true,
+		Fragment:   true,
+		FormatOnly: false,
+		TabIndent:  true,
+		TabWidth:   8,
+	}
+}
+
+func gopTextDocumentEdit(uri span.URI, version int32, edits []protocol.TextEdit) protocol.DocumentChanges {
+	return protocol.DocumentChanges{
+		TextDocumentEdit: &protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				Version: version,
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{
+					URI: protocol.URIFromSpanURI(uri),
+				},
+			},
+			Edits: edits,
+		},
+	}
+}
+
+func gopMoveTitle(title string, notes []string) string {
+	if len(notes) == 0 {
+		return title
+	}
+	return title + " (" + strings.Join(notes, "; ") + ")"
+}
+
+// gopIsClassMethod reports whether decl, declared in file, is a method
+// of a Go+ class with an implicit receiver.
+func gopIsClassMethod(file *ast.File, decl *ast.FuncDecl) bool {
+	// The type checker sets the receiver of class methods.
+	return file.IsClass && (decl.IsClass || decl.Recv == nil || len(decl.Recv.List) == 0)
+}
+
+// gopClassRecv returns the receiver type *T of the methods of the Go+
+// class declared by pgf, or nil if pgf isn't a class file.
+func gopClassRecv(pkg Package, pgf *ParsedGopFile) types.Type {
+	if !pgf.File.IsClass {
+		return nil
+	}
+	info := pkg.GopTypesInfo()
+	for _, decl := range pgf.File.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok && gopIsClassMethod(pgf.File, decl) {
+			if fn, ok := info.Defs[decl.Name].(*types.Func); ok {
+				if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+					return recv.Type()
+				}
+			}
+		}
+	}
+	if name, ok := parserutil.GetClassType(pgf.File, pgf.URI.Filename()); ok {
+		if tname, ok := pkg.GetTypes().Scope().Lookup(name).(*types.TypeName); ok {
+			return types.NewPointer(tname.Type())
+		}
+	}
+	return nil
+}
+
+// gopImplicitThisRefs returns the identifiers of body, the body of a Go+
+// class method, that refer to fields and methods of the implicit receiver.
+func gopImplicitThisRefs(info *typesutil.Info, body *ast.BlockStmt) []*ast.Ident {
+	var refs []*ast.Ident
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, visit) // not n.Sel
+			return false
+		case *ast.CompositeLit:
+			if t := info.TypeOf(n); t != nil && isStruct(t) {
+				ast.Inspect(n.Type, visit)
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						elt = kv.Value // not the field name
+					}
+					ast.Inspect(elt, visit)
+				}
+				return false
+			}
+		case *ast.Ident:
+			switch obj := info.Uses[n].(type) {
+			case *types.Var:
+				if obj.IsField() {
+					refs = append(refs, n)
+				}
+			case *types.Func:
+				if obj.Type().(*types.Signature).Recv() != nil {
+					refs = append(refs, n)
+				}
+			}
+		}
+		return true
+	}
+	if body != nil {
+		ast.Inspect(body, visit)
+	}
+	return refs
+}
+
+func isStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// gopReceiverRefs returns the references to recv, the receiver of decl,
+// in its body. It reports false if the receiver can't be renamed this.
+func gopReceiverRefs(info *typesutil.Info, decl *ast.FuncDecl, recv *types.Var) (refs []*ast.Ident, ok bool) {
+	ok = true
+	if decl.Body != nil {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if id, isIdent := n.(*ast.Ident); isIdent {
+				if info.Uses[id] == recv {
+					refs = append(refs, id)
+				} else if id.Name == "this" {
+					ok = false // this already means something else
+				}
+			}
+			return ok
+		})
+	}
+	if recv.Name() == "this" {
+		refs = nil // nothing to rename
+	}
+	return refs, ok
+}
+
+// gopTypeNameOf returns the type name denoted by the expression typ.
+func gopTypeNameOf(info *typesutil.Info, typ ast.Expr) (*types.TypeName, bool) {
+	var id *ast.Ident
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		id = typ
+	case *ast.SelectorExpr:
+		id = typ.Sel
+	}
+	if id == nil {
+		return nil, false
+	}
+	tname, ok := info.Uses[id].(*types.TypeName)
+	return tname, ok
+}
+
+// gopImportSpec returns the import declaration of file for pkgName, or
+// nil if there is none.
+func gopImportSpec(info *typesutil.Info, file *ast.File, pkgName *types.PkgName) *ast.ImportSpec {
+	for _, spec := range file.Imports {
+		if obj, ok := GopImportedPkgName(info, spec); ok && obj == pkgName {
+			return spec
+		}
+	}
+	return nil
+}
+
+// gopDeclDoc returns the doc comment of decl, if any.
+func gopDeclDoc(decl ast.Decl) *ast.CommentGroup {
+	switch decl := decl.(type) {
+	case *ast.GenDecl:
+		return decl.Doc
+	case *ast.FuncDecl:
+		return decl.Doc
+	case *ast.OverloadFuncDecl:
+		return decl.Doc
+	}
+	return nil
+}
+
+// gopDeclName returns the name of the first entity declared by decl.
+func gopDeclName(decl ast.Decl) string {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		return decl.Name.Name
+	case *ast.OverloadFuncDecl:
+		return decl.Name.Name
+	case *ast.GenDecl:
+		if len(decl.Specs) > 0 {
+			switch spec := decl.Specs[0].(type) {
+			case *ast.TypeSpec:
+				return spec.Name.Name
+			case *ast.ValueSpec:
+				if len(spec.Names) > 0 {
+					return spec.Names[0].Name
+				}
+			}
+		}
+	}
+	return ""
+}
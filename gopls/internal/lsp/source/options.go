@@ -112,15 +112,18 @@ func DefaultOptions() *Options {
 						protocol.SourceOrganizeImports: true,
 						protocol.QuickFix:              true,
 						protocol.RefactorRewrite:       true,
+						protocol.RefactorInline:        true,
 						protocol.RefactorExtract:       true,
+						protocol.SourceGopStyle:        true,
 					},
 					Mod: {
 						protocol.SourceOrganizeImports: true,
 						protocol.QuickFix:              true,
 					},
-					Work: {},
-					Sum:  {},
-					Tmpl: {},
+					Work:   {},
+					Sum:    {},
+					Tmpl:   {},
+					GopMod: {}, // goxls: gop.mod
 				},
 				SupportedCommands: commands,
 			},
@@ -169,6 +172,7 @@ func DefaultOptions() *Options {
 						string(command.UpgradeDependency): true,
 						string(command.Vendor):            true,
 						string(command.RunGopCommand):     true, //goxls: option
+						string(command.GopModRegister):    true, //goxls: option
 						// TODO(hyangah): enable command.RunGovulncheck.
 					},
 				},
@@ -188,7 +192,7 @@ func DefaultOptions() *Options {
 				// TODO(adonovan): switch to new diff.Strings implementation.
 				ComputeEdits:         myers.ComputeEdits,
 				URLRegexp:            urlRegexp(),
-				DefaultAnalyzers:     defaultAnalyzers(),
+				DefaultAnalyzers:     withGopAnalyzers(defaultAnalyzers()), // goxls: Go+ vet suite
 				TypeErrorAnalyzers:   typeErrorAnalyzers(),
 				ConvenienceAnalyzers: convenienceAnalyzers(),
 				StaticcheckAnalyzers: map[string]*Analyzer{},
@@ -568,6 +572,12 @@ type Hooks struct {
 	// Gofumpt formatting rules -- see the Gofumpt documentation for details.
 	GofumptFormat func(ctx context.Context, langVersion, modulePath string, src []byte) ([]byte, error)
 
+	// goxls: GopFormat is the Go+ counterpart of GofumptFormat, which only
+	// understands Go syntax. If set, it is applied to all formatted Go+
+	// files, whatever the gofumpt setting. class reports whether src is a
+	// classfile.
+	GopFormat func(ctx context.Context, langVersion, modulePath string, src []byte, class bool) ([]byte, error)
+
 	DefaultAnalyzers     map[string]*Analyzer
 	TypeErrorAnalyzers   map[string]*Analyzer
 	ConvenienceAnalyzers map[string]*Analyzer
@@ -881,6 +891,7 @@ func (o *Options) Clone() *Options {
 			StaticcheckSupported: o.StaticcheckSupported,
 			ComputeEdits:         o.ComputeEdits,
 			GofumptFormat:        o.GofumptFormat,
+			GopFormat:            o.GopFormat, // goxls: Go+
 			URLRegexp:            o.URLRegexp,
 		},
 		ServerOptions: o.ServerOptions,
@@ -1509,10 +1520,18 @@ func typeErrorAnalyzers() map[string]*Analyzer {
 			Analyzer: nonewvars.Analyzer,
 			Enabled:  true,
 		},
+		nonewvars.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer: nonewvars.GopAnalyzer,
+			Enabled:  true,
+		},
 		noresultvalues.Analyzer.Name: {
 			Analyzer: noresultvalues.Analyzer,
 			Enabled:  true,
 		},
+		noresultvalues.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer: noresultvalues.GopAnalyzer,
+			Enabled:  true,
+		},
 		undeclaredname.Analyzer.Name: {
 			Analyzer: undeclaredname.Analyzer,
 			Fix:      UndeclaredName,
@@ -1522,6 +1541,10 @@ func typeErrorAnalyzers() map[string]*Analyzer {
 			Analyzer: unusedvariable.Analyzer,
 			Enabled:  false,
 		},
+		unusedvariable.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer: unusedvariable.GopAnalyzer,
+			Enabled:  false,
+		},
 	}
 }
 
@@ -1560,6 +1583,7 @@ func defaultAnalyzers() map[string]*Analyzer {
 		composite.Analyzer.Name:     {Analyzer: composite.Analyzer, Enabled: true},
 		copylock.Analyzer.Name:      {Analyzer: copylock.Analyzer, Enabled: true},
 		deprecated.Analyzer.Name:    {Analyzer: deprecated.Analyzer, Enabled: true, Severity: protocol.SeverityHint, Tag: []protocol.DiagnosticTag{protocol.Deprecated}},
+		deprecated.GopAnalyzer.Name: {Analyzer: deprecated.GopAnalyzer, Enabled: true, Severity: protocol.SeverityHint, Tag: []protocol.DiagnosticTag{protocol.Deprecated}}, // goxls: Go+
 		directive.Analyzer.Name:     {Analyzer: directive.Analyzer, Enabled: true},
 		errorsas.Analyzer.Name:      {Analyzer: errorsas.Analyzer, Enabled: true},
 		httpresponse.Analyzer.Name:  {Analyzer: httpresponse.Analyzer, Enabled: true},
@@ -1587,8 +1611,10 @@ func defaultAnalyzers() map[string]*Analyzer {
 		sortslice.Analyzer.Name:        {Analyzer: sortslice.Analyzer, Enabled: true},
 		testinggoroutine.Analyzer.Name: {Analyzer: testinggoroutine.Analyzer, Enabled: true},
 		unusedparams.Analyzer.Name:     {Analyzer: unusedparams.Analyzer, Enabled: false},
+		unusedparams.GopAnalyzer.Name:  {Analyzer: unusedparams.GopAnalyzer, Enabled: false}, // goxls: Go+
 		unusedwrite.Analyzer.Name:      {Analyzer: unusedwrite.Analyzer, Enabled: false},
 		useany.Analyzer.Name:           {Analyzer: useany.Analyzer, Enabled: false},
+		useany.GopAnalyzer.Name:        {Analyzer: useany.GopAnalyzer, Enabled: false}, // goxls: Go+
 		timeformat.Analyzer.Name:       {Analyzer: timeformat.Analyzer, Enabled: true},
 		embeddirective.Analyzer.Name: {
 			Analyzer:        embeddirective.Analyzer,
@@ -1603,16 +1629,31 @@ func defaultAnalyzers() map[string]*Analyzer {
 			Enabled:    true,
 			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
 		},
+		simplifycompositelit.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer:   simplifycompositelit.GopAnalyzer,
+			Enabled:    true,
+			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
+		},
 		simplifyrange.Analyzer.Name: {
 			Analyzer:   simplifyrange.Analyzer,
 			Enabled:    true,
 			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
 		},
+		simplifyrange.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer:   simplifyrange.GopAnalyzer,
+			Enabled:    true,
+			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
+		},
 		simplifyslice.Analyzer.Name: {
 			Analyzer:   simplifyslice.Analyzer,
 			Enabled:    true,
 			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
 		},
+		simplifyslice.GopAnalyzer.Name: { // goxls: use Go+ Analyzer
+			Analyzer:   simplifyslice.GopAnalyzer,
+			Enabled:    true,
+			ActionKind: []protocol.CodeActionKind{protocol.SourceFixAll, protocol.QuickFix},
+		},
 	}
 }
 
@@ -3,3 +3,96 @@
 // license that can be found in the LICENSE file.
 
 package source
+
+import (
+	goxanalysis "golang.org/x/tools/gop/analysis"
+	"golang.org/x/tools/gop/analysis/passes/assign"
+	"golang.org/x/tools/gop/analysis/passes/atomic"
+	"golang.org/x/tools/gop/analysis/passes/atomicalign"
+	"golang.org/x/tools/gop/analysis/passes/bools"
+	"golang.org/x/tools/gop/analysis/passes/buildtag"
+	"golang.org/x/tools/gop/analysis/passes/classshadow"
+	"golang.org/x/tools/gop/analysis/passes/composite"
+	"golang.org/x/tools/gop/analysis/passes/copylock"
+	"golang.org/x/tools/gop/analysis/passes/deepequalerrors"
+	"golang.org/x/tools/gop/analysis/passes/directive"
+	"golang.org/x/tools/gop/analysis/passes/errorsas"
+	"golang.org/x/tools/gop/analysis/passes/errwrap"
+	"golang.org/x/tools/gop/analysis/passes/fieldalignment"
+	"golang.org/x/tools/gop/analysis/passes/httpresponse"
+	"golang.org/x/tools/gop/analysis/passes/ifaceassert"
+	"golang.org/x/tools/gop/analysis/passes/lambdaparams"
+	"golang.org/x/tools/gop/analysis/passes/loopclosure"
+	"golang.org/x/tools/gop/analysis/passes/lostcancel"
+	"golang.org/x/tools/gop/analysis/passes/nilfunc"
+	"golang.org/x/tools/gop/analysis/passes/nilness"
+	"golang.org/x/tools/gop/analysis/passes/overload"
+	"golang.org/x/tools/gop/analysis/passes/printf"
+	"golang.org/x/tools/gop/analysis/passes/shadow"
+	"golang.org/x/tools/gop/analysis/passes/shift"
+	"golang.org/x/tools/gop/analysis/passes/sortslice"
+	"golang.org/x/tools/gop/analysis/passes/stdmethods"
+	"golang.org/x/tools/gop/analysis/passes/stringintconv"
+	"golang.org/x/tools/gop/analysis/passes/structtag"
+	"golang.org/x/tools/gop/analysis/passes/testinggoroutine"
+	"golang.org/x/tools/gop/analysis/passes/tests"
+	"golang.org/x/tools/gop/analysis/passes/timeformat"
+	"golang.org/x/tools/gop/analysis/passes/unmarshal"
+	"golang.org/x/tools/gop/analysis/passes/unreachable"
+	"golang.org/x/tools/gop/analysis/passes/unsafeptr"
+	"golang.org/x/tools/gop/analysis/passes/unusedresult"
+	"golang.org/x/tools/gop/analysis/passes/unusedwrite"
+)
+
+// withGopAnalyzers adds the Go+ variants of the vet suite to the default
+// analyzers, which report their diagnostics on Go+ files. They are enabled
+// like their Go counterparts. The analyzers of Go+ idioms come last.
+func withGopAnalyzers(analyzers map[string]*Analyzer) map[string]*Analyzer {
+	gopAnalyzers := []*Analyzer{
+		// The traditional vet suite:
+		{Analyzer: assign.Analyzer, Enabled: true},
+		{Analyzer: atomic.Analyzer, Enabled: true},
+		{Analyzer: bools.Analyzer, Enabled: true},
+		{Analyzer: buildtag.Analyzer, Enabled: true},
+		{Analyzer: composite.Analyzer, Enabled: true},
+		{Analyzer: copylock.Analyzer, Enabled: true},
+		{Analyzer: directive.Analyzer, Enabled: true},
+		{Analyzer: errorsas.Analyzer, Enabled: true},
+		{Analyzer: httpresponse.Analyzer, Enabled: true},
+		{Analyzer: ifaceassert.Analyzer, Enabled: true},
+		{Analyzer: loopclosure.Analyzer, Enabled: true},
+		{Analyzer: lostcancel.Analyzer, Enabled: true},
+		{Analyzer: nilfunc.Analyzer, Enabled: true},
+		{Analyzer: printf.Analyzer, Enabled: true},
+		{Analyzer: shift.Analyzer, Enabled: true},
+		{Analyzer: stdmethods.Analyzer, Enabled: true},
+		{Analyzer: stringintconv.Analyzer, Enabled: true},
+		{Analyzer: structtag.Analyzer, Enabled: true},
+		{Analyzer: tests.Analyzer, Enabled: true},
+		{Analyzer: unmarshal.Analyzer, Enabled: true},
+		{Analyzer: unreachable.Analyzer, Enabled: true},
+		{Analyzer: unsafeptr.Analyzer, Enabled: true},
+		{Analyzer: unusedresult.Analyzer, Enabled: true},
+
+		// Non-vet analyzers:
+		{Analyzer: atomicalign.Analyzer, Enabled: true},
+		{Analyzer: deepequalerrors.Analyzer, Enabled: true},
+		{Analyzer: fieldalignment.Analyzer, Enabled: false},
+		{Analyzer: nilness.Analyzer, Enabled: false},
+		{Analyzer: shadow.Analyzer, Enabled: false},
+		{Analyzer: sortslice.Analyzer, Enabled: true},
+		{Analyzer: testinggoroutine.Analyzer, Enabled: true},
+		{Analyzer: unusedwrite.Analyzer, Enabled: false},
+		{Analyzer: timeformat.Analyzer, Enabled: true},
+
+		// Go+ analyzers:
+		{Analyzer: classshadow.Analyzer, Enabled: true},
+		{Analyzer: errwrap.Analyzer, Enabled: true},
+		{Analyzer: lambdaparams.Analyzer, Enabled: false},
+		{Analyzer: overload.Analyzer, Enabled: true},
+	}
+	for _, a := range gopAnalyzers {
+		analyzers[goxanalysis.Name(a.Analyzer)] = a
+	}
+	return analyzers
+}
@@ -136,6 +136,16 @@ FindCall:
 		return nil, 0, 0, fmt.Errorf("cannot find signature for Fun %[1]T (%[1]v)", callExpr.Fun)
 	}
 
+	// The type of the called function is its generic signature, as the Go+
+	// compiler does not record the instantiated one.
+	var inferred *types.Signature
+	if callExpr != nil {
+		inferred = gopInferCallSignature(pkg.GopTypesInfo(), callExpr)
+		if inferred != nil && sig.TypeParams().Len() > 0 {
+			sig = inferred
+		}
+	}
+
 	activeParam := gopActiveParameter(callExpr, sig.Params().Len(), sig.Variadic(), pos)
 
 	_, overloads := pkg.GopTypesInfo().OverloadOf(ident)
@@ -176,10 +186,14 @@ FindCall:
 		activeSignature := 0
 		infos := make([]protocol.SignatureInformation, len(overloads))
 		for i, o := range overloads {
+			osig := o.Type().(*types.Signature)
 			if o.Name() == obj.Name() {
 				activeSignature = i
+				if inferred != nil {
+					osig = inferred
+				}
 			}
-			info, err := makeInfo(o.Name(), o.Type().(*types.Signature))
+			info, err := makeInfo(o.Name(), osig)
 			if err != nil {
 				return nil, 0, 0, nil
 			}
@@ -262,6 +262,11 @@ type Snapshot interface {
 	// GopModForFile returns gop module for gop file by uri.
 	// It returns an error if the context was cancelled.
 	GopModForFile(ctx context.Context, uri span.URI) (*gopmod.Module, error)
+
+	// GopListModules returns the modules of the given paths, as listed by
+	// go list -m in the directory of the gop.mod file uri. The result is
+	// cached, keyed on the content of the go.mod and gop.mod files.
+	GopListModules(ctx context.Context, uri span.URI, paths []string) ([]*GopModule, error)
 }
 
 // NarrowestMetadataForFile returns metadata for the narrowest package
@@ -867,6 +872,8 @@ const (
 	Work
 	// goxls: Gop is a Go+ file.
 	Gop
+	// goxls: GopMod is a gop.mod file.
+	GopMod
 )
 
 func (k FileKind) String() string {
@@ -883,6 +890,8 @@ func (k FileKind) String() string {
 		return "go.work"
 	case Gop: // goxls: Gop is a Go+ file
 		return "gop"
+	case GopMod: // goxls: GopMod is a gop.mod file
+		return "gop.mod"
 	default:
 		return fmt.Sprintf("internal error: unknown file kind %d", k)
 	}
@@ -1069,6 +1078,7 @@ const (
 	TemplateError            DiagnosticSource = "template"
 	WorkFileError            DiagnosticSource = "go.work file"
 	ConsistencyInfo          DiagnosticSource = "consistency"
+	GopModError              DiagnosticSource = "gop.mod file" // goxls: gop.mod
 )
 
 func AnalyzerErrorKind(name string) DiagnosticSource {
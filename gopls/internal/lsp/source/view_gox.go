@@ -124,6 +124,12 @@ func (pgf *ParsedGopFile) PosRange(start, end token.Pos) (protocol.Range, error)
 	return pgf.Mapper.PosRange(pgf.Tok, start, end)
 }
 
+// PosMappedRange returns a MappedRange for the token.Pos interval in this file.
+// A MappedRange can be converted to any other form.
+func (pgf *ParsedGopFile) PosMappedRange(start, end token.Pos) (protocol.MappedRange, error) {
+	return pgf.Mapper.PosMappedRange(pgf.Tok, start, end)
+}
+
 // PosLocation returns a protocol Location for the token.Pos interval in this file.
 func (pgf *ParsedGopFile) PosLocation(start, end token.Pos) (protocol.Location, error) {
 	return pgf.Mapper.PosLocation(pgf.Tok, start, end)
@@ -185,3 +191,11 @@ func NarrowestPackageForGopFile(ctx context.Context, snapshot Snapshot, uri span
 	}
 	return pkg, pgf, err
 }
+
+// A GopModule is a module listed by go list -m, see Snapshot.GopListModules.
+type GopModule struct {
+	Path    string
+	Version string
+	Dir     string // directory of the module in the module cache, if any
+	Err     string // error finding the module, eg. if it isn't required
+}
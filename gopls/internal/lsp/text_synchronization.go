@@ -13,7 +13,6 @@ import (
 	"strings"
 	"sync"
 
-	"golang.org/x/tools/gop/goputil"
 	"golang.org/x/tools/gop/langserver"
 	"golang.org/x/tools/gopls/internal/lsp/protocol"
 	"golang.org/x/tools/gopls/internal/lsp/source"
@@ -53,6 +52,10 @@ const (
 	// FromInitialWorkspaceLoad refers to the loading of all packages in the
 	// workspace when the view is first created.
 	FromInitialWorkspaceLoad
+
+	// FromRegenerateGop refers to file modifications caused by regenerating
+	// the gop_autogen.go files of Go+ packages.
+	FromRegenerateGop // goxls: Go+
 )
 
 func (m ModificationSource) String() string {
@@ -71,6 +74,8 @@ func (m ModificationSource) String() string {
 		return "regenerate cgo"
 	case FromInitialWorkspaceLoad:
 		return "initial workspace load"
+	case FromRegenerateGop: // goxls: Go+
+		return "regenerate gop"
 	default:
 		return "unknown file modification"
 	}
@@ -197,9 +202,8 @@ func (s *Server) didChangeWatchedFiles(ctx context.Context, params *protocol.Did
 		})
 
 		// goxls: Go+
-		file := uri.Filename()
-		if fext := filepath.Ext(file); goputil.FileKind(fext) != goputil.FileUnknown {
-			gopFiles = append(gopFiles, file)
+		if s.isGopFile(uri) {
+			gopFiles = append(gopFiles, uri.Filename())
 		}
 	}
 
@@ -221,9 +225,8 @@ func (s *Server) didSave(ctx context.Context, params *protocol.DidSaveTextDocume
 	}
 
 	// goxls: Go+
-	file := uri.Filename()
-	if fext := filepath.Ext(file); goputil.FileKind(fext) != goputil.FileUnknown {
-		langserver.Changed(ctx, file)
+	if s.isGopFile(uri) {
+		langserver.Changed(ctx, uri.Filename())
 	}
 
 	c := source.FileModification{
@@ -0,0 +1,277 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package codelens
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+
+	"golang.org/x/tools/gopls/internal/lsp/command"
+)
+
+// fakeGop installs a fake gop command in a temporary directory on PATH. For
+// "gop run" and "gop test" it records its arguments in the file gop.args of
+// its working directory, echoes them, and then exits with the given status.
+// Any other command, such as the "gop serve" of the Go+ language server, runs
+// the real gop command if there is one, since the language server outlives
+// the test, and otherwise exits at once: the clients of the language server
+// only log its errors.
+func fakeGop(t *testing.T, status int) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gop command requires a POSIX shell")
+	}
+	other := "exit 0"
+	if gop, err := exec.LookPath("gop"); err == nil {
+		other = fmt.Sprintf("exec %q \"$@\"", gop)
+	}
+	dir := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+if [ "$1" != run ] && [ "$1" != test ]; then
+	%s
+fi
+echo "$@" > gop.args
+echo "fake gop $@"
+exit %d
+`, other, status)
+	if err := os.WriteFile(filepath.Join(dir, "gop"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+const runGopWorkspace = `
+-- go.mod --
+module example.com
+
+go 1.18
+-- main.gop --
+package main
+
+println "Hello, Go+"
+`
+
+func TestRunGopCommand(t *testing.T) {
+	fakeGop(t, 0)
+	WithOptions(
+		Modes(Default), // the fake gop command must be on the PATH of gopls
+	).Run(t, runGopWorkspace, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		env.ExecuteCodeLensCommand("main.gop", command.RunGopCommand, nil)
+		env.Await(CompletedWork("Running gop run", 1, false))
+		if got := strings.TrimSpace(env.ReadWorkspaceFile("gop.args")); got != "run" {
+			t.Errorf("gop arguments: got %q, want %q", got, "run")
+		}
+		env.Await(NoShownMessage("failed"))
+	})
+}
+
+// Test that the failure of an asynchronous command is shown, though the
+// context of the command is cancelled once it returns.
+func TestRunGopCommandFailure(t *testing.T) {
+	fakeGop(t, 1)
+	WithOptions(
+		Modes(Default),
+	).Run(t, runGopWorkspace, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		env.ExecuteCodeLensCommand("main.gop", command.RunGopCommand, nil)
+		env.Await(ShownMessage("running gop run failed"))
+	})
+}
+
+func TestGopGenerate(t *testing.T) {
+	// gop_autogen.go is regenerated by the gop command after go generate.
+	if _, err := exec.LookPath("gop"); err != nil {
+		t.Skip("gop command not found")
+	}
+	const workspace = `
+-- go.mod --
+module example.com
+
+go 1.18
+-- generate.go --
+// +build ignore
+
+package main
+
+import "os"
+
+func main() {
+	os.WriteFile("answer.gop", []byte("package " + os.Args[1] + "\n\nconst Answer = 42\n"), 0644)
+}
+-- lib/lib.gop --
+package lib
+
+//` + `go:generate go run ../generate.go lib
+-- lib/double.gop --
+package lib
+
+func Double() int {
+	return 2 * Answer
+}
+`
+	Run(t, workspace, func(t *testing.T, env *Env) {
+		env.OpenFile("lib/lib.gop")
+		env.ExecuteCodeLensCommand("lib/lib.gop", command.Generate, nil)
+		env.Await(NoOutstandingWork())
+		env.CheckForFileChanges()
+		env.AfterChange()
+		if got := env.ReadWorkspaceFile("lib/gop_autogen.go"); !strings.Contains(got, "Answer") {
+			t.Errorf("lib/gop_autogen.go was not regenerated:\n%s", got)
+		}
+		env.OpenFile("lib/double.gop")
+		env.AfterChange()
+		loc := env.GoToDefinition(env.RegexpSearch("lib/double.gop", "Answer"))
+		if got, want := env.Sandbox.Workdir.URIToPath(loc.URI), "lib/answer.gop"; got != want {
+			t.Errorf("definition of Answer: got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestGopCaseTest(t *testing.T) {
+	const workspace = `
+-- go.mod --
+module example.com
+
+go 1.18
+
+require github.com/goplus/gop v1.3.0
+
+replace github.com/goplus/gop => ./gop
+-- gop/go.mod --
+module github.com/goplus/gop
+
+go 1.18
+-- gop/test/classfile.go --
+package test
+
+import "testing"
+
+const GopPackage = true
+
+type Case struct {
+	t *testing.T
+}
+
+func (p *Case) initCase(t *testing.T) { p.t = t }
+
+func (p Case) T() *testing.T { return p.t }
+
+func (p Case) Run(name string, f func(t *testing.T)) bool { return p.t.Run(name, f) }
+
+func Gopt_Case_TestMain(c interface{ initCase(t *testing.T) }, t *testing.T) {
+	c.initCase(t)
+	c.(interface{ Main() }).Main()
+}
+
+type App struct{}
+-- gop_autogen.go --
+package main
+-- gop_autogen_test.go --
+package main
+-- sum_test.gox --
+run "one plus one", t => {
+	if 1+1 != 2 {
+		t.Fail()
+	}
+}
+`
+	fakeGop(t, 0)
+	WithOptions(
+		Modes(Default),
+	).Run(t, workspace, func(t *testing.T, env *Env) {
+		env.OpenFile("sum_test.gox")
+		var got []string
+		for _, lens := range env.CodeLens("sum_test.gox") {
+			if lens.Command.Command != command.RunGopCommand.ID() {
+				continue
+			}
+			var args command.RunGopCommandArgs
+			if err := command.UnmarshalArgs(lens.Command.Arguments, &args); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprintf("%d: %s %s", lens.Range.Start.Line, args.Command, strings.Join(args.Args, " ")))
+		}
+		want := []string{
+			"0: test -run ^Test_sum$",
+			"0: test -run ^Test_sum$/^(one_plus_one)$",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("gop test lenses: got %q, want %q", got, want)
+		}
+
+		env.ExecuteCodeLensCommand("sum_test.gox", command.RunGopCommand, nil)
+		env.Await(CompletedWork("Running gop test", 1, false))
+		if got, want := strings.TrimSpace(env.ReadWorkspaceFile("gop.args")), "test -run ^Test_sum$/^(one_plus_one)$"; got != want {
+			t.Errorf("gop arguments: got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestGopTestCodeLens(t *testing.T) {
+	const workspace = `
+-- go.mod --
+module example.com
+
+go 1.18
+-- sum.gop --
+package sum
+
+func Sum(a, b int) int {
+	return a + b
+}
+-- sum_test.gop --
+package sum
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	if Sum(1, 1) != 2 {
+		t.Fail()
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Sum(1, 1)
+	}
+}
+-- gop_autogen.go --
+package sum
+-- gop_autogen_test.go --
+package sum
+`
+	WithOptions(
+		Settings{"codelenses": map[string]bool{"test": true}},
+	).Run(t, workspace, func(t *testing.T, env *Env) {
+		env.OpenFile("sum_test.gop")
+		var got []string
+		for _, lens := range env.CodeLens("sum_test.gop") {
+			if lens.Command.Command != command.Test.ID() {
+				continue
+			}
+			var uri string
+			var tests, benchmarks []string
+			if err := command.UnmarshalArgs(lens.Command.Arguments, &uri, &tests, &benchmarks); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprintf("%d: %s %v %v", lens.Range.Start.Line, lens.Command.Title, tests, benchmarks))
+		}
+		want := []string{
+			"0: run file benchmarks [] [BenchmarkSum]",
+			"4: run test [TestSum] []",
+			"10: run benchmark [] [BenchmarkSum]",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("test lenses: got %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diagnostics
+
+import (
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+)
+
+// Test that the Go+ vet suite reports its diagnostics on Go+ files.
+func TestGopVetAnalyzers(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a.gop --
+package a
+
+import "fmt"
+
+func Hello(name string) {
+	fmt.Printf("hello %d\n", name)
+}
+
+func Assign(x int) int {
+	x = x
+	return x
+}
+-- a/gop_autogen.go --
+package a
+
+import _ "fmt"
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.gop")
+		env.AfterChange(
+			Diagnostics(env.AtRegexp("a/a.gop", "fmt.Printf"), WithMessage("wrong type")),
+			Diagnostics(env.AtRegexp("a/a.gop", "x = x"), WithMessage("self-assignment")),
+		)
+	})
+}
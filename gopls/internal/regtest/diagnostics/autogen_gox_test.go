@@ -0,0 +1,66 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diagnostics
+
+import (
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/internal/testenv"
+)
+
+// Test that the go command errors of gop_autogen.go are reported on the Go+
+// lines the code is generated from, as named by its //line directives.
+//
+// The gop_autogen.go file is the output of `gop go` for a/a.gop, so that the
+// test holds whether or not gopls regenerates it. Its //go:embed line has no
+// directive of its own: it takes its line from the one of the println
+// statement, which is off by the blank line the compiler dropped.
+func TestGopAutogenListErrors(t *testing.T) {
+	testenv.NeedsGo1Point(t, 16) // for //go:embed
+
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a.gop --
+package a
+
+import _ "embed"
+
+func Hello() {
+	println "hello"
+}
+
+//go:embed missing.txt
+var data string
+-- a/gop_autogen.go --
+// Code generated by gop (Go+); DO NOT EDIT.
+
+package a
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+const _ = true
+//line a/a.gop:5:1
+func Hello() {
+//line a/a.gop:6:1
+	fmt.Println("hello")
+}
+//go:embed missing.txt
+var data string
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a/a.gop")
+		env.AfterChange(
+			Diagnostics(env.AtRegexp("a/a.gop", "missing.txt"), WithMessage("missing.txt")),
+			NoDiagnostics(ForFile("a/gop_autogen.go")),
+		)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+const classfileFiles = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.gop --
+package a
+
+func Distance() int {
+	return 0
+}
+-- a/gop_autogen.go --
+package a
+-- b/Rect.foo --
+func Area() int {
+	return 0
+}
+-- b/gop_autogen.go --
+package main
+`
+
+const classfileGopMod = `gop 1.1
+
+project .foo Foo fmt
+`
+
+func TestGopModClassfileExtension(t *testing.T) {
+	WithOptions(
+		Settings{"symbolMatcher": string(source.SymbolCaseSensitive)},
+	).Run(t, classfileFiles+"-- gop.mod --\n"+classfileGopMod, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Area", "Rect.Area")
+		checkSymbols(env, "Distance", "Distance")
+	})
+}
+
+func TestGopModClassfileExtensionChange(t *testing.T) {
+	WithOptions(
+		Settings{"symbolMatcher": string(source.SymbolCaseSensitive)},
+	).Run(t, classfileFiles, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Area")
+		checkSymbols(env, "Distance", "Distance")
+
+		// Registering the classfile makes Rect.foo a Go+ file.
+		env.WriteWorkspaceFile("gop.mod", classfileGopMod)
+		env.AfterChange()
+		checkSymbols(env, "Area", "Rect.Area")
+	})
+}
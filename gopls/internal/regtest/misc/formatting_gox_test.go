@@ -0,0 +1,256 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+	"golang.org/x/tools/gopls/internal/lsp/tests/compare"
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestGopGofumptFormatting(t *testing.T) {
+	testenv.NeedsGo1Point(t, 18)
+
+	// gofumpt only understands Go syntax: it must not be applied to Go+ files.
+	const input = `
+-- go.mod --
+module foo
+
+go 1.17
+-- foo.gop --
+func foo() {
+	foo :=
+		"bar"
+	println   foo
+	echo [x*x for x <- [1, 3, 5]]
+}
+-- foo.gop.formatted --
+func foo() {
+	foo :=
+		"bar"
+	println foo
+	echo [x*x for x <- [1, 3, 5]]
+}
+-- gop_autogen.go --
+package main
+`
+	WithOptions(
+		Settings{
+			"gofumpt": true,
+		},
+	).Run(t, input, func(t *testing.T, env *Env) {
+		env.OpenFile("foo.gop")
+		env.FormatBuffer("foo.gop")
+		got := env.BufferText("foo.gop")
+		want := env.ReadWorkspaceFile("foo.gop.formatted")
+		if got != want {
+			t.Errorf("unexpected formatting result:\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestGopFormatHook(t *testing.T) {
+	const input = `
+-- go.mod --
+module foo
+
+go 1.17
+-- foo.gop --
+echo   "foo"
+-- Rect.gox --
+var   W int
+-- gop_autogen.go --
+package main
+`
+	// The hook runs whether or not gofumpt is enabled.
+	hook := func(o *source.Options) {
+		o.GopFormat = func(ctx context.Context, langVersion, modulePath string, src []byte, class bool) ([]byte, error) {
+			return append(src, fmt.Sprintf("// module %s, class %v\n", modulePath, class)...), nil
+		}
+	}
+	WithOptions(
+		Modes(Default), // the hook is installed in the server of the test
+		OptionsHook(hook),
+	).Run(t, input, func(t *testing.T, env *Env) {
+		for _, test := range []struct{ file, want string }{
+			{"foo.gop", "echo \"foo\"\n// module foo, class false\n"},
+			{"Rect.gox", "var W int\n// module foo, class true\n"},
+		} {
+			env.OpenFile(test.file)
+			env.FormatBuffer(test.file)
+			if got := env.BufferText(test.file); got != test.want {
+				t.Errorf("%s:\n%s", test.file, compare.Text(test.want, got))
+			}
+		}
+	})
+}
+
+func TestGopStyleCodeAction(t *testing.T) {
+	const input = `
+-- go.mod --
+module foo
+
+go 1.17
+-- main.gop --
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello, world")
+}
+-- Rect.gox --
+func Area() int {
+	return 0
+}
+-- gop_autogen.go --
+package main
+`
+	Run(t, input, func(t *testing.T, env *Env) {
+		// Classfiles are not rewritten.
+		env.OpenFile("Rect.gox")
+		for _, a := range env.CodeAction("Rect.gox", nil) {
+			if a.Kind == protocol.SourceGopStyle {
+				t.Errorf("unexpected code action %q for Rect.gox", a.Title)
+			}
+		}
+
+		env.OpenFile("main.gop")
+		var action *protocol.CodeAction
+		for _, a := range env.CodeAction("main.gop", nil) {
+			if a.Kind == protocol.SourceGopStyle {
+				a := a
+				action = &a
+			}
+		}
+		if action == nil {
+			t.Fatalf("no %s code action for main.gop", protocol.SourceGopStyle)
+		}
+		env.ApplyCodeAction(*action)
+		want := `println "Hello, world"
+`
+		if got := env.BufferText("main.gop"); got != want {
+			t.Errorf("unexpected Go+ style result:\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestGopRangeFormatting(t *testing.T) {
+	const input = `
+-- go.mod --
+module foo
+
+go 1.17
+-- main.gop --
+func add(a,b int) int {
+	return a+b
+}
+
+println   add(1, 2)
+println   add(3, 4)
+-- Rect.gox --
+var (
+	W, H int
+)
+
+func Area() int {
+	return W*H
+}
+
+echo   Area()
+-- gop_autogen.go --
+package main
+`
+	Run(t, input, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		env.RangeFormatBuffer(env.RegexpSearch("main.gop", `return a\+b`))
+		env.RangeFormatBuffer(env.RegexpSearch("main.gop", `add\(1, 2\)`))
+		want := `func add(a,b int) int {
+	return a + b
+}
+
+println add(1, 2)
+println   add(3, 4)
+`
+		if got := env.BufferText("main.gop"); got != want {
+			t.Errorf("unexpected range formatting result:\n%s", compare.Text(want, got))
+		}
+
+		// The top-level statements of a classfile have no braces.
+		env.OpenFile("Rect.gox")
+		env.RangeFormatBuffer(env.RegexpSearch("Rect.gox", `echo`))
+		want = `var (
+	W, H int
+)
+
+func Area() int {
+	return W*H
+}
+
+echo Area()
+`
+		if got := env.BufferText("Rect.gox"); got != want {
+			t.Errorf("unexpected range formatting result:\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestGopOnTypeFormatting(t *testing.T) {
+	const input = `
+-- go.mod --
+module foo
+
+go 1.17
+-- main.gop --
+-- Rect.gox --
+-- gop_autogen.go --
+package main
+`
+	tests := []struct {
+		name      string
+		file      string
+		ch        string
+		src, want string // the cursor is at the start of the line starting with @
+	}{
+		{"brace", "main.gop", "\n", "func f() {\n@x\n}\n", "func f() {\n\tx\n}\n"},
+		{"closing brace", "main.gop", "}", "func f() {\n\tx\n\t@}\n", "func f() {\n\tx\n}\n"},
+		{"newline before closing brace", "main.gop", "\n", "func f() {\n\tx\n\t@}\n", "func f() {\n\tx\n}\n"},
+		{"lambda", "main.gop", "\n", "onMsg \"a\", =>\n@x\n", "onMsg \"a\", =>\n\tx\n"},
+		{"command", "main.gop", "\n", "echo a,\n@b\n", "echo a,\n\tb\n"},
+		{"command continued", "main.gop", "\n", "echo a,\n\tb,\n@c\n", "echo a,\n\tb,\n\tc\n"},
+		{"not a command", "main.gop", "\n", "x := a,\n@b\n", "x := a,\nb\n"},
+		{"class top level", "Rect.gox", "\n", "echo 1\n\t@echo 2\n", "echo 1\necho 2\n"},
+		{"class command continued", "Rect.gox", "\n", "echo a,\n\tb\n\t@echo c\n", "echo a,\n\tb\necho c\n"},
+		{"class method", "Rect.gox", "\n", "func Area() int {\n@return 1\n}\n", "func Area() int {\n\treturn 1\n}\n"},
+		{"raw string", "main.gop", "}", "func f() {\n\ts := `\n}`\n\techo s\n\t@}\n", "func f() {\n\ts := `\n}`\n\techo s\n}\n"},
+		{"block comment", "main.gop", "}", "func f() {\n\t/* (\n\t*/\n\t@}\n", "func f() {\n\t/* (\n\t*/\n}\n"},
+		{"within raw string", "main.gop", "\n", "s := `a\n\t@b`\necho s\n", "s := `a\n\tb`\necho s\n"},
+	}
+	Run(t, input, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		env.OpenFile("Rect.gox")
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				env.SetBufferContent(test.file, strings.Replace(test.src, "@", "", 1))
+				line := strings.Count(test.src[:strings.Index(test.src, "@")], "\n")
+				loc := protocol.Location{
+					URI:   env.Sandbox.Workdir.URI(test.file),
+					Range: protocol.Range{Start: protocol.Position{Line: uint32(line)}, End: protocol.Position{Line: uint32(line)}},
+				}
+				env.OnTypeFormatBuffer(loc, test.ch)
+				if got := env.BufferText(test.file); got != test.want {
+					t.Errorf("unexpected on-type formatting result:\n%s", compare.Text(test.want, got))
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/gopls/internal/lsp/command"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/tests/compare"
+)
+
+// gopModFiles is a module requiring a classfile framework, example.com/fw,
+// which it replaces by a local module.
+const gopModFiles = `
+-- go.mod --
+module mod.com
+
+go 1.18
+
+require example.com/fw v1.0.0
+
+replace example.com/fw => ./fw
+-- main.go --
+package main
+-- fw/go.mod --
+module example.com/fw
+
+go 1.18
+-- fw/gop.mod --
+gop 1.1
+
+project .fw Game example.com/fw
+class .sprite Sprite
+-- fw/fw.go --
+package fw
+
+type Game struct{}
+
+type Sprite struct{}
+`
+
+func TestGopModDiagnostics(t *testing.T) {
+	const gopMod = `
+-- gop.mod --
+gop 1.1
+
+registr example.com/fw
+register example.com/other
+`
+	Run(t, gopModFiles+gopMod, func(t *testing.T, env *Env) {
+		env.OnceMet(
+			InitialWorkspaceLoad,
+			Diagnostics(env.AtRegexp("gop.mod", "registr "), WithMessage("unknown directive: registr")),
+			Diagnostics(env.AtRegexp("gop.mod", "example.com/other"), WithMessage("example.com/other is not required by go.mod")),
+		)
+
+		// Fixing the gop.mod file clears its diagnostics.
+		env.OpenFile("gop.mod")
+		env.RegexpReplace("gop.mod", "registr ", "register ")
+		env.RegexpReplace("gop.mod", "register example.com/other\n", "")
+		env.AfterChange(NoDiagnostics(ForFile("gop.mod")))
+	})
+}
+
+func TestGopModHover(t *testing.T) {
+	const gopMod = `
+-- gop.mod --
+gop 1.1
+
+register example.com/fw
+`
+	Run(t, gopModFiles+gopMod, func(t *testing.T, env *Env) {
+		env.OpenFile("gop.mod")
+		content, _ := env.Hover(env.RegexpSearch("gop.mod", "example.com/fw"))
+		if content == nil {
+			t.Fatal("no hover for example.com/fw")
+		}
+		for _, want := range []string{"example.com/fw@v1.0.0", "project .fw Game example.com/fw", "class .sprite Sprite"} {
+			if !strings.Contains(content.Value, want) {
+				t.Errorf("hover for example.com/fw: got %q, want it to contain %q", content.Value, want)
+			}
+		}
+	})
+}
+
+func TestGopModCompletion(t *testing.T) {
+	const gopMod = `
+-- gop.mod --
+gop 1.1
+`
+	Run(t, gopModFiles+gopMod, func(t *testing.T, env *Env) {
+		env.OpenFile("gop.mod")
+		env.SetBufferContent("gop.mod", "gop 1.1\n\nregister github.com/goplus/spx\nregister \n")
+		completions := env.Completion(env.RegexpSearch("gop.mod", `register ()\n`))
+		var got []string
+		for _, item := range completions.Items {
+			got = append(got, item.Label)
+		}
+		// github.com/goplus/spx is already registered.
+		want := []string{"example.com/fw", "github.com/goplus/yap"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("completion for gop.mod: unexpected items (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGopModRegisterLens(t *testing.T) {
+	const gopMod = `
+-- gop.mod --
+gop 1.1
+`
+	Run(t, gopModFiles+gopMod, func(t *testing.T, env *Env) {
+		env.OpenFile("gop.mod")
+		var titles []string
+		for _, lens := range env.CodeLens("gop.mod") {
+			titles = append(titles, lens.Command.Title)
+		}
+		if diff := cmp.Diff([]string{"Register example.com/fw"}, titles); diff != "" {
+			t.Fatalf("code lenses for gop.mod: unexpected lenses (-want +got):\n%s", diff)
+		}
+
+		env.ExecuteCodeLensCommand("gop.mod", command.GopModRegister, nil)
+		want := "gop 1.1\nregister example.com/fw\n"
+		if got := env.BufferText("gop.mod"); got != want {
+			t.Errorf("gop.mod:\n%s", compare.Text(want, got))
+		}
+		if lenses := env.CodeLens("gop.mod"); len(lenses) != 0 {
+			t.Errorf("got %d code lenses after registering, want none", len(lenses))
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/tests/compare"
+)
+
+func TestGopInlineCall(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- main.gop --
+package main
+
+func greet(name string) string {
+	return "Hello, ${name}!"
+}
+
+func add(x, y int) int {
+	return x + y
+}
+
+func main() {
+	user := "Go+"
+	echo greet(user)
+	echo add(1, 2)
+}
+-- gop_autogen.go --
+package main
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		inline := func(re, title string) {
+			t.Helper()
+			loc := env.RegexpSearch("main.gop", re)
+			actions, err := env.Editor.CodeAction(env.Ctx, loc, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, action := range actions {
+				if action.Kind == protocol.RefactorInline && action.Title == title {
+					env.ApplyCodeAction(action)
+					return
+				}
+			}
+			t.Fatalf("no code action %q at %s", title, re)
+		}
+		inline(`(add)\(1`, "Inline call to add")
+		inline(`(greet)\(user`, "Inline call to greet")
+
+		want := `package main
+
+func greet(name string) string {
+	return "Hello, ${name}!"
+}
+
+func add(x, y int) int {
+	return x + y
+}
+
+func main() {
+	user := "Go+"
+	echo "Hello, ${user}!"
+	echo 1+2
+}
+`
+		if got := env.BufferText("main.gop"); got != want {
+			t.Errorf("main.gop:\n%s", compare.Text(want, got))
+		}
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+)
+
+func TestGopModDocumentLink(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.test
+
+go 1.18
+-- gop.mod --
+gop 1.1
+
+// See https://goplus.org/docs for classfiles.
+project .gmx Game github.com/goplus/spx math
+class .spx Sprite
+import "github.com/goplus/spx/internal"
+
+register github.com/goplus/yap
+-- main.go --
+package main
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("gop.mod")
+		var got []string
+		for _, link := range env.DocumentLink("gop.mod") {
+			got = append(got, *link.Target)
+		}
+		want := []string{
+			"https://pkg.go.dev/github.com/goplus/spx",
+			"https://pkg.go.dev/math",
+			"https://pkg.go.dev/github.com/goplus/spx/internal",
+			"https://goplus.org/docs",
+			"https://pkg.go.dev/github.com/goplus/yap",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("documentLink for gop.mod: unexpected links (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/tests/compare"
+)
+
+const moveFiles = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- main.gop --
+package main
+
+import "fmt"
+
+// Hello says hello.
+func Hello(name string) {
+	fmt.Println("Hello,", name)
+}
+
+func main() {
+	Hello("Go+")
+	r := &Rect{W: 2, H: 3}
+	println(r.Area(), r.Perimeter())
+}
+-- Rect.gox --
+var (
+	W, H int
+)
+
+func Area() int {
+	return W * H
+}
+-- perimeter.gop --
+package main
+
+func (r *Rect) Perimeter() int {
+	return 2 * (r.W + r.H)
+}
+-- gop_autogen.go --
+package main
+`
+
+// findCodeAction returns the code action of the given title at loc. Its
+// kind is refactor.extract for a move into a new file, and refactor.rewrite
+// for a move into an existing file.
+func findCodeAction(t *testing.T, env *Env, loc protocol.Location, title string) protocol.CodeAction {
+	actions, err := env.Editor.CodeAction(env.Ctx, loc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kind := protocol.RefactorRewrite
+	if strings.HasPrefix(title, "Extract ") {
+		kind = protocol.RefactorExtract
+	}
+	for _, action := range actions {
+		if action.Title == title {
+			if action.Kind != kind {
+				t.Errorf("code action %q has kind %q, want %q", title, action.Kind, kind)
+			}
+			return action
+		}
+	}
+	var titles []string
+	for _, action := range actions {
+		titles = append(titles, action.Title)
+	}
+	t.Fatalf("no code action %q, got %q", title, titles)
+	return protocol.CodeAction{}
+}
+
+func TestGopExtractToNewFile(t *testing.T) {
+	// Creating a Go+ file notifies the Go+ language server, gop serve.
+	if _, err := exec.LookPath("gop"); err != nil {
+		t.Skip("gop command not found")
+	}
+	Run(t, moveFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("main.gop")
+		loc := env.RegexpSearch("main.gop", `func (Hello)`)
+		env.ApplyCodeAction(findCodeAction(t, env, loc, "Extract Hello to new file"))
+
+		want := `package main
+
+import "fmt"
+
+// Hello says hello.
+func Hello(name string) {
+	fmt.Println("Hello,", name)
+}
+`
+		if got := env.BufferText("hello.gop"); got != want {
+			t.Errorf("hello.gop:\n%s", compare.Text(want, got))
+		}
+		want = `package main
+
+func main() {
+	Hello("Go+")
+	r := &Rect{W: 2, H: 3}
+	println(r.Area(), r.Perimeter())
+}
+`
+		if got := env.BufferText("main.gop"); got != want {
+			t.Errorf("main.gop:\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestGopMoveClassMethod(t *testing.T) {
+	Run(t, moveFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("Rect.gox")
+		env.OpenFile("perimeter.gop")
+		loc := env.RegexpSearch("Rect.gox", `func (Area)`)
+		env.ApplyCodeAction(findCodeAction(t, env, loc, "Move Area to perimeter.gop (class methods become methods of *Rect)"))
+
+		want := `package main
+
+func (r *Rect) Perimeter() int {
+	return 2 * (r.W + r.H)
+}
+
+func (this *Rect) Area() int {
+	return this.W * this.H
+}
+`
+		if got := env.BufferText("perimeter.gop"); got != want {
+			t.Errorf("perimeter.gop:\n%s", compare.Text(want, got))
+		}
+		want = `var (
+	W, H int
+)
+`
+		if got := env.BufferText("Rect.gox"); got != want {
+			t.Errorf("Rect.gox:\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestGopMoveMethodToClass(t *testing.T) {
+	Run(t, moveFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("Rect.gox")
+		env.OpenFile("perimeter.gop")
+		loc := env.RegexpSearch("perimeter.gop", `(Perimeter)\(`)
+		env.ApplyCodeAction(findCodeAction(t, env, loc, "Move Perimeter to Rect.gox (methods of *Rect become class methods)"))
+
+		want := `var (
+	W, H int
+)
+
+func Area() int {
+	return W * H
+}
+
+func Perimeter() int {
+	return 2 * (this.W + this.H)
+}
+`
+		if got := env.BufferText("Rect.gox"); got != want {
+			t.Errorf("Rect.gox:\n%s", compare.Text(want, got))
+		}
+
+		// Functions can't move to class files.
+		env.OpenFile("main.gop")
+		loc = env.RegexpSearch("main.gop", `func (Hello)`)
+		actions, err := env.Editor.CodeAction(env.Ctx, loc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, action := range actions {
+			if action.Title == "Move Hello to Rect.gox" {
+				t.Errorf("unexpected code action %q", action.Title)
+			}
+		}
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/gopls/internal/lsp/protocol"
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+)
+
+// gopSemanticTokens returns the semantic tokens of the Go+ file name.
+func gopSemanticTokens(t *testing.T, env *Env, name string) []result {
+	p := &protocol.SemanticTokensParams{
+		TextDocument: protocol.TextDocumentIdentifier{
+			URI: env.Sandbox.Workdir.URI(name),
+		},
+	}
+	v, err := env.Editor.Server.SemanticTokensFull(env.Ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return interpret(v.Data, env.BufferText(name))
+}
+
+// Test that only the members of the class are properties and methods when
+// they are used without naming the receiver.
+func TestGopSemanticClassMembers(t *testing.T) {
+	want := []result{
+		{"var", "keyword", ""},
+		{"Width", "variable", "definition"},
+		{"int", "type", "defaultLibrary"},
+		{"Height", "variable", "definition"},
+		{"int", "type", "defaultLibrary"},
+		{"type", "keyword", ""},
+		{"Point", "type", "definition"},
+		{"struct", "keyword", ""},
+		{"X", "variable", "definition"},
+		{"int", "type", "defaultLibrary"},
+		{"func", "keyword", ""},
+		{"Area", "method", "definition"},
+		{"int", "type", "defaultLibrary"},
+		{"p", "variable", "definition"},
+		{":=", "operator", ""},
+		{"Point", "type", ""},
+		{"X", "variable", ""}, // a field, but not of the class
+		{"Width", "property", ""},
+		{"return", "keyword", ""},
+		{"p", "variable", ""},
+		{"X", "variable", ""},
+		{"*", "operator", ""},
+		{"Height", "property", ""},
+		{"func", "keyword", ""},
+		{"Double", "method", "definition"},
+		{"int", "type", "defaultLibrary"},
+		{"return", "keyword", ""},
+		{"*", "operator", ""},
+		{"Area", "method", ""},
+	}
+	const src = `
+-- go.mod --
+module example.com
+
+go 1.19
+-- Rect.gox --
+var (
+	Width  int
+	Height int
+)
+
+type Point struct {
+	X int
+}
+
+func Area() int {
+	p := Point{X: Width}
+	return p.X * Height
+}
+
+func Double() int {
+	return 2 * Area()
+}
+-- gop_autogen.go --
+package main
+`
+	WithOptions(
+		Modes(Default),
+		Settings{"semanticTokens": true},
+	).Run(t, src, func(t *testing.T, env *Env) {
+		env.OpenFile("Rect.gox")
+		env.AfterChange()
+		seen := gopSemanticTokens(t, env, "Rect.gox")
+		if x := cmp.Diff(want, seen); x != "" {
+			t.Errorf("Semantic tokens do not match (-want +got):\n%s", x)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	. "golang.org/x/tools/gopls/internal/lsp/regtest"
+	"golang.org/x/tools/gopls/internal/lsp/source"
+)
+
+func TestWorkspaceSymbolGop(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.gop --
+package a
+
+type Point struct {
+	X, Y int
+}
+
+func (p *Point) Scale(n int) {
+}
+
+func Distance(p, q Point) int {
+	return 0
+}
+-- a/gop_autogen.go --
+package a
+-- b/Rect.gox --
+var (
+	Width, Height int
+)
+
+func Area() int {
+	return Width * Height
+}
+-- b/gop_autogen.go --
+package main
+`
+
+	var symbolMatcher = string(source.SymbolFastFuzzy)
+	WithOptions(
+		Settings{"symbolMatcher": symbolMatcher},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Distance", "Distance")
+		checkSymbols(env, "Scale", "Point.Scale")
+		checkSymbols(env, "Rect", "Rect", "Rect.Area", "Rect.Width", "Rect.Height")
+		checkSymbols(env, "Area", "Rect.Area")
+		checkSymbols(env, "^mod.com/a.Point", "mod.com/a.Point", "mod.com/a.Point.X", "mod.com/a.Point.Y", "mod.com/a.Point.Scale")
+	})
+}
+
+func TestWorkspaceSymbolGopCaseSensitive(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.gop --
+package a
+
+func area() int {
+	return 0
+}
+-- a/gop_autogen.go --
+package a
+-- b/gop_autogen.go --
+package main
+-- b/Rect.gox --
+func Area() int {
+	return 0
+}
+`
+
+	var symbolMatcher = string(source.SymbolCaseSensitive)
+	WithOptions(
+		Settings{"symbolMatcher": symbolMatcher},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Area", "Rect.Area")
+		checkSymbols(env, "area", "area")
+	})
+}
@@ -0,0 +1,9 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+// This file opens back doors for testing.
+
+func (callee *Callee) Effects() []int { return callee.impl.Effects }
@@ -0,0 +1,381 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline_test
+
+import "testing"
+
+// Testcases mostly come in pairs, of a success and a failure
+// to substitute based on specific constant argument values.
+
+func TestFalconStringIndex(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Non-negative string index.",
+			callee: `func f(i int) byte { return s[i] }; var s string`,
+			caller: `func _() { f(0) }`,
+			want:   `func _() { _ = s[0] }`,
+		},
+		{
+			descr:  "Negative string index.",
+			callee: `func f(i int) byte { return s[i] }; var s string`,
+			caller: `func _() { f(-1) }`,
+			want: `func _() {
+	var i int = -1
+	_ = s[i]
+}`,
+		},
+		{
+			descr:  "String index in range.",
+			callee: `func f(s string, i int) byte { return s[i] }`,
+			caller: `func _() { f("-", 0) }`,
+			want:   `func _() { _ = "-"[0] }`,
+		},
+		{
+			descr:  "String index out of range.",
+			callee: `func f(s string, i int) byte { return s[i] }`,
+			caller: `func _() { f("-", 1) }`,
+			want: `func _() {
+	var (
+		s string = "-"
+		i int    = 1
+	)
+	_ = s[i]
+}`,
+		},
+		{
+			descr:  "Remove known prefix (OK)",
+			callee: `func f(s, prefix string) string { return s[:len(prefix)] }`,
+			caller: `func _() { f("", "") }`,
+			want:   `func _() { _ = ""[:len("")] }`,
+		},
+		{
+			descr:  "Remove not-a-prefix (out of range)",
+			callee: `func f(s, prefix string) string { return s[:len(prefix)] }`,
+			caller: `func _() { f("", "pre") }`,
+			want: `func _() {
+	var s, prefix string = "", "pre"
+	_ = s[:len(prefix)]
+}`,
+		},
+	})
+}
+
+func TestFalconSliceIndices(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Monotonic (0<=i<=j) slice indices (len unknown).",
+			callee: `func f(i, j int) []int { return s[i:j] }; var s []int`,
+			caller: `func _() { f(0, 1) }`,
+			want:   `func _() { _ = s[0:1] }`,
+		},
+		{
+			descr:  "Non-monotonic slice indices (len unknown).",
+			callee: `func f(i, j int) []int { return s[i:j] }; var s []int`,
+			caller: `func _() { f(1, 0) }`,
+			want: `func _() {
+	var i, j int = 1, 0
+	_ = s[i:j]
+}`,
+		},
+		{
+			descr:  "Negative slice index.",
+			callee: `func f(i, j int) []int { return s[i:j] }; var s []int`,
+			caller: `func _() { f(-1, 1) }`,
+			want: `func _() {
+	var i, j int = -1, 1
+	_ = s[i:j]
+}`,
+		},
+	})
+}
+
+func TestFalconMapKeys(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Unique map keys (int)",
+			callee: `func f(x int) { _ = map[int]bool{1: true, x: true} }`,
+			caller: `func _() { f(2) }`,
+			want:   `func _() { _ = map[int]bool{1: true, 2: true} }`,
+		},
+		{
+			descr:  "Duplicate map keys (int)",
+			callee: `func f(x int) { _ = map[int]bool{1: true, x: true} }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	var x int = 1
+	_ = map[int]bool{1: true, x: true}
+}`,
+		},
+		{
+			descr:  "Unique map keys (varied built-in types)",
+			callee: `func f(x int16) { _ = map[any]bool{1: true, x: true} }`,
+			caller: `func _() { f(2) }`,
+			want:   `func _() { _ = map[any]bool{1: true, int16(2): true} }`,
+		},
+		{
+			descr:  "Duplicate map keys (varied built-in types)",
+			callee: `func f(x int16) { _ = map[any]bool{1: true, x: true} }`,
+			caller: `func _() { f(1) }`,
+			want:   `func _() { _ = map[any]bool{1: true, int16(1): true} }`,
+		},
+		{
+			descr:  "Unique map keys (varied user-defined types)",
+			callee: `func f(x myint) { _ = map[any]bool{1: true, x: true} }; type myint int`,
+			caller: `func _() { f(2) }`,
+			want:   `func _() { _ = map[any]bool{1: true, myint(2): true} }`,
+		},
+		{
+			descr:  "Duplicate map keys (varied user-defined types)",
+			callee: `func f(x myint, y myint2) { _ = map[any]bool{x: true, y: true} }; type (myint int; myint2 int)`,
+			caller: `func _() { f(1, 1) }`,
+			want: `func _() {
+	var (
+		x myint  = 1
+		y myint2 = 1
+	)
+	_ = map[any]bool{x: true, y: true}
+}`,
+		},
+		{
+			descr:  "Duplicate map keys (user-defined alias to built-in)",
+			callee: `func f(x myint, y int) { _ = map[any]bool{x: true, y: true} }; type myint = int`,
+			caller: `func _() { f(1, 1) }`,
+			want: `func _() {
+	var (
+		x myint = 1
+		y int   = 1
+	)
+	_ = map[any]bool{x: true, y: true}
+}`,
+		},
+	})
+}
+
+func TestFalconSwitchCases(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Unique switch cases (int).",
+			callee: `func f(x int) { switch 0 { case x: case 1: } }`,
+			caller: `func _() { f(2) }`,
+			want: `func _() {
+	switch 0 {
+	case 2:
+	case 1:
+	}
+}`,
+		},
+		{
+			descr:  "Duplicate switch cases (int).",
+			callee: `func f(x int) { switch 0 { case x: case 1: } }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	var x int = 1
+	switch 0 {
+	case x:
+	case 1:
+	}
+}`,
+		},
+		{
+			descr:  "Unique switch cases (varied built-in types).",
+			callee: `func f(x int) { switch any(nil) { case x: case int16(1): } }`,
+			caller: `func _() { f(2) }`,
+			want: `func _() {
+	switch any(nil) {
+	case 2:
+	case int16(1):
+	}
+}`,
+		},
+		{
+			descr:  "Duplicate switch cases (varied built-in types).",
+			callee: `func f(x int) { switch any(nil) { case x: case int16(1): } }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	switch any(nil) {
+	case 1:
+	case int16(1):
+	}
+}`,
+		},
+	})
+}
+
+func TestFalconDivision(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Division by two.",
+			callee: `func f(x, y int) int { return x / y }`,
+			caller: `func _() { f(1, 2) }`,
+			want:   `func _() { _ = 1 / 2 }`,
+		},
+		{
+			descr:  "Division by zero.",
+			callee: `func f(x, y int) int { return x / y }`,
+			caller: `func _() { f(1, 0) }`,
+			want: `func _() {
+	var x, y int = 1, 0
+	_ = x / y
+}`,
+		},
+		{
+			descr:  "Division by two (statement).",
+			callee: `func f(x, y int) { x /= y }`,
+			caller: `func _() { f(1, 2) }`,
+			want: `func _() {
+	var x int = 1
+	x /= 2
+}`,
+		},
+		{
+			descr:  "Division by zero (statement).",
+			callee: `func f(x, y int) { x /= y }`,
+			caller: `func _() { f(1, 0) }`,
+			want: `func _() {
+	var x, y int = 1, 0
+	x /= y
+}`,
+		},
+		{
+			descr:  "Division of minint by two (ok).",
+			callee: `func f(x, y int32) { _ = x / y }`,
+			caller: `func _() { f(-0x80000000, 2) }`,
+			want:   `func _() { _ = int32(-0x80000000) / int32(2) }`,
+		},
+		{
+			descr:  "Division of minint by -1 (overflow).",
+			callee: `func f(x, y int32) { _ = x / y }`,
+			caller: `func _() { f(-0x80000000, -1) }`,
+			want: `func _() {
+	var x, y int32 = -0x80000000, -1
+	_ = x / y
+}`,
+		},
+	})
+}
+
+func TestFalconMinusMinInt(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Negation of maxint.",
+			callee: `func f(x int32) int32 { return -x }`,
+			caller: `func _() { f(0x7fffffff) }`,
+			want:   `func _() { _ = -int32(0x7fffffff) }`,
+		},
+		{
+			descr:  "Negation of minint.",
+			callee: `func f(x int32) int32 { return -x }`,
+			caller: `func _() { f(-0x80000000) }`,
+			want: `func _() {
+	var x int32 = -0x80000000
+	_ = -x
+}`,
+		},
+	})
+}
+
+func TestFalconArithmeticOverflow(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Addition without overflow.",
+			callee: `func f(x, y int32) int32 { return x + y }`,
+			caller: `func _() { f(100, 200) }`,
+			want:   `func _() { _ = int32(100) + int32(200) }`,
+		},
+		{
+			descr:  "Addition with overflow.",
+			callee: `func f(x, y int32) int32 { return x + y }`,
+			caller: `func _() { f(1<<30, 1<<30) }`,
+			want: `func _() {
+	var x, y int32 = 1 << 30, 1 << 30
+	_ = x + y
+}`,
+		},
+		{
+			descr:  "Conversion in range.",
+			callee: `func f(x int) int8 { return int8(x) }`,
+			caller: `func _() { f(123) }`,
+			want:   `func _() { _ = int8(123) }`,
+		},
+		{
+			descr:  "Conversion out of range.",
+			callee: `func f(x int) int8 { return int8(x) }`,
+			caller: `func _() { f(456) }`,
+			want: `func _() {
+	var x int = 456
+	_ = int8(x)
+}`,
+		},
+	})
+}
+
+func TestFalconComplex(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Complex arithmetic (good).",
+			callee: `func f(re, im float64, z complex128) byte { return "x"[int(real(complex(re, im)*complex(re, -im)-z))] }`,
+			caller: `func _() { f(1, 2, 5+0i) }`,
+			want:   `func _() { _ = "x"[int(real(complex(float64(1), float64(2))*complex(float64(1), -float64(2))-(5+0i)))] }`,
+		},
+		{
+			descr:  "Complex arithmetic (bad).",
+			callee: `func f(re, im float64, z complex128) byte { return "x"[int(real(complex(re, im)*complex(re, -im)-z))] }`,
+			caller: `func _() { f(1, 3, 5+0i) }`,
+			want: `func _() {
+	var (
+		re, im float64    = 1, 3
+		z      complex128 = 5 + 0i
+	)
+	_ = "x"[int(real(complex(re, im)*complex(re, -im)-z))]
+}`,
+		},
+	})
+}
+func TestFalconMisc(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Compound constant expression (good).",
+			callee: `func f(x, y string, i, j int) byte { return x[i*len(y)+j] }`,
+			caller: `func _() { f("abc", "xy", 2, -3) }`,
+			want:   `func _() { _ = "abc"[2*len("xy")+-3] }`,
+		},
+		{
+			descr:  "Compound constant expression (index out of range).",
+			callee: `func f(x, y string, i, j int) byte { return x[i*len(y)+j] }`,
+			caller: `func _() { f("abc", "xy", 4, -3) }`,
+			want: `func _() {
+	var (
+		x, y string = "abc", "xy"
+		i, j int    = 4, -3
+	)
+	_ = x[i*len(y)+j]
+}`,
+		},
+		{
+			descr:  "Constraints within nested functions (good).",
+			callee: `func f(x int) { _ = func() { _ = [1]int{}[x] } }`,
+			caller: `func _() { f(0) }`,
+			want:   `func _() { _ = func() { _ = [1]int{}[0] } }`,
+		},
+		{
+			descr:  "Constraints within nested functions (bad).",
+			callee: `func f(x int) { _ = func() { _ = [1]int{}[x] } }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	var x int = 1
+	_ = func() { _ = [1]int{}[x] }
+}`,
+		},
+		{
+			descr:  "Falcon violation rejects only the constant arguments (x, z).",
+			callee: `func f(x, y, z string) string { return x[:2] + y + z[:2] }; var b string`,
+			caller: `func _() { f("a", b, "c") }`,
+			want: `func _() {
+	var x, z string = "a", "c"
+	_ = x[:2] + b + z[:2]
+}`,
+		},
+	})
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/gop/ast/astutil"
+	goinline "golang.org/x/tools/internal/refactor/inline"
+)
+
+// AnalyzeGoCallee is like AnalyzeCallee, for a function declared in a Go
+// file of pkg, which Go+ code may call as well. The Go syntax of the
+// function is inlined as Go+ syntax.
+func AnalyzeGoCallee(logf func(string, ...any), fset *token.FileSet, pkg *types.Package, info *types.Info, decl *goast.FuncDecl, content []byte) (*Callee, error) {
+	gocallee, err := goinline.AnalyzeCallee(logf, fset, pkg, info, decl, content)
+	if err != nil {
+		return nil, err
+	}
+	// The Callees of both inliners have the same serialized form.
+	data, err := gocallee.GobEncode()
+	if err != nil {
+		return nil, err
+	}
+	callee := new(Callee)
+	if err := callee.GobDecode(data); err != nil {
+		return nil, err
+	}
+	// Sanity check: the offsets are only valid if the Go+ parser
+	// accepts the compacted content.
+	if _, _, err := parseCompact(callee.impl.Content); err != nil {
+		return nil, err
+	}
+	return callee, nil
+}
+
+// gopCheckFuncDecl reports an error if decl is a Go+ function that
+// can't be inlined.
+func gopCheckFuncDecl(decl *ast.FuncDecl, name string) error {
+	switch {
+	case decl.IsClass || decl.Static:
+		return fmt.Errorf("cannot inline method %s of a Go+ class", name)
+	case decl.Operator:
+		return fmt.Errorf("cannot inline operator %s", name)
+	case decl.Shadow:
+		return fmt.Errorf("cannot inline the entry point %s of a Go+ file", name)
+	}
+
+	// ast.Inspect doesn't support matrix literals.
+	unsupported := false
+	astutil.Apply(decl, func(c *astutil.Cursor) bool {
+		switch c.Node().(type) {
+		case *ast.MatrixLit, *ast.ElemEllipsis:
+			unsupported = true
+		}
+		return !unsupported
+	}, nil)
+	if unsupported {
+		return fmt.Errorf("cannot inline function %s: matrix literals are not yet supported", name)
+	}
+	return nil
+}
+
+// isGopBuiltin reports whether id, declared in pkg, is a reference to
+// obj by a Go+ builtin name, such as echo for fmt.Println.
+func isGopBuiltin(pkg *types.Package, id *ast.Ident, obj types.Object) bool {
+	return id.Name != obj.Name() && obj.Pkg() != nil && obj.Pkg() != pkg
+}
+
+// builtinName returns the name of the Go built-in function denoted
+// by obj, or "" if obj is not a built-in. The Go+ checker represents
+// some built-ins, such as real, copy and panic, by objects of the
+// gogen builtin package, whose path is empty.
+func builtinName(obj types.Object) string {
+	if obj == nil {
+		return ""
+	}
+	if _, ok := obj.(*types.Builtin); !ok && obj.Pkg() != nil && obj.Pkg().Path() != "" {
+		return ""
+	}
+	if _, ok := types.Universe.Lookup(obj.Name()).(*types.Builtin); !ok {
+		return ""
+	}
+	return obj.Name()
+}
+
+// A selection describes a field or method selection x.f, like a
+// *types.Selection.
+type selection struct {
+	kind     types.SelectionKind
+	obj      types.Object
+	index    []int
+	indirect bool
+}
+
+func (s *selection) Kind() types.SelectionKind { return s.kind }
+func (s *selection) Obj() types.Object         { return s.obj }
+func (s *selection) Index() []int              { return s.index }
+func (s *selection) Indirect() bool            { return s.indirect }
+
+// selectionOf returns the selection denoted by e, or false if e is a
+// qualified identifier. The Go+ checker does not record Selections, so
+// the selection is recomputed from the type of e.X when it is missing.
+func selectionOf(info *typesutil.Info, e *ast.SelectorExpr) (*selection, bool) {
+	if seln, ok := info.Selections[e]; ok {
+		return &selection{seln.Kind(), seln.Obj(), seln.Index(), seln.Indirect()}, true
+	}
+	obj := info.Uses[e.Sel]
+	if obj == nil {
+		return nil, false
+	}
+	if id, ok := e.X.(*ast.Ident); ok && is[*types.PkgName](info.Uses[id]) {
+		return nil, false // qualified identifier
+	}
+	x := astutil.Unparen(e.X)
+	tv, ok := info.Types[x]
+	if !ok {
+		return nil, false
+	}
+	isType := tv.IsType()
+	if star, ok := x.(*ast.StarExpr); ok {
+		// The Go+ checker records the operand of (*T).f as a value.
+		isType = isType || info.Types[star.X].IsType()
+	}
+	lookup, index, indirect := types.LookupFieldOrMethod(tv.Type, true, obj.Pkg(), obj.Name())
+	if lookup == nil {
+		return nil, false
+	}
+	kind := types.MethodVal
+	if _, ok := lookup.(*types.Var); ok {
+		kind = types.FieldVal
+	} else if isType {
+		kind = types.MethodExpr
+	}
+	return &selection{kind, lookup, index, indirect}, true
+}
+
+// isLambda reports whether e is a Go+ lambda expression.
+func isLambda(e ast.Expr) bool {
+	switch astutil.Unparen(e).(type) {
+	case *ast.LambdaExpr, *ast.LambdaExpr2:
+		return true
+	}
+	return false
+}
+
+// gopStringPartSafe reports whether e may be embedded in a Go+ string
+// literal, as in "${e}": it must contain neither quotes nor braces.
+func gopStringPartSafe(e ast.Expr) bool {
+	safe := true
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BasicLit:
+			if n.Kind != token.INT && n.Kind != token.FLOAT &&
+				n.Kind != token.IMAG && n.Kind != token.RAT {
+				safe = false
+			}
+		case *ast.CompositeLit, *ast.FuncLit, *ast.StructType, *ast.InterfaceType,
+			*ast.LambdaExpr, *ast.LambdaExpr2, *ast.SliceLit, *ast.MatrixLit,
+			*ast.ComprehensionExpr, *ast.EnvExpr:
+			safe = false
+		}
+		return safe
+	})
+	return safe
+}
+
+// gopUpdateStringLits recomputes the Value of the Go+ string literals
+// "${x}" beneath root from their parts, since the printer only prints
+// their Value.
+func gopUpdateStringLits(root ast.Node) {
+	astutil.Apply(root, func(c *astutil.Cursor) bool {
+		if lit, ok := c.Node().(*ast.BasicLit); ok && lit.Extra != nil {
+			lit.Value = astutil.StringLitValue(lit.Extra.Parts, func(x ast.Expr) string {
+				// The expression may be a mix of caller and callee
+				// nodes: format it without positions.
+				x = cloneNode(x).(ast.Expr)
+				clearPositions(x)
+				return debugFormatNode(token.NewFileSet(), x)
+			})
+		}
+		return true
+	}, nil)
+}
+
+// gopConstType returns the type of the constant expression e in a
+// neutral context, that is, without the type the context of e may have
+// imposed on it (see arguments).
+func gopConstType(info *typesutil.Info, e ast.Expr) types.Type {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.Typ[types.UntypedInt]
+		case token.CHAR:
+			return types.Typ[types.UntypedRune]
+		case token.FLOAT:
+			return types.Typ[types.UntypedFloat]
+		case token.IMAG:
+			return types.Typ[types.UntypedComplex]
+		case token.STRING:
+			return types.Typ[types.UntypedString]
+		}
+
+	case *ast.ParenExpr:
+		return gopConstType(info, e.X)
+
+	case *ast.Ident:
+		if c, ok := info.Uses[e].(*types.Const); ok {
+			return c.Type()
+		}
+
+	case *ast.SelectorExpr:
+		if c, ok := info.Uses[e.Sel].(*types.Const); ok {
+			return c.Type()
+		}
+
+	case *ast.UnaryExpr:
+		return gopConstType(info, e.X)
+
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return types.Typ[types.UntypedBool]
+		case token.SHL, token.SHR:
+			return gopConstType(info, e.X)
+		}
+		x, y := gopConstType(info, e.X), gopConstType(info, e.Y)
+		if !isBasic(x, types.IsUntyped) {
+			return x
+		}
+		if !isBasic(y, types.IsUntyped) {
+			return y
+		}
+		// Both untyped: the "larger" numeric kind wins (int < rune
+		// < float < complex).
+		if isBasic(x, types.IsNumeric) && isBasic(y, types.IsNumeric) &&
+			y.(*types.Basic).Kind() > x.(*types.Basic).Kind() {
+			return y
+		}
+		return x
+
+	case *ast.CallExpr:
+		if tv := info.Types[e.Fun]; tv.IsType() {
+			return tv.Type // conversion T(x)
+		}
+	}
+	return info.TypeOf(e)
+}
+
+// gopParseCaller parses src, the new content of the caller file.
+func gopParseCaller(caller *Caller, src []byte) (*ast.File, error) {
+	mode := parser.ParseComments | parser.SkipObjectResolution | parser.AllErrors
+	if caller.File.IsClass {
+		mode |= parser.ParseGoPlusClass
+	}
+	filename := caller.Fset.File(caller.Call.Pos()).Name()
+	f, err := parser.ParseFile(caller.Fset, filename, src, mode)
+	if f != nil {
+		f.IsProj, f.IsClass, f.IsNormalGox = caller.File.IsProj, caller.File.IsClass, caller.File.IsNormalGox
+	}
+	return f, err
+}
+
+// gopDeleteUnusedImports deletes from f, the new syntax of the caller
+// file, the imports that the caller used but f no longer does. It
+// reports whether any import was deleted.
+func gopDeleteUnusedImports(caller *Caller, f *ast.File) (deleted bool) {
+	used := make(map[*types.PkgName]bool)
+	for _, obj := range caller.Info.Uses {
+		if pkgname, ok := obj.(*types.PkgName); ok {
+			used[pkgname] = true
+		}
+	}
+	type importKey struct{ name, path string }
+	var unused []importKey
+	for _, imp := range caller.File.Imports {
+		pkgname, ok := importedPkgName(caller.Info, imp)
+		if !ok || !used[pkgname] || pkgname.Name() == "_" || pkgname.Name() == "." {
+			continue
+		}
+		if !gopUsesPkgName(f, pkgname.Name()) {
+			var name string
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			unused = append(unused, importKey{name, pkgname.Imported().Path()})
+		}
+	}
+	for _, imp := range unused {
+		if astutil.DeleteNamedImport(caller.Fset, f, imp.name, imp.path) {
+			deleted = true
+		}
+	}
+	return deleted
+}
+
+// gopUsesPkgName reports whether f may refer to the package imported as
+// name, that is, whether it has a selection name.x.
+func gopUsesPkgName(f *ast.File, name string) (used bool) {
+	for _, decl := range f.Decls {
+		astutil.Apply(decl, func(c *astutil.Cursor) bool {
+			if sel, ok := c.Node().(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok && id.Name == name {
+					used = true
+				}
+			}
+			return !used
+		}, nil)
+	}
+	return used
+}
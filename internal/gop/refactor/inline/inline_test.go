@@ -0,0 +1,1015 @@
+// Copyright 2023 The GoPlus Authors (goplus.org). All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/types"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gogen/packages"
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+	"golang.org/x/tools/internal/gop/refactor/inline"
+)
+
+// A testcase inlines the first call to f in caller, a Go+ file. f is
+// declared in callee, a Go+ file of the same package, or is q.F, declared
+// in the Go package q, if goCallee is set.
+type testcase struct {
+	descr          string
+	callee, caller string // Go+ source files (sans package decl) of caller, callee
+	want           string // expected new portion of caller file, or "error: regexp"
+	goCallee       bool   // callee is the Go source of package q
+}
+
+func TestErrors(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "A shadowed Go+ builtin can't be referenced.",
+			callee: `func f(s string) { echo s }`,
+			caller: `func _() { echo := 0; _ = echo; f "hi" }`,
+			want:   `error: built-in "echo" is shadowed`,
+		},
+	})
+}
+
+func TestBasics(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Basic",
+			callee: `func f(x int) int { return x }`,
+			caller: `var _ = f(0)`,
+			want:   `var _ = 0`,
+		},
+		{
+			descr:  "Empty body, some arg effects.",
+			callee: `func f(x, y, z int) {}`,
+			caller: `func _() { f(1, recover().(int), 3) }`,
+			want:   `func _() { _ = recover().(int) }`,
+		},
+		{
+			descr:  "Non-duplicable arguments are bound by a var decl.",
+			callee: `func f(s string, i int) { println(s, s, i, i) }`,
+			caller: `func _() { f("hi", 0) }`,
+			want: `func _() {
+	var s string = "hi"
+	println(s, s, 0, 0)
+}`,
+		},
+		{
+			descr:  "Tail call.",
+			callee: `func f(x int) int { if x > 0 { return x }; return -x }`,
+			caller: `func _(y int) int { return f(y) }`,
+			want: `func _(y int) int {
+	if y > 0 {
+		return y
+	}
+	return -y
+}`,
+		},
+		{
+			descr:  "Implicit return conversions defeat reduction of return statements.",
+			callee: `func f(x int16) any { return x }`,
+			caller: `var _ = f(1)`,
+			want:   `var _ = func() any { return int16(1) }()`,
+		},
+	})
+}
+
+func TestExprStmtReduction(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "A call in an unrestricted ExprStmt may be replaced by the body stmts.",
+			callee: `func f() { var _ = len("") }`,
+			caller: `func _() { f() }`,
+			want:   `func _() { var _ = len("") }`,
+		},
+		{
+			descr:  "ExprStmts in the body of a switch case are unrestricted.",
+			callee: `func f() { x := 1; print(x) }`,
+			caller: `func _() { switch { case true: f() } }`,
+			want: `func _() {
+	switch {
+	case true:
+		x := 1
+		print(x)
+	}
+}`,
+		},
+		{
+			descr:  "ExprStmts in the body of a select case are unrestricted.",
+			callee: `func f() { x := 1; print(x) }`,
+			caller: `func _() { select { default: f() } }`,
+			want: `func _() {
+	select {
+	default:
+		x := 1
+		print(x)
+	}
+}`,
+		},
+		{
+			descr:  "Some ExprStmt contexts are restricted to simple statements.",
+			callee: `func f() { var _ = len("") }`,
+			caller: `func _(cond bool) { if f(); cond {} }`,
+			want: `func _(cond bool) {
+	if func() { var _ = len("") }(); cond {
+	}
+}`,
+		},
+		{
+			descr:  "Braces must be preserved to avoid a name conflict (decl before).",
+			callee: `func f() { x := 1; print(x) }`,
+			caller: `func _() { x := 2; print(x); f() }`,
+			want: `func _() {
+	x := 2
+	print(x)
+	{
+		x := 1
+		print(x)
+	}
+}`,
+		},
+		{
+			descr:  "Braces must be preserved to avoid a name conflict (decl after).",
+			callee: `func f() { x := 1; print(x) }`,
+			caller: `func _() { f(); x := 2; print(x) }`,
+			want: `func _() {
+	{
+		x := 1
+		print(x)
+	}
+	x := 2
+	print(x)
+}`,
+		},
+		{
+			descr:  "Braces must be preserved to avoid a forward jump across a decl.",
+			callee: `func f() { x := 1; print(x) }`,
+			caller: `func _() { goto label; f(); label: }`,
+			want: `func _() {
+	goto label
+	{
+		x := 1
+		print(x)
+	}
+label:
+}`,
+		},
+	})
+}
+
+func TestPrecedenceParens(t *testing.T) {
+	// Ensure that parens are inserted when (and only when) necessary
+	// around the replacement for the call expression. (This is a special
+	// case in the way the inliner uses a combination of AST formatting
+	// for the call and text splicing for the rest of the file.)
+	runTests(t, []testcase{
+		{
+			descr:  "Multiplication in addition context (no parens).",
+			callee: `func f(x, y int) int { return x * y }`,
+			caller: `func _() { _ = 1 + f(2, 3) }`,
+			want:   `func _() { _ = 1 + 2*3 }`,
+		},
+		{
+			descr:  "Addition in multiplication context (parens).",
+			callee: `func f(x, y int) int { return x + y }`,
+			caller: `func _() { _ = 1 * f(2, 3) }`,
+			want:   `func _() { _ = 1 * (2 + 3) }`,
+		},
+		{
+			descr:  "Addition in negation context (parens).",
+			callee: `func f(x, y int) int { return x + y }`,
+			caller: `func _() { _ = -f(1, 2) }`,
+			want:   `func _() { _ = -(1 + 2) }`,
+		},
+		{
+			descr:  "Addition in call context (no parens).",
+			callee: `func f(x, y int) int { return x + y }`,
+			caller: `func _() { println(f(1, 2)) }`,
+			want:   `func _() { println(1 + 2) }`,
+		},
+		{
+			descr:  "Addition in slice operand context (parens).",
+			callee: `func f(x, y string) string { return x + y }`,
+			caller: `func _() { _ = f("x",  "y")[1:2] }`,
+			want:   `func _() { _ = ("x" + "y")[1:2] }`,
+		},
+		{
+			descr:  "String literal in slice operand context (no parens).",
+			callee: `func f(x string) string { return x }`,
+			caller: `func _() { _ = f("xy")[1:2] }`,
+			want:   `func _() { _ = "xy"[1:2] }`,
+		},
+	})
+}
+
+func TestSubstitution(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Arg to unref'd param can be eliminated if has no effects.",
+			callee: `func f(x, y int) {}; var global int`,
+			caller: `func _() { f(0, global) }`,
+			want:   `func _() {}`,
+		},
+		{
+			descr:  "But not if it may contain last reference to a caller local var.",
+			callee: `func f(int) {}`,
+			caller: `func _() { var local int; f(local) }`,
+			want:   `func _() { var local int; _ = local }`,
+		},
+		{
+			descr:  "Regression test for detection of shadowing in nested functions.",
+			callee: `func f(x int) { _ = func() { y := 1; print(y); print(x) } }`,
+			caller: `func _(y int) { f(y) } `,
+			want: `func _(y int) {
+	var x int = y
+	_ = func() { y := 1; print(y); print(x) }
+}`,
+		},
+	})
+}
+
+func TestTailCallStrategy(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Tail call.",
+			callee: `func f() int { return 1 }`,
+			caller: `func _() int { return f() }`,
+			want:   `func _() int { return 1 }`,
+		},
+		{
+			descr:  "Void tail call.",
+			callee: `func f() { println() }`,
+			caller: `func _() { f() }`,
+			want:   `func _() { println() }`,
+		},
+		{
+			descr:  "Void tail call with defer.", // => literalized
+			callee: `func f() { defer f(); println() }`,
+			caller: `func _() { f() }`,
+			want:   `func _() { func() { defer f(); println() }() }`,
+		},
+		// Tests for issue #63336:
+		{
+			descr:  "Tail call with non-trivial return conversion (caller.sig = callee.sig).",
+			callee: `func f() error { if true { return nil } else { return e } }; var e struct{error}`,
+			caller: `func _() error { return f() }`,
+			want: `func _() error {
+	if true {
+		return nil
+	} else {
+		return e
+	}
+}`,
+		},
+		{
+			descr:  "Tail call with non-trivial return conversion (caller.sig != callee.sig).",
+			callee: `func f() error { return E{} }; type E struct{error}`,
+			caller: `func _() any { return f() }`,
+			want:   `func _() any { return func() error { return E{} }() }`,
+		},
+	})
+}
+
+func TestSpreadCalls(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr: "Edge case: cannot literalize spread method call.",
+			callee: `type I int
+ 			func g() (I, I)
+			func (r I) f(x, y I) I {
+				defer g() // force literalization
+				return x + y + r
+			}`,
+			caller: `func _() I { return recover().(I).f(g()) }`,
+			want:   `error: can't yet inline spread call to method`,
+		},
+		{
+			descr:  "Spread argument evaluated for effect.",
+			callee: `func f(int, int) {}; func g() (int, int)`,
+			caller: `func _() { f(g())  }`,
+			want:   `func _() { _, _ = g() }`,
+		},
+		{
+			descr:  "Edge case: receiver and spread argument, both evaluated for effect.",
+			callee: `type T int; func (T) f(int, int) {}; func g() (int, int)`,
+			caller: `func _() { T(0).f(g())  }`,
+			want: `func _() {
+	var (
+		_    = T(0)
+		_, _ = g()
+	)
+}`,
+		},
+	})
+}
+
+func TestVariadic(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Variadic cancellation (basic).",
+			callee: `func f(args ...any) { defer f(&args); println(args) }`,
+			caller: `func _(slice []any) { f(slice...) }`,
+			want:   `func _(slice []any) { func(args []any) { defer f(&args); println(args) }(slice) }`,
+		},
+		{
+			descr:  "Variadic cancellation (literalization with parameter elimination).",
+			callee: `func f(args ...any) { defer f(); println(args) }`,
+			caller: `func _(slice []any) { f(slice...) }`,
+			want:   `func _(slice []any) { func() { defer f(); println(slice) }() }`,
+		},
+		{
+			descr:  "Variadic cancellation (reduction).",
+			callee: `func f(args ...any) { println(args) }`,
+			caller: `func _(slice []any) { f(slice...) }`,
+			want:   `func _(slice []any) { println(slice) }`,
+		},
+		{
+			// defer => literalization
+			descr:  "Variadic elimination (literalization).",
+			callee: `func f(x any, rest ...any) { defer println(x, rest) }`,
+			caller: `func _() { f(1, 2, 3) }`,
+			want:   `func _() { func() { defer println(any(1), []any{2, 3}) }() }`,
+		},
+		{
+			descr:  "Variadic elimination (reduction).",
+			callee: `func f(x int, rest ...int) { println(x, rest) }`,
+			caller: `func _() { f(1, 2, 3) }`,
+			want:   `func _() { println(1, []int{2, 3}) }`,
+		},
+		{
+			descr:  "Spread call to variadic (1 arg, 1 param).",
+			callee: `func f(rest ...int) { println(rest) }; func g() (a, b int)`,
+			caller: `func _() { f(g()) }`,
+			want:   `func _() { func(rest ...int) { println(rest) }(g()) }`,
+		},
+		{
+			descr:  "Spread call to variadic (1 arg, 2 params).",
+			callee: `func f(x int, rest ...int) { println(x, rest) }; func g() (a, b int)`,
+			caller: `func _() { f(g()) }`,
+			want:   `func _() { func(x int, rest ...int) { println(x, rest) }(g()) }`,
+		},
+		{
+			descr:  "Spread call to variadic (1 arg, 3 params).",
+			callee: `func f(x, y int, rest ...int) { println(x, y, rest) }; func g() (a, b, c int)`,
+			caller: `func _() { f(g()) }`,
+			want:   `func _() { func(x, y int, rest ...int) { println(x, y, rest) }(g()) }`,
+		},
+	})
+}
+
+func TestParameterBindingDecl(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "IncDec counts as assignment.",
+			callee: `func f(x int) { x++ }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	var x int = 1
+	x++
+}`,
+		},
+		{
+			descr:  "Binding declaration (x, y, z eliminated).",
+			callee: `func f(w, x, y any, z int) { println(w, y, z) }; func g(int) int`,
+			caller: `func _() { f(g(0), g(1), g(2), g(3)) }`,
+			want: `func _() {
+	var w, _ any = g(0), g(1)
+	println(w, any(g(2)), g(3))
+}`,
+		},
+		{
+			descr:  "Reduction of stmt-context call to { return exprs }, with substitution",
+			callee: `func f(ch chan int) int { return <-ch }; func g() chan int`,
+			caller: `func _() { f(g()) }`,
+			want:   `func _() { <-g() }`,
+		},
+		{
+			// Same again, with callee effects:
+			descr:  "Binding decl in reduction of stmt-context call to { return exprs }",
+			callee: `func f(x int) int { return <-h(g(2), x) }; func g(int) int; func h(int, int) chan int`,
+			caller: `func _() { f(g(1)) }`,
+			want: `func _() {
+	var x int = g(1)
+	<-h(g(2), x)
+}`,
+		},
+		// goxls: the upstream "No binding decl due to shadowing of int"
+		// case is omitted: the Go+ checker rejects a parameter named int
+		// ("int is not a type") in func f(int, y any, z int).
+	})
+}
+
+func TestEmbeddedFields(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Embedded fields in x.f method selection (direct).",
+			callee: `type T int; func (t T) f() { print(t) }; type U struct{ T }`,
+			caller: `func _(u U) { u.f() }`,
+			want:   `func _(u U) { print(u.T) }`,
+		},
+		{
+			descr:  "Embedded fields in x.f method selection (implicit *).",
+			callee: `type ( T int; U struct{*T}; V struct {U} ); func (t T) f() { print(t) }`,
+			caller: `func _(v V) { v.f() }`,
+			want:   `func _(v V) { print(*v.U.T) }`,
+		},
+		{
+			descr:  "Embedded fields in x.f method selection (implicit &).",
+			callee: `type ( T int; U struct{T}; V struct {U} ); func (t *T) f() { print(t) }`,
+			caller: `func _(v V) { v.f() }`,
+			want:   `func _(v V) { print(&v.U.T) }`,
+		},
+		// Now the same tests again with T.f(recv).
+		{
+			descr:  "Embedded fields in T.f method selection.",
+			callee: `type T int; func (t T) f() { print(t) }; type U struct{ T }`,
+			caller: `func _(u U) { U.f(u) }`,
+			want:   `func _(u U) { print(u.T) }`,
+		},
+		{
+			descr:  "Embedded fields in T.f method selection (implicit *).",
+			callee: `type ( T int; U struct{*T}; V struct {U} ); func (t T) f() { print(t) }`,
+			caller: `func _(v V) { V.f(v) }`,
+			want:   `func _(v V) { print(*v.U.T) }`,
+		},
+		{
+			descr:  "Embedded fields in (*T).f method selection.",
+			callee: `type ( T int; U struct{T}; V struct {U} ); func (t *T) f() { print(t) }`,
+			caller: `func _(v V) { (*V).f(&v) }`,
+			want:   `func _(v V) { print(&(&v).U.T) }`,
+		},
+		{
+			// x is a single-assign var, and x.f does not load through a pointer
+			// (despite types.Selection.Indirect=true), so x is pure.
+			descr:  "No binding decl is required for recv in method-to-method calls.",
+			callee: `type T struct{}; func (x *T) f() { g(); print(*x) }; func g()`,
+			caller: `func (x *T) _() { x.f() }`,
+			want: `func (x *T) _() {
+	g()
+	print(*x)
+}`,
+		},
+		{
+			// goxls: in Go+, var x *T = &x refers to itself, so the
+			// binding decl would shadow the receiver x.
+			descr:  "Same, with implicit &recv.",
+			callee: `type T struct{}; func (x *T) f() { g(); print(*x) }; func g()`,
+			caller: `func (x T) _() { x.f() }`,
+			want:   `func (x T) _() { func(x *T) { g(); print(*x) }(&x) }`,
+		},
+	})
+}
+
+func TestSubstitutionPreservesArgumentEffectOrder(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Arguments have effects, but parameters are evaluated in order.",
+			callee: `func f(a, b, c int) { print(a, b, c) }; func g(int) int`,
+			caller: `func _() { f(g(1), g(2), g(3)) }`,
+			want:   `func _() { print(g(1), g(2), g(3)) }`,
+		},
+		{
+			descr:  "Arguments have effects, and parameters are evaluated out of order.",
+			callee: `func f(a, b, c int) { print(a, c, b) }; func g(int) int`,
+			caller: `func _() { f(g(1), g(2), g(3)) }`,
+			want: `func _() {
+	var a, b int = g(1), g(2)
+	print(a, g(3), b)
+}`,
+		},
+		{
+			descr:  "Pure arguments may commute with argument that have effects.",
+			callee: `func f(a, b, c int) { print(a, c, b) }; func g(int) int`,
+			caller: `func _() { f(g(1), 2, g(3)) }`,
+			want:   `func _() { print(g(1), g(3), 2) }`,
+		},
+		{
+			descr:  "Impure arguments may commute with each other.",
+			callee: `func f(a, b, c, d int) { print(a, c, b, d) }; func g(int) int; var x, y int`,
+			caller: `func _() { f(g(1), x, y, g(2)) }`,
+			want:   `func _() { print(g(1), y, x, g(2)) }`,
+		},
+		{
+			descr:  "Impure arguments do not commute with arguments that have effects (1)",
+			callee: `func f(a, b, c, d int) { print(a, c, b, d) }; func g(int) int; var x, y int`,
+			caller: `func _() { f(g(1), g(2), y, g(3)) }`,
+			want: `func _() {
+	var a, b int = g(1), g(2)
+	print(a, y, b, g(3))
+}`,
+		},
+		{
+			descr:  "Impure arguments do not commute with those that have effects (2).",
+			callee: `func f(a, b, c, d int) { print(a, c, b, d) }; func g(int) int; var x, y int`,
+			caller: `func _() { f(g(1), y, g(2), g(3)) }`,
+			want: `func _() {
+	var a, b int = g(1), y
+	print(a, g(2), b, g(3))
+}`,
+		},
+		{
+			descr:  "Callee effects commute with pure arguments.",
+			callee: `func f(a, b, c int) { print(a, c, recover().(int), b) }; func g(int) int`,
+			caller: `func _() { f(g(1), 2, g(3)) }`,
+			want:   `func _() { print(g(1), g(3), recover().(int), 2) }`,
+		},
+		{
+			descr:  "Callee reads may commute with impure arguments.",
+			callee: `func f(a, b int) { print(a, x, b) }; func g(int) int; var x, y int`,
+			caller: `func _() { f(g(1), y) }`,
+			want:   `func _() { print(g(1), x, y) }`,
+		},
+		{
+			descr:  "All impure parameters preceding a read hazard must be kept.",
+			callee: `func f(a, b, c int) { print(a, b, recover().(int), c) }; var x, y, z int`,
+			caller: `func _() { f(x, y, z) }`,
+			want: `func _() {
+	var c int = z
+	print(x, y, recover().(int), c)
+}`,
+		},
+		{
+			descr:  "All parameters preceding a write hazard must be kept.",
+			callee: `func f(a, b, c int) { print(a, b, recover().(int), c) }; func g(int) int; var x, y, z int`,
+			caller: `func _() { f(x, y, g(0))  }`,
+			want: `func _() {
+	var a, b, c int = x, y, g(0)
+	print(a, b, recover().(int), c)
+}`,
+		},
+		{
+			descr:  "[W1 R0 W2 W4 R3] -- test case for second iteration of effect loop",
+			callee: `func f(a, b, c, d, e int) { print(b, a, c, e, d) }; func g(int) int; var x, y int`,
+			caller: `func _() { f(x, g(1), g(2), y, g(3))  }`,
+			want: `func _() {
+	var a, b, c, d int = x, g(1), g(2), y
+	print(b, a, c, g(3), d)
+}`,
+		},
+		{
+			// In this example, the set() call is rejected as a substitution
+			// candidate due to a shadowing conflict (x). This must entail that the
+			// selection x.y (R) is also rejected, because it is lower numbered.
+			//
+			// Incidentally this program (which panics when executed) illustrates
+			// that although effects occur left-to-right, read operations such
+			// as x.y are not ordered wrt writes, depending on the compiler.
+			// Changing x.y to identity(x).y forces the ordering and avoids the panic.
+			//
+			// goxls: set is not generic, as Go+ can't declare generic funcs.
+			// In Go+, var x, y int = x.y, ... refers to itself, so the
+			// binding decl would shadow x and the call is literalized.
+			descr:  "Hazards with args already rejected (e.g. due to shadowing) are detected too.",
+			callee: `func f(x, y int) int { return x + y }; func set(ptr **struct{ y int }, old, new *struct{ y int }) int { println(old); *ptr = new; return 0; }`,
+			caller: `func _() { x := new(struct{ y int }); f(x.y, set(&x, x, nil)) }`,
+			want:   `func _() { x := new(struct{ y int }); func(x, y int) int { return x + y }(x.y, set(&x, x, nil)) }`,
+		},
+		{
+			// Rejection of a later parameter for reasons other than callee
+			// effects (e.g. escape) may create hazards with lower-numbered
+			// parameters that require them to be rejected too.
+			descr:  "Hazards with already eliminated parameters (variant)",
+			callee: `func f(x, y int) { _ = &y }; func g(int) int`,
+			caller: `func _() { f(g(1), g(2)) }`,
+			want: `func _() {
+	var _, y int = g(1), g(2)
+	_ = &y
+}`,
+		},
+		{
+			// In this case g(2) is rejected for substitution because it is
+			// unreferenced but has effects, so parameter x must also be rejected
+			// so that its argument v can be evaluated earlier in the binding decl.
+			descr:  "Hazards with already eliminated parameters (unreferenced fx variant)",
+			callee: `func f(x, y int) { _ = x }; func g(int) int; var v int`,
+			caller: `func _() { f(v, g(2)) }`,
+			want: `func _() {
+	var x, _ int = v, g(2)
+	_ = x
+}`,
+		},
+		{
+			descr:  "Defer f() evaluates f() before unknown effects",
+			callee: `func f(int, y any, z int) { defer println(int, y, z) }; func g(int) int`,
+			caller: `func _() { f(g(1), g(2), g(3)) }`,
+			want:   `func _() { func() { defer println(any(g(1)), any(g(2)), g(3)) }() }`,
+		},
+	})
+}
+
+func TestNamedResultVars(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Stmt-context call to {return g()} that mentions named result.",
+			callee: `func f() (x int) { return g(x) }; func g(int) int`,
+			caller: `func _() { f() }`,
+			want: `func _() {
+	var x int
+	g(x)
+}`,
+		},
+		{
+			descr:  "Ditto, with binding decl again.",
+			callee: `func f(y string) (x int) { return x+x+len(y+y) }`,
+			caller: `func _() { f(".") }`,
+			want: `func _() {
+	var (
+		y string = "."
+		x int
+	)
+	_ = x + x + len(y+y)
+}`,
+		},
+
+		{
+			descr:  "Ditto, with binding decl (due to repeated y refs).",
+			callee: `func f(y string) (x string) { return x+y+y }`,
+			caller: `func _() { f(".") }`,
+			want: `func _() {
+	var (
+		y string = "."
+		x string
+	)
+	_ = x + y + y
+}`,
+		},
+		{
+			descr:  "Stmt-context call to {return binary} that mentions named result.",
+			callee: `func f() (x int) { return x+x }`,
+			caller: `func _() { f() }`,
+			want: `func _() {
+	var x int
+	_ = x + x
+}`,
+		},
+		{
+			descr:  "Tail call to {return expr} that mentions named result.",
+			callee: `func f() (x int) { return x }`,
+			caller: `func _() int { return f() }`,
+			want:   `func _() int { return func() (x int) { return x }() }`,
+		},
+		{
+			descr:  "Tail call to {return} that implicitly reads named result.",
+			callee: `func f() (x int) { return }`,
+			caller: `func _() int { return f() }`,
+			want:   `func _() int { return func() (x int) { return }() }`,
+		},
+		{
+			descr:  "Spread-context call to {return expr} that mentions named result.",
+			callee: `func f() (x, y int) { return x, y }`,
+			caller: `func _() { var _, _ = f() }`,
+			want:   `func _() { var _, _ = func() (x, y int) { return x, y }() }`,
+		},
+		{
+			descr:  "Shadowing in binding decl for named results => literalization.",
+			callee: `func f(y string) (x y) { return x+x+len(y+y) }; type y = int`,
+			caller: `func _() { f(".") }`,
+			want:   `func _() { func(y string) (x y) { return x + x + len(y+y) }(".") }`,
+		},
+	})
+}
+
+func TestSubstitutionPreservesParameterType(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Substitution preserves argument type (#63193).",
+			callee: `func f(x int16) { y := x; _ = (*int16)(&y) }`,
+			caller: `func _() { f(1) }`,
+			want: `func _() {
+	y := int16(1)
+	_ = (*int16)(&y)
+}`,
+		},
+		{
+			descr:  "Same, with non-constant (unnamed to named struct) conversion.",
+			callee: `func f(x T) { y := x; _ = (*T)(&y) }; type T struct{}`,
+			caller: `func _() { f(struct{}{}) }`,
+			want: `func _() {
+	y := T(struct{}{})
+	_ = (*T)(&y)
+}`,
+		},
+		{
+			descr:  "Same, with non-constant (chan to <-chan) conversion.",
+			callee: `func f(x T) { y := x; _ = (*T)(&y) }; type T = <-chan int; var ch chan int`,
+			caller: `func _() { f(ch) }`,
+			want: `func _() {
+	y := T(ch)
+	_ = (*T)(&y)
+}`,
+		},
+		{
+			descr:  "Same, with untyped nil to typed nil conversion.",
+			callee: `func f(x *int) { y := x; _ = (**int)(&y) }`,
+			caller: `func _() { f(nil) }`,
+			want: `func _() {
+	y := (*int)(nil)
+	_ = (**int)(&y)
+}`,
+		},
+		{
+			descr:  "Conversion of untyped int to named type is made explicit.",
+			callee: `type T int; func (x T) f() { x.g() }; func (T) g() {}`,
+			caller: `func _() { T.f(1) }`,
+			want:   `func _() { T(1).g() }`,
+		},
+		{
+			descr:  "Check for shadowing error on type used in the conversion.",
+			callee: `func f(x T) { _ = &x == (*T)(nil) }; type T int16`,
+			caller: `func _() { type T bool; f(1) }`,
+			want:   `error: T.*shadowed.*by.*type`,
+		},
+	})
+}
+
+func TestGopSyntax(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Command-style call.",
+			callee: `func f(x int) { println(x) }`,
+			caller: `func _() { f 1 }`,
+			want:   `func _() { println(1) }`,
+		},
+		{
+			descr:  "Go+ builtin.",
+			callee: `func f(s string) { echo s }`,
+			caller: `func _() { f "hi" }`,
+			want:   `func _() { echo "hi" }`,
+		},
+		{
+			descr:  "Lambdas are bound, not substituted.",
+			callee: `func f(g func(int) int) { println(g(1)) }`,
+			caller: `func _() { f(x => x * 2) }`,
+			want: `func _() {
+	var g func(int) int = x => x * 2
+	println(g(1))
+}`,
+		},
+		{
+			descr:  "Lambdas in expression context are literalized.",
+			callee: `func f(g func(int) int) int { return g(1) }`,
+			caller: `var _ = f(x => x * 2)`,
+			want:   `var _ = func(g func(int) int) int { return g(1) }(x => x * 2)`,
+		},
+		{
+			descr:  "Substitution in a string literal.",
+			callee: `func f(name string) string { return "Hi, ${name}!" }`,
+			caller: `func _(user string) { _ = f(user) }`,
+			want:   `func _(user string) { _ = "Hi, ${user}!" }`,
+		},
+		{
+			descr:  "String arguments are not substituted in a string literal.",
+			callee: `func f(name string) string { return "Hi, ${name}!" }`,
+			caller: `var _ = f("Go+")`,
+			want:   `var _ = func(name string) string { return "Hi, ${name}!" }("Go+")`,
+		},
+	})
+}
+
+func TestGoCallee(t *testing.T) {
+	runTests(t, []testcase{
+		{
+			descr:  "Go function called from Go+.",
+			callee: `import "fmt"; func F(name string) { fmt.Println("Hello, " + name) }`,
+			caller: `import "q"; func _() { q.F "Go+" }`,
+			want: `import fmt "fmt"
+
+func _() { fmt.Println("Hello, " + "Go+") }`,
+			goCallee: true,
+		},
+	})
+}
+
+const (
+	funcName   = "f" // callee declared in the Go+ callee file
+	goFuncName = "F" // callee declared in the Go package q
+)
+
+func runTests(t *testing.T, tests []testcase) {
+	for _, test := range tests {
+		test := test
+		t.Run(test.descr, func(t *testing.T) {
+			fset := token.NewFileSet()
+			mustParse := func(filename string, content any) *ast.File {
+				f, err := parser.ParseFile(fset, filename, content, parser.ParseComments|parser.SkipObjectResolution)
+				if err != nil {
+					t.Fatalf("ParseFile: %v", err)
+				}
+				return f
+			}
+
+			// Type check the Go callee, if any, as package q.
+			importer := packages.NewImporter(fset)
+			var (
+				goDecl    *goast.FuncDecl
+				goPkg     *types.Package
+				goInfo    *types.Info
+				goContent string
+			)
+			if test.goCallee {
+				goContent = "package q\n" + strings.ReplaceAll(test.callee, "; func", "\nfunc")
+				goFile, err := goparser.ParseFile(fset, "q.go", goContent, goparser.ParseComments)
+				if err != nil {
+					t.Fatalf("ParseFile: %v", err)
+				}
+				for _, d := range goFile.Decls {
+					if d, ok := d.(*goast.FuncDecl); ok && d.Name.Name == goFuncName {
+						goDecl = d
+					}
+				}
+				goInfo = &types.Info{
+					Defs:       make(map[*goast.Ident]types.Object),
+					Uses:       make(map[*goast.Ident]types.Object),
+					Types:      make(map[goast.Expr]types.TypeAndValue),
+					Implicits:  make(map[goast.Node]types.Object),
+					Selections: make(map[*goast.SelectorExpr]*types.Selection),
+					Scopes:     make(map[goast.Node]*types.Scope),
+				}
+				conf := &types.Config{Importer: importer}
+				goPkg, err = conf.Check("q", fset, []*goast.File{goFile}, goInfo)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Parse callee file and find first func decl named f.
+			var (
+				decl          *ast.FuncDecl
+				calleeFiles   []*ast.File
+				calleeContent string
+			)
+			if !test.goCallee {
+				calleeContent = "package p\n" + test.callee
+				calleeFile := mustParse("callee.gop", calleeContent)
+				for _, d := range calleeFile.Decls {
+					if d, ok := d.(*ast.FuncDecl); ok && d.Name.Name == funcName {
+						decl = d
+						break
+					}
+				}
+				if decl == nil {
+					t.Fatalf("declaration of func %s not found: %s", funcName, test.callee)
+				}
+				calleeFiles = append(calleeFiles, calleeFile)
+			}
+
+			// Parse caller file and find first call to f().
+			callerContent := "package p\n" + strings.ReplaceAll(test.caller, "; func", "\nfunc")
+			callerFile := mustParse("caller.gop", callerContent)
+			var call *ast.CallExpr
+			ast.Inspect(callerFile, func(n ast.Node) bool {
+				if n, ok := n.(*ast.CallExpr); ok {
+					switch fun := n.Fun.(type) {
+					case *ast.SelectorExpr:
+						if fun.Sel.Name == goFuncName || fun.Sel.Name == funcName {
+							call = n
+						}
+					case *ast.Ident:
+						if fun.Name == funcName {
+							call = n
+						}
+					}
+				}
+				return call == nil
+			})
+			if call == nil {
+				t.Fatalf("call to %s not found: %s", funcName, test.caller)
+			}
+
+			// Type check both files as one package.
+			info := newInfo()
+			conf := &typesutil.CheckConfig{
+				Importer: importerFunc(func(path string) (*types.Package, error) {
+					if path == "q" && goPkg != nil {
+						return goPkg, nil
+					}
+					return importer.Import(path)
+				}),
+			}
+			pkg, err := conf.Check("p", fset, append([]*ast.File{callerFile}, calleeFiles...), info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Analyze callee and inline call.
+			doIt := func() ([]byte, error) {
+				var callee *inline.Callee
+				var err error
+				if test.goCallee {
+					callee, err = inline.AnalyzeGoCallee(t.Logf, fset, goPkg, goInfo, goDecl, []byte(goContent))
+				} else {
+					callee, err = inline.AnalyzeCallee(t.Logf, fset, pkg, info, decl, []byte(calleeContent))
+				}
+				if err != nil {
+					return nil, err
+				}
+				if err := checkTranscode(callee); err != nil {
+					t.Fatal(err)
+				}
+
+				caller := &inline.Caller{
+					Fset:    fset,
+					Types:   pkg,
+					Info:    info,
+					File:    callerFile,
+					Call:    call,
+					Content: []byte(callerContent),
+				}
+				return inline.Inline(t.Logf, caller, callee)
+			}
+			gotContent, err := doIt()
+
+			// Want error?
+			if rest := strings.TrimPrefix(test.want, "error: "); rest != test.want {
+				if err == nil {
+					t.Fatalf("unexpected sucess: want error matching %q", rest)
+				}
+				msg := err.Error()
+				if ok, err := regexp.MatchString(rest, msg); err != nil {
+					t.Fatalf("invalid regexp: %v", err)
+				} else if !ok {
+					t.Fatalf("wrong error: %s (want match for %q)", msg, rest)
+				}
+				return
+			}
+
+			// Want success.
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Compute a single-hunk line-based diff.
+			srcLines := strings.Split(callerContent, "\n")
+			gotLines := strings.Split(string(gotContent), "\n")
+			for len(srcLines) > 0 && len(gotLines) > 0 &&
+				srcLines[0] == gotLines[0] {
+				srcLines = srcLines[1:]
+				gotLines = gotLines[1:]
+			}
+			for len(srcLines) > 0 && len(gotLines) > 0 &&
+				srcLines[len(srcLines)-1] == gotLines[len(gotLines)-1] {
+				srcLines = srcLines[:len(srcLines)-1]
+				gotLines = gotLines[:len(gotLines)-1]
+			}
+			got := strings.Join(gotLines, "\n")
+
+			if strings.TrimSpace(got) != strings.TrimSpace(test.want) {
+				t.Fatalf("\nInlining this call:\t%s\nof this callee:    \t%s\nproduced:\n%s\nWant:\n\n%s",
+					test.caller,
+					test.callee,
+					got,
+					test.want)
+			}
+
+			// Check that resulting code type-checks.
+			newCallerFile := mustParse("newcaller.gop", gotContent)
+			if _, err := conf.Check("p", fset, append([]*ast.File{newCallerFile}, calleeFiles...), newInfo()); err != nil {
+				t.Fatalf("modified source failed to typecheck: %v <<%s>>", err, gotContent)
+			}
+		})
+	}
+}
+
+func newInfo() *typesutil.Info {
+	return &typesutil.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Overloads:  make(map[*ast.Ident]types.Object),
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// checkTranscode replaces *callee by the results of gob-encoding and
+// then decoding it, to test that these operations are lossless.
+func checkTranscode(callee *inline.Callee) error {
+	// Perform Gob transcoding so that it is exercised by the test.
+	var enc bytes.Buffer
+	if err := gob.NewEncoder(&enc).Encode(callee); err != nil {
+		return fmt.Errorf("internal error: gob encoding failed: %v", err)
+	}
+	*callee = inline.Callee{}
+	if err := gob.NewDecoder(&enc).Decode(callee); err != nil {
+		return fmt.Errorf("internal error: gob decoding failed: %v", err)
+	}
+	return nil
+}